@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+// HandleConfigCommand runs the config subcommand in args, if any, and exits.
+// It runs before the config file is required to load, so that a broken file
+// can still be shown and fixed; configErr is why it failed to load, if it
+// did.
+func HandleConfigCommand(args []string, configErr error) {
+	if len(args) != 2 || args[0] != "config" {
+		return
+	}
+
+	switch args[1] {
+	case "show":
+		exitCommand(showConfig(configErr))
+	case "edit":
+		exitCommand(editConfig())
+	}
+}
+
+// HandleCommand runs the subcommand in args, if any, and exits. Without a
+// subcommand the TUI starts.
+func HandleCommand(args []string) {
+	if len(args) == 0 {
+		return
+	}
+
+	i18nService := di.GetService[*services.I18nService](di.I18nServiceKey)
+
+	if args[0] == "history" {
+		exitCommand(showHistory(args[1:]))
+	}
+
+	fmt.Fprintf(os.Stderr, "%s\n", i18nService.TranslateWithData("command_unknown", map[string]interface{}{"Command": strings.Join(args, " ")}))
+	os.Exit(2)
+}
+
+// exitCommand exits with the error of a subcommand, if any.
+func exitCommand(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// showConfig prints the effective settings or, when the config file failed
+// to load, why.
+func showConfig(configErr error) error {
+	configService := di.GetService[*services.ConfigService](di.ConfigServiceKey)
+
+	fmt.Printf("# %s\n", configService.FilePath())
+	if configErr != nil {
+		return configErr
+	}
+
+	rendered, err := configService.Render()
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	return nil
+}
+
+// editConfig opens the config file in the user's editor, creating it with
+// the defaults first, and checks the result.
+func editConfig() error {
+	configService := di.GetService[*services.ConfigService](di.ConfigServiceKey)
+	if err := configService.EnsureFile(); err != nil {
+		return err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], configService.FilePath())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %q: %w", editor, err)
+	}
+
+	return configService.Load()
+}
+
+// showHistory prints the most recent runs matching the filter in args,
+// formatted as JSON with --json.
+func showHistory(args []string) error {
+	i18nService := di.GetService[*services.I18nService](di.I18nServiceKey)
+	historyService := di.GetService[*services.HistoryService](di.HistoryServiceKey)
+
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, i18nService.Translate("flags_history_json"))
+	limit := flags.Int("limit", 20, i18nService.Translate("flags_history_limit"))
+	// Flags may come after the words of the filter.
+	var words []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil
+			}
+			return err
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		words = append(words, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+
+	records, err := historyService.List(strings.Join(words, " "), *limit)
+	if err != nil {
+		return err
+	}
+	return writeHistory(os.Stdout, records, *asJSON)
+}
+
+// writeHistory writes one line per run, or the runs as a JSON array.
+func writeHistory(w io.Writer, records []models.HistoryRecord, asJSON bool) error {
+	if asJSON {
+		if records == nil {
+			records = []models.HistoryRecord{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	for _, record := range records {
+		status := "✓"
+		if !record.Succeeded() {
+			status = "✗"
+		}
+		command, _, _ := strings.Cut(record.Command, "\n")
+		fmt.Fprintf(w, "%s %s %4d %8s  %s  %s\n",
+			record.StartedAt.Local().Format("2006-01-02 15:04:05"),
+			status,
+			record.ExitCode,
+			record.Duration().Round(time.Millisecond),
+			record.Title,
+			command)
+	}
+	return nil
+}
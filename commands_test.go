@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+func TestWriteHistory(t *testing.T) {
+	records := []models.HistoryRecord{
+		{
+			ItemID:     "item_1",
+			Title:      "Deploy",
+			Command:    "make deploy\nmake smoke",
+			Variables:  map[string]string{"stage": "prod"},
+			Cwd:        "/srv/api",
+			StartedAt:  time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			DurationMS: 1500,
+			ExitCode:   2,
+		},
+	}
+
+	var text bytes.Buffer
+	if err := writeHistory(&text, records, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if line := text.String(); !strings.Contains(line, "✗    2     1.5s  Deploy  make deploy\n") {
+		t.Errorf("Expected one line for the run, got %q", line)
+	}
+
+	var output bytes.Buffer
+	if err := writeHistory(&output, records, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded []models.HistoryRecord
+	if err := json.Unmarshal(output.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", output.String(), err)
+	}
+	if len(decoded) != 1 || decoded[0].Variables["stage"] != "prod" || decoded[0].Cwd != "/srv/api" {
+		t.Errorf("Expected the record back, got %+v", decoded)
+	}
+
+	output.Reset()
+	if err := writeHistory(&output, nil, true); err != nil || strings.TrimSpace(output.String()) != "[]" {
+		t.Errorf("Expected an empty array without runs, got %q (%v)", output.String(), err)
+	}
+}
+
+func TestEditConfig_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("theme = [\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Setenv(services.ConfigFileEnv, configPath)
+	t.Setenv("VISUAL", "")
+
+	configService, err := services.NewConfigService("go-workflows", services.NewValidationService())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := configService.Load(); err == nil {
+		t.Fatal("Expected the invalid config file to fail to load")
+	}
+	di.RegisterService(di.ConfigServiceKey, configService)
+
+	// The editor gets the path of the config file and replaces it.
+	editor := filepath.Join(dir, "editor")
+	script := "#!/bin/sh\necho \"$1\" > \"$1.opened\"\necho 'theme = \"dark\"' > \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0o755); err != nil {
+		t.Fatalf("Failed to write editor: %v", err)
+	}
+	t.Setenv("EDITOR", editor)
+
+	if err := editConfig(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opened, _ := os.ReadFile(configPath + ".opened"); strings.TrimSpace(string(opened)) != configPath {
+		t.Errorf("Expected the editor to open %s, got %q", configPath, opened)
+	}
+	if theme := configService.Config().Theme; theme != "dark" {
+		t.Errorf("Expected the edited config to load, got theme %q", theme)
+	}
+
+	// Saving a file that is still invalid is reported.
+	t.Setenv("EDITOR", "true")
+	if err := os.WriteFile(configPath, []byte("colour = \"red\"\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := editConfig(); err == nil || !strings.Contains(err.Error(), "unknown settings") {
+		t.Errorf("Expected the invalid config to be reported, got %v", err)
+	}
+}
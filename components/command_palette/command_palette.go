@@ -0,0 +1,161 @@
+package commandpalette
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).PaddingBottom(1)
+)
+
+// Model lists the actions available in the list and runs the chosen one.
+type Model struct {
+	Keys          helpkeys.CommandPaletteKeyMap
+	Title         string
+	NoMatchesText string
+	actions       []helpkeys.Action
+	filtered      []helpkeys.Action
+	cursor        int
+	filter        textinput.Model
+	width         int
+	height        int
+}
+
+func New(keys helpkeys.CommandPaletteKeyMap, placeholder string) Model {
+	filter := textinput.New()
+	filter.Placeholder = placeholder
+	filter.Prompt = ": "
+
+	return Model{
+		Keys:   keys,
+		filter: filter,
+	}
+}
+
+// Open resets the palette with the actions that can currently be run.
+func (m *Model) Open(title string, actions []helpkeys.Action) tea.Cmd {
+	m.Title = title
+	m.actions = actions
+	m.cursor = 0
+	m.filter.SetValue("")
+	m.applyFilter()
+	return m.filter.Focus()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.filter.Width = width - 4
+}
+
+func (m Model) Selected() (helpkeys.Action, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return helpkeys.Action{}, false
+	}
+	return m.filtered[m.cursor], true
+}
+
+// applyFilter fuzzy matches the filter against the action descriptions,
+// best matches first.
+func (m *Model) applyFilter() {
+	query := m.filter.Value()
+	if query == "" {
+		m.filtered = m.actions
+	} else {
+		descriptions := make([]string, len(m.actions))
+		for i, action := range m.actions {
+			descriptions[i] = action.Binding.Help().Desc
+		}
+
+		m.filtered = nil
+		for _, match := range fuzzy.Find(query, descriptions) {
+			m.filtered = append(m.filtered, m.actions[match.Index])
+		}
+	}
+
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.Keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Down):
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Close):
+		m.filter.Blur()
+		return m, shared.CloseCommandPaletteCmd()
+	case key.Matches(keyMsg, m.Keys.Submit):
+		if selected, ok := m.Selected(); ok {
+			m.filter.Blur()
+			return m, shared.RunActionCmd(helpkeys.KeyMsgFor(selected.Binding))
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(keyMsg)
+	m.applyFilter()
+	return m, cmd
+}
+
+func (m Model) View() string {
+	lines := []string{titleStyle.Render(m.Title), m.filter.View(), ""}
+
+	if len(m.filtered) == 0 {
+		lines = append(lines, theme.Current().Blurred.Render(m.NoMatchesText))
+	}
+
+	visible := m.height - len(lines)
+	if visible < 1 {
+		visible = len(m.filtered)
+	}
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	end := start + visible
+	if end > len(m.filtered) {
+		end = len(m.filtered)
+	}
+
+	for i := start; i < end; i++ {
+		help := m.filtered[i].Binding.Help()
+		keyLabel := theme.Current().Blurred.Render(help.Key)
+		if i == m.cursor {
+			lines = append(lines, theme.Current().Focused.Render("> "+help.Desc)+" "+keyLabel)
+		} else {
+			lines = append(lines, "  "+help.Desc+" "+keyLabel)
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -3,11 +3,8 @@ package confirmationmodal
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
 
-var (
-	focusedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	blurredStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	"github.com/evertonstz/go-workflows/components/theme"
 )
 
 type (
@@ -76,11 +73,11 @@ func (m Model) View() string {
 	var confirmButton, cancelButton string
 
 	if m.selectedInput == confirm {
-		confirmButton = focusedStyle.Render("[ " + m.ConfirmButton + " ]")
-		cancelButton = blurredStyle.Render("[ " + m.CancelButton + " ]")
+		confirmButton = theme.Current().Focused.Render("[ " + m.ConfirmButton + " ]")
+		cancelButton = theme.Current().Blurred.Render("[ " + m.CancelButton + " ]")
 	} else {
-		confirmButton = blurredStyle.Render("[ " + m.ConfirmButton + " ]")
-		cancelButton = focusedStyle.Render("[ " + m.CancelButton + " ]")
+		confirmButton = theme.Current().Blurred.Render("[ " + m.ConfirmButton + " ]")
+		cancelButton = theme.Current().Focused.Render("[ " + m.CancelButton + " ]")
 	}
 
 	return lipgloss.JoinVertical(
@@ -0,0 +1,256 @@
+package folderpicker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).PaddingBottom(1)
+)
+
+type Entry struct {
+	Path  string
+	Name  string
+	Depth int
+}
+
+type Model struct {
+	Keys          helpkeys.FolderPickerKeyMap
+	Title         string
+	NewFolderText func(parentPath string) string
+	NoMatchesText string
+	entries       []Entry
+	filtered      []Entry
+	currentPath   string
+	cursor        int
+	filter        textinput.Model
+	nameInput     textinput.Model
+	creating      bool
+	width         int
+	height        int
+}
+
+func New(keys helpkeys.FolderPickerKeyMap, filterPlaceholder string) Model {
+	filter := textinput.New()
+	filter.Placeholder = filterPlaceholder
+	filter.Prompt = "/ "
+
+	nameInput := textinput.New()
+	nameInput.Prompt = "+ "
+
+	return Model{
+		Keys:          keys,
+		NewFolderText: func(parentPath string) string { return parentPath },
+		filter:        filter,
+		nameInput:     nameInput,
+	}
+}
+
+// BuildEntries flattens the folder hierarchy of db into a depth-first list,
+// starting with the root folder.
+func BuildEntries(db models.DatabaseV2) []Entry {
+	entries := []Entry{{Path: "/", Name: "/", Depth: 0}}
+	return appendSubfolders(entries, db, "/", 1)
+}
+
+func appendSubfolders(entries []Entry, db models.DatabaseV2, parentPath string, depth int) []Entry {
+	subfolders := db.GetSubfolders(parentPath)
+	sort.Slice(subfolders, func(i, j int) bool {
+		return strings.ToLower(subfolders[i].Name) < strings.ToLower(subfolders[j].Name)
+	})
+
+	for _, folder := range subfolders {
+		entries = append(entries, Entry{Path: folder.Path, Name: folder.Name, Depth: depth})
+		entries = appendSubfolders(entries, db, folder.Path, depth+1)
+	}
+	return entries
+}
+
+// Open resets the picker with the folders of db and highlights currentPath.
+func (m *Model) Open(db models.DatabaseV2, title, currentPath string) tea.Cmd {
+	m.Title = title
+	m.entries = BuildEntries(db)
+	m.currentPath = currentPath
+	m.creating = false
+	m.nameInput.SetValue("")
+	m.nameInput.Blur()
+	m.filter.SetValue("")
+	m.applyFilter()
+
+	m.cursor = 0
+	for i, entry := range m.filtered {
+		if entry.Path == currentPath {
+			m.cursor = i
+			break
+		}
+	}
+
+	return m.filter.Focus()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.filter.Width = width - 4
+	m.nameInput.Width = width - 4
+}
+
+func (m Model) Selected() (Entry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return Entry{}, false
+	}
+	return m.filtered[m.cursor], true
+}
+
+func (m *Model) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+	if query == "" {
+		m.filtered = m.entries
+	} else {
+		m.filtered = nil
+		for _, entry := range m.entries {
+			if strings.Contains(strings.ToLower(entry.Path), query) {
+				m.filtered = append(m.filtered, entry)
+			}
+		}
+	}
+
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.creating {
+		return m.updateCreating(keyMsg)
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.Keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Down):
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Close):
+		return m, shared.CloseFolderPickerCmd()
+	case key.Matches(keyMsg, m.Keys.Submit):
+		if selected, ok := m.Selected(); ok {
+			return m, shared.PickFolderCmd(selected.Path, "")
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.NewFolder):
+		if _, ok := m.Selected(); ok {
+			m.creating = true
+			m.filter.Blur()
+			return m, m.nameInput.Focus()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(keyMsg)
+	m.applyFilter()
+	return m, cmd
+}
+
+func (m Model) updateCreating(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.Keys.Close):
+		m.creating = false
+		m.nameInput.SetValue("")
+		m.nameInput.Blur()
+		return m, m.filter.Focus()
+	case key.Matches(msg, m.Keys.Submit):
+		name := strings.TrimSpace(m.nameInput.Value())
+		selected, ok := m.Selected()
+		if name == "" || !ok {
+			return m, nil
+		}
+		return m, shared.PickFolderCmd(selected.Path, name)
+	}
+
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var rows []string
+
+	visibleRows := m.height - 5
+	if m.creating {
+		visibleRows -= 2
+	}
+	if visibleRows < 1 {
+		visibleRows = len(m.filtered)
+	}
+
+	start := 0
+	if m.cursor >= visibleRows {
+		start = m.cursor - visibleRows + 1
+	}
+	end := start + visibleRows
+	if end > len(m.filtered) {
+		end = len(m.filtered)
+	}
+
+	for i := start; i < end; i++ {
+		entry := m.filtered[i]
+		label := strings.Repeat("  ", entry.Depth) + "📁 " + entry.Name
+		if entry.Path == m.currentPath {
+			label += theme.Current().Blurred.Render(" •")
+		}
+
+		if i == m.cursor {
+			rows = append(rows, theme.Current().Focused.Render("> "+label))
+		} else {
+			rows = append(rows, "  "+label)
+		}
+	}
+
+	if len(m.filtered) == 0 {
+		rows = append(rows, theme.Current().Blurred.Render(m.NoMatchesText))
+	}
+
+	sections := []string{
+		titleStyle.Render(m.Title),
+		m.filter.View(),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	}
+
+	if m.creating {
+		if selected, ok := m.Selected(); ok {
+			sections = append(sections, "", m.NewFolderText(selected.Path), m.nameInput.View())
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
@@ -0,0 +1,242 @@
+package foldertree
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+type row struct {
+	path        string
+	name        string
+	depth       int
+	hasChildren bool
+}
+
+// Model renders the folder hierarchy as an expandable tree. Only expanded
+// folders show their children; the root is always expanded.
+type Model struct {
+	Keys        helpkeys.FolderTreeKeyMap
+	RootName    string
+	tree        models.FolderTree
+	rows        []row
+	expanded    map[string]bool
+	currentPath string
+	cursor      int
+	focused     bool
+	width       int
+	height      int
+}
+
+func New(keys helpkeys.FolderTreeKeyMap, rootName string) Model {
+	return Model{
+		Keys:     keys,
+		RootName: rootName,
+		expanded: map[string]bool{"/": true},
+	}
+}
+
+// SetTree replaces the folders shown, keeping the expanded folders and the
+// cursor on the same path when it still exists.
+func (m *Model) SetTree(tree models.FolderTree) {
+	selected := m.SelectedPath()
+	m.tree = tree
+	m.buildRows()
+	m.selectPath(selected)
+}
+
+// SetCurrentPath marks path as the folder being browsed, expanding its
+// ancestors so that it is visible.
+func (m *Model) SetCurrentPath(path string) {
+	m.currentPath = path
+	if !strings.HasPrefix(path, "/") {
+		return
+	}
+
+	for ancestor := path; ancestor != "/"; {
+		ancestor = parentPath(ancestor)
+		m.expanded[ancestor] = true
+	}
+	m.buildRows()
+	m.selectPath(path)
+}
+
+func (m *Model) Focus() {
+	m.focused = true
+}
+
+func (m *Model) Blur() {
+	m.focused = false
+}
+
+func (m Model) Focused() bool {
+	return m.focused
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m Model) SelectedPath() string {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return "/"
+	}
+	return m.rows[m.cursor].path
+}
+
+func (m *Model) selectPath(path string) {
+	for i, r := range m.rows {
+		if r.path == path {
+			m.cursor = i
+			return
+		}
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *Model) buildRows() {
+	m.rows = []row{{path: "/", name: m.RootName, depth: 0, hasChildren: len(m.tree.Folders) > 0}}
+	m.appendRows(m.tree.Folders, 1)
+}
+
+func (m *Model) appendRows(nodes []models.FolderNode, depth int) {
+	sorted := append([]models.FolderNode(nil), nodes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Folder.Name) < strings.ToLower(sorted[j].Folder.Name)
+	})
+
+	for _, node := range sorted {
+		m.rows = append(m.rows, row{
+			path:        node.Folder.Path,
+			name:        node.Folder.Name,
+			depth:       depth,
+			hasChildren: len(node.Subfolders) > 0,
+		})
+		if m.expanded[node.Folder.Path] {
+			m.appendRows(node.Subfolders, depth+1)
+		}
+	}
+}
+
+func parentPath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return "/"
+	}
+	return path[:i]
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focused {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.Keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.Keys.Down):
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, m.Keys.Expand):
+		if r := m.rows[m.cursor]; r.hasChildren && !m.expanded[r.path] {
+			m.expanded[r.path] = true
+			m.buildRows()
+		}
+	case key.Matches(keyMsg, m.Keys.Collapse):
+		r := m.rows[m.cursor]
+		if r.path != "/" && r.hasChildren && m.expanded[r.path] {
+			m.expanded[r.path] = false
+			m.buildRows()
+			m.selectPath(r.path)
+		} else if r.path != "/" {
+			m.selectPath(parentPath(r.path))
+		}
+	case key.Matches(keyMsg, m.Keys.Open):
+		return m, shared.OpenFolderCmd(m.SelectedPath())
+	case key.Matches(keyMsg, m.Keys.Close):
+		return m, shared.CloseFolderTreeCmd()
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	visibleRows := m.height
+	if visibleRows < 1 {
+		visibleRows = len(m.rows)
+	}
+
+	start := 0
+	if m.cursor >= visibleRows {
+		start = m.cursor - visibleRows + 1
+	}
+	end := start + visibleRows
+	if end > len(m.rows) {
+		end = len(m.rows)
+	}
+
+	var lines []string
+	for i := start; i < end; i++ {
+		r := m.rows[i]
+
+		marker := "  "
+		if r.hasChildren {
+			marker = "▸ "
+			if m.expanded[r.path] {
+				marker = "▾ "
+			}
+		}
+
+		label := strings.Repeat("  ", r.depth) + marker + r.name
+		if m.width > 0 {
+			label = truncate(label, m.width-2)
+		}
+
+		switch {
+		case i == m.cursor && m.focused:
+			lines = append(lines, theme.Current().Focused.Render("> "+label))
+		case r.path == m.currentPath:
+			lines = append(lines, theme.Current().Selected.Render("• "+label))
+		case m.focused:
+			lines = append(lines, "  "+label)
+		default:
+			lines = append(lines, theme.Current().Blurred.Render("  "+label))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,170 @@
+package gotoprompt
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+const maxSuggestions = 10
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).PaddingBottom(1)
+)
+
+// Model is a prompt that jumps to any folder by path. While the input is
+// empty it suggests the breadcrumbs of the current folder; otherwise it
+// autocompletes over every folder path.
+type Model struct {
+	Keys          helpkeys.GoToPromptKeyMap
+	Title         string
+	NoMatchesText string
+	paths         []string
+	breadcrumbs   []string
+	suggestions   []string
+	cursor        int
+	input         textinput.Model
+	width         int
+	height        int
+}
+
+func New(keys helpkeys.GoToPromptKeyMap, placeholder string) Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.Prompt = "→ "
+
+	return Model{
+		Keys:  keys,
+		input: input,
+	}
+}
+
+// Open resets the prompt. paths are the folders that can be reached and
+// breadcrumbs the folders leading to the current one, starting at the root.
+func (m *Model) Open(title string, paths, breadcrumbs []string) tea.Cmd {
+	m.Title = title
+	m.paths = paths
+	m.breadcrumbs = breadcrumbs
+	m.input.SetValue("")
+	m.suggest()
+
+	// Going up one level is the most likely jump from the breadcrumbs
+	m.cursor = len(m.suggestions) - 2
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+
+	return m.input.Focus()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.Width = width - 4
+}
+
+func (m Model) Selected() (string, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.suggestions) {
+		return "", false
+	}
+	return m.suggestions[m.cursor], true
+}
+
+// suggest lists the paths matching the input, those starting with it first.
+func (m *Model) suggest() {
+	query := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	if query == "" {
+		m.suggestions = m.breadcrumbs
+	} else {
+		var prefixed, contained []string
+		for _, path := range m.paths {
+			lower := strings.ToLower(path)
+			switch {
+			case strings.HasPrefix(lower, query):
+				prefixed = append(prefixed, path)
+			case strings.Contains(lower, query):
+				contained = append(contained, path)
+			}
+		}
+		m.suggestions = append(prefixed, contained...)
+		if len(m.suggestions) > maxSuggestions {
+			m.suggestions = m.suggestions[:maxSuggestions]
+		}
+	}
+
+	if m.cursor >= len(m.suggestions) {
+		m.cursor = len(m.suggestions) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.Keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Down):
+		if m.cursor < len(m.suggestions)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Close):
+		m.input.Blur()
+		return m, shared.CloseGoToPromptCmd()
+	case key.Matches(keyMsg, m.Keys.Complete):
+		if selected, ok := m.Selected(); ok {
+			m.input.SetValue(selected)
+			m.input.CursorEnd()
+			m.suggest()
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Submit):
+		if selected, ok := m.Selected(); ok {
+			m.input.Blur()
+			return m, shared.OpenFolderCmd(selected)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(keyMsg)
+	m.suggest()
+	return m, cmd
+}
+
+func (m Model) View() string {
+	lines := []string{titleStyle.Render(m.Title), m.input.View(), ""}
+
+	if len(m.suggestions) == 0 {
+		lines = append(lines, theme.Current().Blurred.Render(m.NoMatchesText))
+	}
+	for i, suggestion := range m.suggestions {
+		if i == m.cursor {
+			lines = append(lines, theme.Current().Focused.Render("> "+suggestion))
+		} else {
+			lines = append(lines, "  "+suggestion)
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
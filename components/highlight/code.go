@@ -0,0 +1,218 @@
+package highlight
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// codeSyntax describes a language with C-like tokens closely enough for
+// LexCode to highlight it.
+type codeSyntax struct {
+	keywords map[string]bool
+	// foldCase matches keywords regardless of case.
+	foldCase     bool
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+	// tripleQuotes allows strings delimited by three quotes.
+	tripleQuotes bool
+	// stringPrefixes are letters that may precede a quote, like f"" in
+	// Python.
+	stringPrefixes string
+	// backslashEscapes lets backslashes escape quotes in strings.
+	backslashEscapes bool
+	// variablePrefixes start variables, such as $name in jq.
+	variablePrefixes string
+	// specialPrefixes start special names, such as Python decorators.
+	specialPrefixes string
+	// paths makes .name a variable, as in jq.
+	paths     bool
+	operators string
+}
+
+func words(list string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(list) {
+		set[word] = true
+	}
+	return set
+}
+
+var (
+	pythonSyntax = codeSyntax{
+		keywords: words(`False None True and as assert async await break class continue def del elif else
+			except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield`),
+		lineComments:     []string{"#"},
+		quotes:           `"'`,
+		tripleQuotes:     true,
+		stringPrefixes:   "rRbBfFuU",
+		backslashEscapes: true,
+		specialPrefixes:  "@",
+		operators:        "+-*/%=<>!&|^~:",
+	}
+	sqlSyntax = codeSyntax{
+		keywords: words(`add all alter and as asc begin between by case cascade check column commit constraint create
+			cross database default delete desc distinct drop else end exists foreign from full group having if in
+			index inner insert into is join key left like limit not null offset on or order outer primary references
+			returning right rollback schema select set table then transaction truncate union unique update using
+			values view when where with`),
+		foldCase:         true,
+		lineComments:     []string{"--"},
+		blockComment:     [2]string{"/*", "*/"},
+		quotes:           `'"`,
+		variablePrefixes: "$:@",
+		operators:        "+-*/%=<>!|",
+	}
+	jqSyntax = codeSyntax{
+		keywords:         words(`and as catch def elif else end foreach if import include label or not reduce then try`),
+		lineComments:     []string{"#"},
+		quotes:           `"`,
+		backslashEscapes: true,
+		variablePrefixes: "$",
+		specialPrefixes:  "@",
+		paths:            true,
+		operators:        "|+-*/%=<>!?,:",
+	}
+)
+
+// LexCode splits code into tokens following syntax. Identifiers directly
+// followed by a parenthesis are highlighted as commands, since they call
+// functions.
+func LexCode(code string, syntax codeSyntax) []Token {
+	var tokens []Token
+	emit := func(kind Kind, text string) {
+		if last := len(tokens) - 1; last >= 0 && tokens[last].Kind == kind {
+			tokens[last].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text})
+	}
+
+	for pos := 0; pos < len(code); {
+		rest := code[pos:]
+		r, size := utf8.DecodeRuneInString(rest)
+
+		if hasAnyPrefix(rest, syntax.lineComments) {
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			emit(Comment, rest[:end])
+			pos += end
+			continue
+		}
+		if open := syntax.blockComment[0]; open != "" && strings.HasPrefix(rest, open) {
+			end := strings.Index(rest[len(open):], syntax.blockComment[1])
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += len(open) + len(syntax.blockComment[1])
+			}
+			emit(Comment, rest[:end])
+			pos += end
+			continue
+		}
+
+		switch {
+		case strings.ContainsRune(syntax.quotes, r):
+			end := stringEnd(rest, syntax)
+			emit(String, rest[:end])
+			pos += end
+		case unicode.IsSpace(r):
+			emit(Plain, rest[:size])
+			pos += size
+		case r >= '0' && r <= '9':
+			end := 1
+			for end < len(rest) && (isNameChar(rest[end]) || rest[end] == '.') {
+				end++
+			}
+			emit(Number, rest[:end])
+			pos += end
+		case isNameStart(rest[0]):
+			end := 1
+			for end < len(rest) && isNameChar(rest[end]) {
+				end++
+			}
+			word := rest[:end]
+			if isStringPrefix(word, syntax) && end < len(rest) && strings.Contains(syntax.quotes, rest[end:end+1]) {
+				end += stringEnd(rest[end:], syntax)
+				emit(String, rest[:end])
+			} else {
+				emit(wordKind(word, rest[end:], syntax), word)
+			}
+			pos += end
+		case syntax.paths && r == '.' && len(rest) > 1 && isNameStart(rest[1]),
+			strings.ContainsRune(syntax.variablePrefixes, r) && len(rest) > 1 && isNameChar(rest[1]):
+			end := 2
+			for end < len(rest) && isNameChar(rest[end]) {
+				end++
+			}
+			emit(Variable, rest[:end])
+			pos += end
+		case strings.ContainsRune(syntax.specialPrefixes, r) && len(rest) > 1 && isNameStart(rest[1]):
+			end := 2
+			for end < len(rest) && (isNameChar(rest[end]) || rest[end] == '.') {
+				end++
+			}
+			emit(Special, rest[:end])
+			pos += end
+		case strings.ContainsRune(syntax.operators, r):
+			emit(Operator, rest[:size])
+			pos += size
+		default:
+			emit(Plain, rest[:size])
+			pos += size
+		}
+	}
+	return tokens
+}
+
+func isStringPrefix(word string, syntax codeSyntax) bool {
+	return syntax.stringPrefixes != "" && len(word) <= 2 && strings.Trim(word, syntax.stringPrefixes) == ""
+}
+
+func wordKind(word, after string, syntax codeSyntax) Kind {
+	key := word
+	if syntax.foldCase {
+		key = strings.ToLower(word)
+	}
+	if syntax.keywords[key] {
+		return Keyword
+	}
+	if strings.HasPrefix(after, "(") {
+		return Command
+	}
+	return Plain
+}
+
+// stringEnd returns the length of the string at the start of s, or the
+// length of s when the string is not closed.
+func stringEnd(s string, syntax codeSyntax) int {
+	quote := s[:1]
+	if syntax.tripleQuotes && strings.HasPrefix(s, strings.Repeat(quote, 3)) {
+		delimiter := strings.Repeat(quote, 3)
+		if end := strings.Index(s[3:], delimiter); end >= 0 {
+			return end + 6
+		}
+		return len(s)
+	}
+	for i := 1; i < len(s); i++ {
+		switch {
+		case syntax.backslashEscapes && s[i] == '\\':
+			i++
+		case s[i] == quote[0]:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
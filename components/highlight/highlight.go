@@ -0,0 +1,108 @@
+package highlight
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// Kind classifies a token.
+type Kind int
+
+const (
+	Plain Kind = iota
+	Command
+	Keyword
+	Flag
+	String
+	Variable
+	Number
+	Operator
+	// Special covers substitutions, arithmetic and heredoc delimiters.
+	Special
+	Comment
+)
+
+var kindNames = map[Kind]string{
+	Plain:    "plain",
+	Command:  "command",
+	Keyword:  "keyword",
+	Flag:     "flag",
+	String:   "string",
+	Variable: "variable",
+	Number:   "number",
+	Operator: "operator",
+	Special:  "special",
+	Comment:  "comment",
+}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// Token is a piece of source text of a single kind.
+type Token struct {
+	Kind Kind
+	Text string
+}
+
+// Lex splits code written in language into tokens. Unknown languages are
+// lexed as shell.
+func Lex(language, code string) []Token {
+	switch language {
+	case models.LanguagePython:
+		return LexCode(code, pythonSyntax)
+	case models.LanguageSQL:
+		return LexCode(code, sqlSyntax)
+	case models.LanguageJq:
+		return LexCode(code, jqSyntax)
+	case models.LanguageYAML:
+		return LexYAML(code)
+	default:
+		return LexShell(code)
+	}
+}
+
+// Code colors code written in language with the syntax colors of the theme.
+func Code(language, code string) string {
+	return Render(Lex(language, code), theme.Current().Syntax)
+}
+
+// Render colors every token with the style of its kind, leaving plain text
+// as it is.
+func Render(tokens []Token, syntax theme.SyntaxStyles) string {
+	styles := map[Kind]lipgloss.Style{
+		Command:  syntax.Command,
+		Keyword:  syntax.Keyword,
+		Flag:     syntax.Flag,
+		String:   syntax.String,
+		Variable: syntax.Variable,
+		Number:   syntax.Number,
+		Operator: syntax.Operator,
+		Special:  syntax.Special,
+		Comment:  syntax.Comment,
+	}
+
+	var result strings.Builder
+	for _, token := range tokens {
+		style, ok := styles[token.Kind]
+		if !ok {
+			result.WriteString(token.Text)
+			continue
+		}
+		// Styles pad multi-line text into a block, so lines are rendered
+		// one by one.
+		for i, line := range strings.Split(token.Text, "\n") {
+			if i > 0 {
+				result.WriteString("\n")
+			}
+			if line != "" {
+				result.WriteString(style.Render(line))
+			}
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,89 @@
+package highlight
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// annotate writes every token that is not plain as «kind|text».
+func annotate(tokens []Token) string {
+	var result strings.Builder
+	for _, token := range tokens {
+		if token.Kind == Plain {
+			result.WriteString(token.Text)
+			continue
+		}
+		result.WriteString("«" + token.Kind.String() + "|" + token.Text + "»")
+	}
+	return result.String()
+}
+
+// TestLex_Golden lexes every input in testdata/<language> as that language
+// and compares the tokens with the .golden file next to it.
+func TestLex_Golden(t *testing.T) {
+	inputs, err := filepath.Glob("testdata/*/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	languages := map[string]bool{}
+	for _, input := range inputs {
+		if filepath.Ext(input) == ".golden" {
+			continue
+		}
+		language := filepath.Base(filepath.Dir(input))
+		languages[language] = true
+		name := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+		t.Run(language+"/"+name, func(t *testing.T) {
+			content, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			code := strings.TrimSuffix(string(content), "\n")
+
+			tokens := Lex(language, code)
+			got := annotate(tokens) + "\n"
+
+			golden := strings.TrimSuffix(input, filepath.Ext(input)) + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("Tokens differ from %s\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+
+			var joined strings.Builder
+			for i, token := range tokens {
+				if token.Text == "" {
+					t.Errorf("Token %d is empty", i)
+				}
+				if i > 0 && tokens[i-1].Kind == token.Kind {
+					t.Errorf("Tokens %d and %d share the kind %s and should be one token", i-1, i, token.Kind)
+				}
+				joined.WriteString(token.Text)
+			}
+			if joined.String() != code {
+				t.Errorf("Expected the tokens to join into the code\ngot:  %q\nwant: %q", joined.String(), code)
+			}
+		})
+	}
+
+	for _, language := range models.Languages {
+		if !languages[language.ID] {
+			t.Errorf("Expected test inputs in testdata/%s", language.ID)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package highlight
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// literal marks the unquoted text of a word until the word is classified.
+const literal Kind = -1
+
+var (
+	reservedWords = map[string]bool{
+		"!": true, "[[": true, "{": true, "}": true, "case": true, "coproc": true,
+		"do": true, "done": true, "elif": true, "else": true, "esac": true,
+		"fi": true, "for": true, "function": true, "if": true, "in": true,
+		"select": true, "then": true, "time": true, "until": true, "while": true,
+	}
+	// argumentKeywords are followed by names or arguments rather than by a
+	// command.
+	argumentKeywords = map[string]bool{"[[": true, "case": true, "for": true, "function": true, "select": true}
+
+	// operators are matched longest first.
+	operators = []string{
+		"<<<", "<<-", "&>>", ";;&",
+		"&&", "||", ";;", ";&", "|&", "<<", ">>", "<&", ">&", "&>", "<>", ">|", "<(", ">(",
+		"|", "&", ";", "<", ">", "(", ")",
+	}
+
+	assignmentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\[[^\]]*\])?\+?=`)
+)
+
+type heredoc struct {
+	delimiter string
+	stripTabs bool
+}
+
+type shellLexer struct {
+	src    string
+	pos    int
+	tokens []Token
+	// expectCommand is set where the next word names a command.
+	expectCommand bool
+	// redirectTarget is set where the next word is the target of a
+	// redirection, and heredocTarget where it is a heredoc delimiter.
+	redirectTarget bool
+	heredocTarget  bool
+	stripTabs      bool
+	// lastKeyword is the reserved word that started the current command, so
+	// that the "in" of for and case is recognized.
+	lastKeyword string
+	// casePattern is set while lexing the patterns of a case item and inTest
+	// inside [[ ]], where words are never commands.
+	casePattern bool
+	inTest      bool
+	heredocs    []heredoc
+}
+
+// LexShell splits a shell command into tokens. Joining the text of the
+// tokens gives back the command. Incomplete commands, such as an unclosed
+// quote, are lexed as far as they go.
+func LexShell(command string) []Token {
+	l := &shellLexer{src: command, expectCommand: true}
+	l.lexCommands(0)
+	return l.tokens
+}
+
+// lexCommands lexes commands until the unquoted terminator term, which is
+// left for the caller. A zero term lexes until the end of the input.
+func (l *shellLexer) lexCommands(term byte) {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case term != 0 && c == term:
+			return
+		case c == ' ' || c == '\t':
+			l.emit(Plain, l.src[l.pos:l.pos+1])
+			l.pos++
+		case c == '\n':
+			l.emit(Plain, "\n")
+			l.pos++
+			l.expectCommand = true
+			l.lastKeyword = ""
+			l.lexHeredocBodies()
+		case c == '\\' && l.peek(1) == '\n':
+			l.emit(Plain, "\\\n")
+			l.pos += 2
+		case c == '#':
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				end = len(l.src) - l.pos
+			}
+			l.emit(Comment, l.src[l.pos:l.pos+end])
+			l.pos += end
+		case isMeta(c):
+			l.lexOperator()
+		default:
+			l.lexWord(term)
+		}
+	}
+}
+
+func (l *shellLexer) lexOperator() {
+	op := l.src[l.pos : l.pos+1]
+	for _, candidate := range operators {
+		if strings.HasPrefix(l.src[l.pos:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	l.pos += len(op)
+	l.lastKeyword = ""
+
+	switch op {
+	case "<(", ">(":
+		l.emit(Special, op)
+		l.lexNested(')', Special)
+	case "(":
+		l.emit(Operator, op)
+		l.expectCommand = true
+		l.lexCommands(')')
+		if l.pos < len(l.src) {
+			l.emit(Operator, ")")
+			l.pos++
+		}
+		l.expectCommand = true
+	case ")":
+		// Ends a case pattern; the command follows it.
+		l.emit(Operator, op)
+		l.expectCommand = true
+		l.casePattern = false
+	case ";;", ";&", ";;&":
+		l.emit(Operator, op)
+		l.casePattern = true
+	case "|", "&&", "||":
+		l.emit(Operator, op)
+		l.expectCommand = !l.casePattern && !l.inTest
+	case "<<", "<<-":
+		l.emit(Operator, op)
+		l.heredocTarget = true
+		l.stripTabs = op == "<<-"
+	case "<", ">", ">>", "<&", ">&", "&>", "&>>", "<>", ">|", "<<<":
+		l.emit(Operator, op)
+		l.redirectTarget = true
+	default:
+		l.emit(Operator, op)
+		l.expectCommand = true
+	}
+}
+
+// lexNested lexes the commands of a substitution up to term, which is
+// emitted as kind.
+func (l *shellLexer) lexNested(term byte, kind Kind) {
+	outer := *l
+	l.expectCommand, l.redirectTarget, l.heredocTarget = true, false, false
+	l.lastKeyword, l.casePattern, l.inTest = "", false, false
+
+	l.lexCommands(term)
+	if l.pos < len(l.src) {
+		l.emit(kind, l.src[l.pos:l.pos+1])
+		l.pos++
+	}
+
+	l.expectCommand, l.redirectTarget, l.heredocTarget = outer.expectCommand, outer.redirectTarget, outer.heredocTarget
+	l.lastKeyword, l.casePattern, l.inTest = outer.lastKeyword, outer.casePattern, outer.inTest
+}
+
+func (l *shellLexer) lexWord(term byte) {
+	if l.lexFileDescriptor() {
+		return
+	}
+
+	outer := l.tokens
+	l.tokens = nil
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if isMeta(c) || c == term {
+			break
+		}
+		switch c {
+		case '\\':
+			size := 1
+			if l.pos+1 < len(l.src) {
+				_, size = utf8.DecodeRuneInString(l.src[l.pos+1:])
+				size++
+			}
+			l.emit(literal, l.src[l.pos:l.pos+size])
+			l.pos += size
+		case '\'':
+			l.lexSingleQuoted()
+		case '"':
+			l.lexDoubleQuoted()
+		case '$':
+			if !l.lexDollar() {
+				l.emit(literal, "$")
+				l.pos++
+			}
+		case '`':
+			l.emit(Special, "`")
+			l.pos++
+			l.lexNested('`', Special)
+		default:
+			_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			l.emit(literal, l.src[l.pos:l.pos+size])
+			l.pos += size
+		}
+	}
+
+	parts := l.classifyWord(l.tokens)
+	l.tokens = outer
+	for _, part := range parts {
+		l.emit(part.Kind, part.Text)
+	}
+}
+
+// lexFileDescriptor lexes the file descriptor of a redirection such as 2>&1.
+func (l *shellLexer) lexFileDescriptor() bool {
+	end := l.pos
+	for end < len(l.src) && l.src[end] >= '0' && l.src[end] <= '9' {
+		end++
+	}
+	if end == l.pos || end == len(l.src) || (l.src[end] != '<' && l.src[end] != '>') {
+		return false
+	}
+	l.emit(Operator, l.src[l.pos:end])
+	l.pos = end
+	l.lexOperator()
+	return true
+}
+
+// classifyWord gives the unquoted parts of a word the kind that fits the
+// position of the word and updates what the next word is expected to be.
+func (l *shellLexer) classifyWord(parts []Token) []Token {
+	var raw, text strings.Builder
+	onlyLiteral := true
+	for _, part := range parts {
+		raw.WriteString(part.Text)
+		if part.Kind == literal {
+			text.WriteString(part.Text)
+		} else {
+			onlyLiteral = false
+		}
+	}
+	word := text.String()
+
+	kind := Plain
+	switch {
+	case l.heredocTarget:
+		l.heredocs = append(l.heredocs, heredoc{
+			delimiter: strings.NewReplacer(`'`, "", `"`, "", `\`, "").Replace(raw.String()),
+			stripTabs: l.stripTabs,
+		})
+		l.heredocTarget, l.redirectTarget = false, false
+		for i := range parts {
+			parts[i].Kind = Special
+		}
+		return parts
+	case l.redirectTarget:
+		l.redirectTarget = false
+		kind = argumentKind(word)
+	case l.casePattern:
+		if onlyLiteral && word == "esac" {
+			kind = Keyword
+			l.casePattern = false
+		}
+	case l.expectCommand && len(parts) > 0 && parts[0].Kind == literal && assignmentPattern.MatchString(parts[0].Text):
+		name := assignmentPattern.FindString(parts[0].Text)
+		value := Token{Kind: literal, Text: strings.TrimPrefix(parts[0].Text, name)}
+		parts = append([]Token{{Kind: Variable, Text: name}, value}, parts[1:]...)
+	case l.expectCommand && onlyLiteral && reservedWords[word]:
+		kind = Keyword
+		l.lastKeyword = word
+		l.expectCommand = !argumentKeywords[word]
+		l.inTest = word == "[["
+	case l.expectCommand:
+		kind = Command
+		l.expectCommand = false
+	case onlyLiteral && word == "in" && (l.lastKeyword == "for" || l.lastKeyword == "case" || l.lastKeyword == "select"):
+		kind = Keyword
+		l.casePattern = l.lastKeyword == "case"
+		l.lastKeyword = ""
+	case onlyLiteral && word == "]]" && l.inTest:
+		kind = Keyword
+		l.inTest = false
+	default:
+		kind = argumentKind(word)
+	}
+
+	for i := range parts {
+		if parts[i].Kind == literal {
+			parts[i].Kind = kind
+		}
+	}
+	return parts
+}
+
+func argumentKind(word string) Kind {
+	if len(word) > 1 && word[0] == '-' {
+		return Flag
+	}
+	if word != "" && strings.Trim(word, "0123456789") == "" {
+		return Number
+	}
+	return Plain
+}
+
+func (l *shellLexer) lexSingleQuoted() {
+	end := len(l.src)
+	if i := strings.IndexByte(l.src[l.pos+1:], '\''); i >= 0 {
+		end = l.pos + i + 2
+	}
+	l.emit(String, l.src[l.pos:end])
+	l.pos = end
+}
+
+// lexAnsiCQuoted lexes $'...', where backslashes escape quotes.
+func (l *shellLexer) lexAnsiCQuoted() {
+	end := l.pos + 2
+	for end < len(l.src) && l.src[end] != '\'' {
+		if l.src[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	end = min(end+1, len(l.src))
+	l.emit(String, l.src[l.pos:end])
+	l.pos = end
+}
+
+func (l *shellLexer) lexDoubleQuoted() {
+	l.emit(String, `"`)
+	l.pos++
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; c {
+		case '"':
+			l.emit(String, `"`)
+			l.pos++
+			return
+		case '\\':
+			end := min(l.pos+2, len(l.src))
+			l.emit(String, l.src[l.pos:end])
+			l.pos = end
+		case '$':
+			if !l.lexDollar() {
+				l.emit(String, "$")
+				l.pos++
+			}
+		case '`':
+			l.emit(Special, "`")
+			l.pos++
+			l.lexNested('`', Special)
+		default:
+			l.emit(String, l.src[l.pos:l.pos+1])
+			l.pos++
+		}
+	}
+}
+
+// lexDollar lexes the expansion at the current position. It reports false
+// when the dollar sign does not start one.
+func (l *shellLexer) lexDollar() bool {
+	rest := l.src[l.pos:]
+	switch {
+	case strings.HasPrefix(rest, "$(("):
+		end := l.pos + matching(rest[1:], '(', ')') + 1
+		l.emit(Special, l.src[l.pos:end])
+		l.pos = end
+	case strings.HasPrefix(rest, "$("):
+		l.emit(Special, "$(")
+		l.pos += 2
+		l.lexNested(')', Special)
+	case strings.HasPrefix(rest, "${"):
+		end := l.pos + matching(rest[1:], '{', '}') + 1
+		l.emit(Variable, l.src[l.pos:end])
+		l.pos = end
+	case strings.HasPrefix(rest, "$["):
+		end := l.pos + matching(rest[1:], '[', ']') + 1
+		l.emit(Special, l.src[l.pos:end])
+		l.pos = end
+	case strings.HasPrefix(rest, "$'"):
+		l.lexAnsiCQuoted()
+	case len(rest) > 1 && isNameStart(rest[1]):
+		end := 2
+		for end < len(rest) && isNameChar(rest[end]) {
+			end++
+		}
+		l.emit(Variable, rest[:end])
+		l.pos += end
+	case len(rest) > 1 && strings.IndexByte("0123456789@*#?$!-", rest[1]) >= 0:
+		l.emit(Variable, rest[:2])
+		l.pos += 2
+	default:
+		return false
+	}
+	return true
+}
+
+// lexHeredocBodies lexes the bodies of the heredocs declared on the line that
+// just ended.
+func (l *shellLexer) lexHeredocBodies() {
+	for _, doc := range l.heredocs {
+		for l.pos < len(l.src) {
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				end = len(l.src) - l.pos
+			}
+			line := l.src[l.pos : l.pos+end]
+			check := line
+			if doc.stripTabs {
+				check = strings.TrimLeft(line, "\t")
+			}
+			if check == doc.delimiter {
+				l.emit(Special, line)
+				l.pos += end
+				if l.pos < len(l.src) {
+					l.emit(Plain, "\n")
+					l.pos++
+				}
+				break
+			}
+			end = min(end+1, len(l.src)-l.pos)
+			l.emit(String, l.src[l.pos:l.pos+end])
+			l.pos += end
+		}
+	}
+	l.heredocs = nil
+}
+
+func (l *shellLexer) emit(kind Kind, text string) {
+	if text == "" {
+		return
+	}
+	if last := len(l.tokens) - 1; last >= 0 && l.tokens[last].Kind == kind {
+		l.tokens[last].Text += text
+		return
+	}
+	l.tokens = append(l.tokens, Token{Kind: kind, Text: text})
+}
+
+func (l *shellLexer) peek(offset int) byte {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+// matching returns the length of the bracketed text at the start of s,
+// including the brackets, or the length of s when it is not closed.
+func matching(s string, open, close byte) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+func isMeta(c byte) bool {
+	return strings.IndexByte(" \t\n;&|<>()", c) >= 0
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
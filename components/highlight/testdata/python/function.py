@@ -0,0 +1,8 @@
+@lru_cache(maxsize=None)
+def fib(n: int) -> int:
+    """Return the nth Fibonacci number."""
+    if n < 2:
+        return n  # base case
+    return fib(n - 1) + fib(n - 2)
+
+print(f"{fib(30)=}", r'\d+', b"bytes")
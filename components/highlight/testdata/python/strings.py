@@ -0,0 +1,4 @@
+path = 'it\'s here'
+query = """SELECT *
+FROM t"""
+print(path, query, 0x1F, 3.14, None, True)
@@ -0,0 +1 @@
+ps aux | awk '{print $2, $11}' | sort -k2 | uniq -c | head -n 10 > procs.txt
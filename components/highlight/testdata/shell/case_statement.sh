@@ -0,0 +1 @@
+case "$1" in start) systemctl start nginx ;; stop|halt) systemctl stop nginx ;; *) echo "usage: $0 start|stop" >&2 ;; esac
@@ -0,0 +1 @@
+ssh "$(whoami)@$(hostname -f)" "tail -f /var/log/$(date +%Y-%m-%d).log"
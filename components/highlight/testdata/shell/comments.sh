@@ -0,0 +1,3 @@
+# Rebuild and restart
+make build && ./bin/server --port=8080 & # run in the background
+echo "not # a comment" issue#12
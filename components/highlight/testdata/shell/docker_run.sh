@@ -0,0 +1 @@
+docker run --rm -it -e DATABASE_URL="postgres://$DB_USER@localhost:5432/app" -v "$(pwd)":/app -w /app golang:1.24 go test ./...
@@ -0,0 +1 @@
+find . -name '*.log' -mtime +7 -exec rm {} \; 2>/dev/null
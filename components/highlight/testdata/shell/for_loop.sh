@@ -0,0 +1 @@
+for f in *.png; do convert "$f" -resize 50% "thumb_${f%.png}.jpg"; done
@@ -0,0 +1 @@
+git log --oneline --graph --pretty=format:'%h %s (%an)' -n 20 | grep -i "fix"
@@ -0,0 +1,5 @@
+cat <<EOF > ~/.ssh/config
+Host $HOST
+  User "deploy"
+EOF
+chmod 600 ~/.ssh/config
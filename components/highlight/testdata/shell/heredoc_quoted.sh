@@ -0,0 +1,5 @@
+kubectl apply -f - <<-'YAML'
+	kind: Namespace
+	metadata: {name: $(not-expanded)}
+	YAML
+echo done
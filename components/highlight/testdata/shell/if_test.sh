@@ -0,0 +1 @@
+if [[ -f .env && $(wc -l < .env) -gt 0 ]]; then set -a; source .env; set +a; else echo 'no .env' 1>&2; fi
@@ -0,0 +1 @@
+diff <(sort a.txt) <(sort b.txt) && echo $((40 + 2)) <<< "$HOME"
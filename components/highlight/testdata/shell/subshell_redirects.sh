@@ -0,0 +1 @@
+(cd /tmp && tar -czf backup.tgz ~/notes) &>> backup.log; export PATH="$HOME/go/bin:$PATH"
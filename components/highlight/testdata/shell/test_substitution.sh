@@ -0,0 +1 @@
+if [[ $(ls | wc -l) -gt 0 || -n "$x" ]]; then echo ok; fi
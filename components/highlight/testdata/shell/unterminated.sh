@@ -0,0 +1 @@
+echo "still typing $(date
@@ -0,0 +1,5 @@
+-- users table
+CREATE TABLE IF NOT EXISTS users (
+  id serial PRIMARY KEY,
+  email text UNIQUE NOT NULL /* lowercased */
+);
@@ -0,0 +1 @@
+update accounts set balance = balance - $1 where id = :id returning "balance";
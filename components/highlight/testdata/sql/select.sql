@@ -0,0 +1,7 @@
+SELECT u.id, count(*) AS total
+FROM users u
+LEFT JOIN orders o ON o.user_id = u.id
+WHERE u.created_at >= '2024-01-01' AND u.name <> 'O''Brien'
+GROUP BY u.id
+ORDER BY total DESC
+LIMIT 10;
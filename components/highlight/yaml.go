@@ -0,0 +1,168 @@
+package highlight
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	yamlKeyPattern    = regexp.MustCompile(`^(\s*)((?:-\s+)*)("[^"]*"|'[^']*'|[^\s#'"][^:#]*?)(\s*:)(\s|$)`)
+	yamlFlowKey       = regexp.MustCompile(`^([^\s,\[\]{}:#'"][^,\[\]{}:#]*?)(:)(\s|$)`)
+	yamlItemPattern   = regexp.MustCompile(`^(\s*)((?:-\s+)+|-$)`)
+	yamlNumberPattern = regexp.MustCompile(`^[-+]?(\d[\d_]*(\.\d*)?([eE][-+]?\d+)?|0x[0-9a-fA-F]+|\.inf|\.nan)$`)
+	yamlSpecialWords  = words(`true false yes no on off null ~ True False Yes No On Off Null TRUE FALSE NULL`)
+)
+
+// LexYAML splits a YAML document into tokens line by line. Keys are
+// keywords, anchors and aliases variables, and the lines of block scalars
+// strings.
+func LexYAML(document string) []Token {
+	var tokens []Token
+	emit := func(kind Kind, text string) {
+		if text == "" {
+			return
+		}
+		if last := len(tokens) - 1; last >= 0 && tokens[last].Kind == kind {
+			tokens[last].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text})
+	}
+
+	// blockIndent is the indentation of the line that started a block
+	// scalar, or -1 outside of one.
+	blockIndent := -1
+	for i, line := range strings.Split(document, "\n") {
+		if i > 0 {
+			emit(Plain, "\n")
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) == "" || indent > blockIndent {
+				emit(Plain, line[:indent])
+				emit(String, line[indent:])
+				continue
+			}
+			blockIndent = -1
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "---" || trimmed == "...":
+			emit(Plain, line[:indent])
+			emit(Special, line[indent:])
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			emit(Plain, line[:indent])
+			emit(Comment, line[indent:])
+			continue
+		}
+
+		rest := line
+		if match := yamlKeyPattern.FindStringSubmatch(line); match != nil {
+			emit(Plain, match[1])
+			emitItems(emit, match[2])
+			emit(Keyword, match[3])
+			emit(Operator, strings.TrimLeft(match[4], " "))
+			rest = line[len(match[1])+len(match[2])+len(match[3])+len(match[4]):]
+		} else if match := yamlItemPattern.FindStringSubmatch(line); match != nil {
+			emit(Plain, match[1])
+			emitItems(emit, match[2])
+			rest = line[len(match[0]):]
+		}
+		if lexYAMLValue(emit, rest) {
+			blockIndent = indent
+		}
+	}
+	return tokens
+}
+
+// emitItems emits the dashes of list items as operators.
+func emitItems(emit func(Kind, string), items string) {
+	for _, r := range items {
+		if r == '-' {
+			emit(Operator, "-")
+		} else {
+			emit(Plain, string(r))
+		}
+	}
+}
+
+// lexYAMLValue emits the value after a key or list item and reports whether
+// it starts a block scalar.
+func lexYAMLValue(emit func(Kind, string), value string) bool {
+	// flow counts the brackets and braces left open.
+	flow := 0
+	for value != "" {
+		trimmed := strings.TrimLeft(value, " \t")
+		emit(Plain, value[:len(value)-len(trimmed)])
+		value = trimmed
+		if value == "" {
+			break
+		}
+
+		switch value[0] {
+		case '#':
+			emit(Comment, value)
+			return false
+		case '|', '>':
+			end := strings.IndexAny(value, " \t")
+			if end < 0 {
+				end = len(value)
+			}
+			emit(Operator, value[:end])
+			lexYAMLValue(emit, value[end:])
+			return true
+		case '"', '\'':
+			end := stringEnd(value, codeSyntax{backslashEscapes: value[0] == '"'})
+			emit(String, value[:end])
+			value = value[end:]
+			continue
+		case '&', '*':
+			end := strings.IndexAny(value, " \t,]}")
+			if end < 0 {
+				end = len(value)
+			}
+			emit(Variable, value[:end])
+			value = value[end:]
+			continue
+		case '[', '{', ']', '}', ',':
+			switch value[0] {
+			case '[', '{':
+				flow++
+			case ']', '}':
+				flow--
+			}
+			emit(Operator, value[:1])
+			value = value[1:]
+			continue
+		}
+		if match := yamlFlowKey.FindStringSubmatch(value); flow > 0 && match != nil {
+			emit(Keyword, match[1])
+			emit(Operator, match[2])
+			value = value[len(match[1])+len(match[2]):]
+			continue
+		}
+
+		end := strings.Index(value, " #")
+		if end < 0 {
+			end = len(value)
+		}
+		if flow > 0 {
+			if closing := strings.IndexAny(value[:end], ",]}"); closing >= 0 {
+				end = closing
+			}
+		}
+		scalar := strings.TrimRight(value[:end], " \t")
+		switch {
+		case yamlSpecialWords[scalar]:
+			emit(Special, scalar)
+		case yamlNumberPattern.MatchString(scalar):
+			emit(Number, scalar)
+		default:
+			emit(Plain, scalar)
+		}
+		value = value[len(scalar):]
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package keys
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ActionContext describes what the list is showing, so that actions which
+// do not apply can be hidden.
+type ActionContext struct {
+	OnFolder        bool
+	OnWorkflow      bool
+	OnMultiStep     bool
+	HasVariants     bool
+	HasSelection    bool
+	InVirtualFolder bool
+	AtRoot          bool
+	SidebarVisible  bool
+}
+
+func (c ActionContext) onEntry() bool {
+	return c.OnFolder || c.OnWorkflow
+}
+
+// Action is a list binding together with the context it applies to. A nil
+// Available means the action is always available.
+type Action struct {
+	Binding   key.Binding
+	Available func(ActionContext) bool
+	// Hidden actions show in the help view but not in the command palette.
+	Hidden bool
+}
+
+func (a Action) IsAvailable(ctx ActionContext) bool {
+	return a.Binding.Enabled() && (a.Available == nil || a.Available(ctx))
+}
+
+// ActionGroups is the registry of list actions. The help view, the command
+// palette and the key handlers all read their bindings from here.
+func (k ListKeyMap) ActionGroups() [][]Action {
+	return [][]Action{
+		{
+			{Binding: k.AddNewWorkflow},
+			{Binding: k.Delete, Available: func(c ActionContext) bool { return c.onEntry() || c.HasSelection }},
+			{Binding: k.CopyWorkflow, Available: func(c ActionContext) bool { return c.OnWorkflow || c.HasSelection }},
+			{Binding: k.RunWorkflow, Available: func(c ActionContext) bool { return c.OnWorkflow }},
+			{Binding: k.ResumeWorkflow, Available: func(c ActionContext) bool { return c.OnMultiStep }},
+			{Binding: k.MoveWorkflow, Available: func(c ActionContext) bool { return c.OnWorkflow || c.HasSelection }},
+			{Binding: k.ToggleFavorite, Available: func(c ActionContext) bool { return c.OnWorkflow }},
+			{Binding: k.History},
+			{Binding: k.CycleVariant, Available: func(c ActionContext) bool { return c.HasVariants }},
+		},
+		{
+			{Binding: k.NewFolder},
+			{Binding: k.EditFolder, Available: func(c ActionContext) bool { return c.OnFolder }},
+		},
+		{
+			{Binding: k.GoToFolder},
+			{Binding: k.ParentFolder, Available: func(c ActionContext) bool { return !c.AtRoot }},
+			{Binding: k.RootFolder, Available: func(c ActionContext) bool { return !c.AtRoot }},
+		},
+		{
+			{Binding: k.Duplicate, Available: ActionContext.onEntry},
+			{Binding: k.Cut, Available: ActionContext.onEntry},
+			{Binding: k.Copy, Available: ActionContext.onEntry},
+			{Binding: k.Paste},
+		},
+		{
+			{Binding: k.ToggleSelect, Available: ActionContext.onEntry},
+			{Binding: k.SelectAll},
+			{Binding: k.InvertSelection},
+			{Binding: k.TagWorkflows, Available: func(c ActionContext) bool { return c.OnWorkflow || c.HasSelection }},
+		},
+		{
+			{Binding: k.CycleSort, Available: func(c ActionContext) bool { return !c.InVirtualFolder }},
+			{Binding: k.ToggleFoldersFirst, Available: func(c ActionContext) bool { return !c.InVirtualFolder }},
+			{Binding: k.MoveEntryUp, Available: func(c ActionContext) bool { return c.onEntry() && !c.InVirtualFolder }},
+			{Binding: k.MoveEntryDown, Available: func(c ActionContext) bool { return c.onEntry() && !c.InVirtualFolder }},
+		},
+		{
+			{Binding: k.ToggleSidebar},
+			{Binding: k.FocusSidebar, Available: func(c ActionContext) bool { return c.SidebarVisible }},
+			{Binding: k.CommandPalette, Hidden: true},
+		},
+		{
+			{Binding: k.Up, Hidden: true},
+			{Binding: k.Down, Hidden: true},
+			{Binding: k.Enter, Hidden: true},
+			{Binding: k.Esc, Hidden: true},
+			{Binding: k.Help},
+			{Binding: k.Quit},
+		},
+	}
+}
+
+// PaletteActions lists the actions that can be run from the command palette
+// in ctx.
+func (k ListKeyMap) PaletteActions(ctx ActionContext) []Action {
+	var actions []Action
+	for _, group := range k.ActionGroups() {
+		for _, action := range group {
+			if !action.Hidden && action.IsAvailable(ctx) {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if name := t.String(); name != "" && t != tea.KeyRunes {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// KeyMsgFor builds the key press that triggers binding, so that running an
+// action from the palette goes through the same handlers as typing its key.
+func KeyMsgFor(binding key.Binding) tea.KeyMsg {
+	keys := binding.Keys()
+	if len(keys) == 0 {
+		return tea.KeyMsg{}
+	}
+
+	name := keys[0]
+	alt := false
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && len(rest) > 0 {
+		name, alt = rest, true
+	}
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name), Alt: alt}
+}
@@ -0,0 +1,193 @@
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/charmbracelet/bubbles/help"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+const DefaultPreset = "default"
+
+// Presets remap the default bindings. Keys active while typing in a form
+// (navigation, submit, close and help) are never bound to printable
+// characters, so that they can still be typed.
+var Presets = map[string]map[string][]string{
+	DefaultPreset: {},
+	"vim": {
+		"up":            {"up", "ctrl+k"},
+		"down":          {"down", "ctrl+j"},
+		"toggle_select": {"v", " "},
+		"select_all":    {"V"},
+	},
+	"emacs": {
+		"up":                  {"up", "ctrl+p"},
+		"down":                {"down", "ctrl+n"},
+		"left":                {"left", "ctrl+b"},
+		"right":               {"right", "ctrl+f"},
+		"close":               {"esc", "ctrl+g"},
+		"back":                {"esc", "ctrl+g"},
+		"command_palette":     {"alt+x"},
+		"go_to_folder":        {"alt+g"},
+		"picker_new_folder":   {"alt+n"},
+		"toggle_select":       {"ctrl+@", " "},
+		"cut":                 {"ctrl+w"},
+		"copy":                {"alt+w"},
+		"paste":               {"ctrl+y"},
+		"next_suggestion":     {"alt+n"},
+		"previous_suggestion": {"alt+p"},
+	},
+}
+
+var (
+	// configuredKeys holds the keys of every remapped action.
+	configuredKeys map[string][]string
+	// knownActions collects the ID of every binding that was built.
+	knownActions = map[string]bool{}
+)
+
+// Configure applies the user's keybindings to every key map built from now
+// on. It fails, leaving the default bindings in place, when the preset or an
+// action is unknown, a key is malformed, two actions of the same key map
+// share a key or an action takes the quit key or a key the list handles.
+func Configure(i18n *services.I18nService, bindings models.KeyBindings) error {
+	presetName := bindings.Preset
+	if presetName == "" {
+		presetName = DefaultPreset
+	}
+	preset, ok := Presets[presetName]
+	if !ok {
+		return fmt.Errorf("invalid keybindings: unknown preset %q (available: %s)", presetName, strings.Join(presetNames(), ", "))
+	}
+
+	merged := map[string][]string{}
+	for id, keys := range preset {
+		merged[id] = keys
+	}
+	for id, keys := range bindings.Keys {
+		merged[id] = keys
+	}
+
+	var problems []string
+	for _, id := range sortedIDs(merged) {
+		if len(merged[id]) == 0 {
+			problems = append(problems, fmt.Sprintf("%q has no keys", id))
+		}
+		for _, k := range merged[id] {
+			if !isValidKey(k) {
+				problems = append(problems, fmt.Sprintf("%q uses unknown key %q", id, k))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid keybindings: %s", strings.Join(problems, "; "))
+	}
+
+	configuredKeys = merged
+	keyMaps := allKeyMaps(i18n)
+
+	for _, id := range sortedIDs(merged) {
+		if !knownActions[id] {
+			problems = append(problems, fmt.Sprintf("unknown action %q", id))
+		}
+	}
+	listKeys := keyMaps["list"].(ListKeyMap)
+	for _, name := range sortedIDs(keyMaps) {
+		reserved := []key.Binding{listKeys.Quit}
+		if name == "list" {
+			reserved = append(reserved, listModelBindings(listKeys)...)
+		}
+		problems = append(problems, conflicts(name, keyMaps[name], reserved)...)
+	}
+	if len(problems) > 0 {
+		configuredKeys = nil
+		return fmt.Errorf("invalid keybindings: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func allKeyMaps(i18n *services.I18nService) map[string]help.KeyMap {
+	return map[string]help.KeyMap{
+		"list":            NewListKeys(i18n),
+		"form":            NewAddNewKeys(i18n),
+		"folder picker":   NewFolderPickerKeys(i18n),
+		"folder tree":     NewFolderTreeKeys(i18n),
+		"go to prompt":    NewGoToPromptKeys(i18n),
+		"command palette": NewCommandPaletteKeys(i18n),
+		"tag form":        NewTagFormKeys(i18n),
+		"variable form":   NewVariableFormKeys(i18n),
+		"history":         NewHistoryKeys(i18n),
+	}
+}
+
+// listModelBindings returns the keys the bubbles list under the list key map
+// handles, with its moves named as the actions they share keys with. Of its
+// quit keys only "q" is kept, as esc goes back first.
+func listModelBindings(keys ListKeyMap) []key.Binding {
+	model := NewListModelKeys(keys.Up, keys.Down)
+	model.CursorUp.SetHelp(keys.Up.Help().Key, keys.Up.Help().Desc)
+	model.CursorDown.SetHelp(keys.Down.Help().Key, keys.Down.Help().Desc)
+	model.Quit.SetKeys("q")
+	return []key.Binding{
+		model.CursorUp, model.CursorDown, model.PrevPage, model.NextPage,
+		model.GoToStart, model.GoToEnd, model.Filter, model.ShowFullHelp, model.Quit,
+	}
+}
+
+// conflicts reports every key bound to more than one action of keyMap, or to
+// an action and one of the reserved bindings, which are handled around it.
+func conflicts(name string, keyMap help.KeyMap, reserved []key.Binding) []string {
+	var problems []string
+	owners := map[string]string{}
+	for _, group := range append([][]key.Binding{reserved}, keyMap.FullHelp()...) {
+		for _, binding := range group {
+			for _, k := range binding.Keys() {
+				desc := binding.Help().Desc
+				if owner, taken := owners[k]; taken && owner != desc {
+					problems = append(problems, fmt.Sprintf("%s: %q is bound to both %q and %q", name, keyLabel(k), owner, desc))
+					continue
+				}
+				owners[k] = desc
+			}
+		}
+	}
+	return problems
+}
+
+func isValidKey(k string) bool {
+	if rest, ok := strings.CutPrefix(k, "alt+"); ok && rest != "" {
+		k = rest
+	}
+	if _, ok := keyTypes[k]; ok {
+		return true
+	}
+	return utf8.RuneCountInString(k) == 1
+}
+
+func keyLabel(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+func presetNames() []string {
+	return sortedIDs(Presets)
+}
+
+func sortedIDs[V any](m map[string]V) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
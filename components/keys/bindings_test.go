@@ -0,0 +1,100 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+func newTestI18n(t *testing.T) *services.I18nService {
+	t.Helper()
+	i18n, err := services.NewI18nService("en", "../../locales")
+	if err != nil {
+		t.Fatalf("Failed to load locales: %v", err)
+	}
+	return i18n
+}
+
+func TestConfigure(t *testing.T) {
+	i18n := newTestI18n(t)
+	t.Cleanup(func() { Configure(i18n, models.KeyBindings{}) })
+
+	tests := []struct {
+		name          string
+		bindings      models.KeyBindings
+		errorContains string
+	}{
+		{name: "no bindings"},
+		{name: "custom help key", bindings: models.KeyBindings{Keys: map[string][]string{"help": {"f2"}}}},
+		{name: "unknown preset", bindings: models.KeyBindings{Preset: "nano"}, errorContains: `unknown preset "nano" (available: default, emacs, vim)`},
+		{name: "copy on the delete key", bindings: models.KeyBindings{Keys: map[string][]string{"copy": {"d"}}}, errorContains: `list: "d" is bound to both`},
+		{name: "delete on enter", bindings: models.KeyBindings{Keys: map[string][]string{"delete": {"enter"}}}, errorContains: `list: "enter" is bound to both`},
+		{name: "unknown action", bindings: models.KeyBindings{Keys: map[string][]string{"frobnicate": {"z"}}}, errorContains: `unknown action "frobnicate"`},
+		{name: "unknown key", bindings: models.KeyBindings{Keys: map[string][]string{"help": {"ctrl+shift+x"}}}, errorContains: `"help" uses unknown key "ctrl+shift+x"`},
+		{name: "copy on a list move", bindings: models.KeyBindings{Keys: map[string][]string{"copy": {"j"}}}, errorContains: `list: "j" is bound to both`},
+		{name: "paste on the list filter", bindings: models.KeyBindings{Keys: map[string][]string{"paste": {"/"}}}, errorContains: `list: "/" is bound to both`},
+		{name: "cut on the list quit", bindings: models.KeyBindings{Keys: map[string][]string{"cut": {"q"}}}, errorContains: `list: "q" is bound to both`},
+		{name: "refresh on the quit key", bindings: models.KeyBindings{Keys: map[string][]string{"refresh_suggestions": {"ctrl+c"}}}, errorContains: `variable form: "ctrl+c" is bound to both`},
+		{name: "no keys", bindings: models.KeyBindings{Keys: map[string][]string{"help": {}}}, errorContains: `"help" has no keys`},
+	}
+	for _, name := range presetNames() {
+		tests = append(tests, struct {
+			name          string
+			bindings      models.KeyBindings
+			errorContains string
+		}{name: "preset " + name, bindings: models.KeyBindings{Preset: name}})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Configure(i18n, tt.bindings)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestConfigure_AppliesKeys(t *testing.T) {
+	i18n := newTestI18n(t)
+	t.Cleanup(func() { Configure(i18n, models.KeyBindings{}) })
+
+	if err := Configure(i18n, models.KeyBindings{Preset: "vim", Keys: map[string][]string{"delete": {"X", "delete"}}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	listKeys := NewListKeys(i18n)
+	if keys := listKeys.Delete.Keys(); len(keys) != 2 || keys[0] != "X" || listKeys.Delete.Help().Key != "X" {
+		t.Errorf("Expected delete on X and delete, got %v", keys)
+	}
+	if keys := listKeys.Up.Keys(); len(keys) != 2 || keys[1] != "ctrl+k" {
+		t.Errorf("Expected the vim preset to apply, got %v", keys)
+	}
+
+	if err := Configure(i18n, models.KeyBindings{Keys: map[string][]string{"copy": {"d"}}}); err == nil {
+		t.Fatal("Expected a conflict")
+	}
+	if keys := NewListKeys(i18n).Delete.Keys(); len(keys) != 1 || keys[0] != "d" {
+		t.Errorf("Expected the default bindings after a failed configuration, got %v", keys)
+	}
+}
+
+func TestDefaultHelpKey(t *testing.T) {
+	i18n := newTestI18n(t)
+	Configure(i18n, models.KeyBindings{})
+
+	// Terminals send ctrl+h for backspace, which goes to the parent folder.
+	help := NewListKeys(i18n).Help
+	for _, k := range help.Keys() {
+		if k == "ctrl+h" || k == "backspace" {
+			t.Errorf("Expected help off the backspace keys, got %v", help.Keys())
+		}
+	}
+}
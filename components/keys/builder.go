@@ -6,12 +6,15 @@ import (
 	"github.com/evertonstz/go-workflows/shared/di/services"
 )
 
+// KeyBuilder creates the bindings of every key map. Each binding has an ID
+// that the user's keybindings refer to when remapping it.
 type KeyBuilder struct {
-	i18n *services.I18nService
+	i18n      *services.I18nService
+	overrides map[string][]string
 }
 
 func NewKeyBuilder(i18n *services.I18nService) *KeyBuilder {
-	return &KeyBuilder{i18n: i18n}
+	return &KeyBuilder{i18n: i18n, overrides: configuredKeys}
 }
 
 type NavigationKeySet struct {
@@ -34,39 +37,143 @@ type WorkflowActionKeySet struct {
 	AddNewWorkflow key.Binding
 	Delete         key.Binding
 	CopyWorkflow   key.Binding
+	RunWorkflow    key.Binding
+	ResumeWorkflow key.Binding
+	MoveWorkflow   key.Binding
+	ToggleFavorite key.Binding
+	History        key.Binding
+	CycleVariant   key.Binding
+}
+
+type FolderActionKeySet struct {
+	NewFolder  key.Binding
+	EditFolder key.Binding
+}
+
+type FolderNavigationKeySet struct {
+	GoToFolder   key.Binding
+	ParentFolder key.Binding
+	RootFolder   key.Binding
+}
+
+type ClipboardActionKeySet struct {
+	Duplicate key.Binding
+	Cut       key.Binding
+	Copy      key.Binding
+	Paste     key.Binding
+}
+
+type SelectionKeySet struct {
+	ToggleSelect    key.Binding
+	SelectAll       key.Binding
+	InvertSelection key.Binding
+	TagWorkflows    key.Binding
+}
+
+type ViewKeySet struct {
+	CycleSort          key.Binding
+	ToggleFoldersFirst key.Binding
+	MoveEntryUp        key.Binding
+	MoveEntryDown      key.Binding
+	ToggleSidebar      key.Binding
+	FocusSidebar       key.Binding
+	CommandPalette     key.Binding
 }
 
 func (b *KeyBuilder) Navigation() NavigationKeySet {
 	return NavigationKeySet{
-		Up:    b.key("up", "↑", "key_help_move_up"),
-		Down:  b.key("down", "↓", "key_help_move_down"),
-		Left:  b.key("left", "←", "key_help_move_left"),
-		Right: b.key("right", "→", "key_help_move_right"),
+		Up:    b.key("up", "up", "↑", "key_help_move_up"),
+		Down:  b.key("down", "down", "↓", "key_help_move_down"),
+		Left:  b.key("left", "left", "←", "key_help_move_left"),
+		Right: b.key("right", "right", "→", "key_help_move_right"),
 	}
 }
 
 func (b *KeyBuilder) Actions() ActionKeySet {
 	return ActionKeySet{
-		Submit: b.key("enter", "enter", "key_help_submit"),
-		Close:  b.key("esc", "esc", "key_help_close"),
-		Help:   b.key("ctrl+h", "ctrl+h", "key_help_toggle_help"),
-		Quit:   b.key("ctrl+c", "ctrl+c", "key_help_quit"),
-		Enter:  b.key("enter", "enter", "key_help_open_folder"),
-		Esc:    b.key("esc", "esc", "key_help_close"),
+		Submit: b.key("submit", "enter", "enter", "key_help_submit"),
+		Close:  b.key("close", "esc", "esc", "key_help_close"),
+		Help:   b.key("help", "f1", "f1", "key_help_toggle_help"),
+		Quit:   b.key("quit", "ctrl+c", "ctrl+c", "key_help_quit"),
+		Enter:  b.key("open_folder", "enter", "enter", "key_help_open_folder"),
+		Esc:    b.key("back", "esc", "esc", "key_help_close"),
 	}
 }
 
 func (b *KeyBuilder) WorkflowActions() WorkflowActionKeySet {
 	return WorkflowActionKeySet{
-		AddNewWorkflow: b.key("a", "a", "key_help_add_workflow"),
-		Delete:         b.key("d", "d", "key_help_delete_workflow"),
-		CopyWorkflow:   b.key("y", "y", "key_help_copy_workflow"),
+		AddNewWorkflow: b.key("add_workflow", "a", "a", "key_help_add_workflow"),
+		Delete:         b.key("delete", "d", "d", "key_help_delete_workflow"),
+		CopyWorkflow:   b.key("copy_command", "y", "y", "key_help_copy_workflow"),
+		RunWorkflow:    b.key("run", "r", "r", "key_help_run_workflow"),
+		ResumeWorkflow: b.key("resume", "R", "R", "key_help_resume_workflow"),
+		MoveWorkflow:   b.key("move", "m", "m", "key_help_move_workflow"),
+		ToggleFavorite: b.key("toggle_favorite", "s", "s", "key_help_toggle_favorite"),
+		History:        b.key("history", "H", "H", "key_help_history"),
+		CycleVariant:   b.key("cycle_variant", "w", "w", "key_help_cycle_variant"),
 	}
 }
 
-func (b *KeyBuilder) key(keys, short, helpKey string) key.Binding {
+func (b *KeyBuilder) FolderActions() FolderActionKeySet {
+	return FolderActionKeySet{
+		NewFolder:  b.key("new_folder", "n", "n", "key_help_new_folder"),
+		EditFolder: b.key("edit_folder", "e", "e", "key_help_edit_folder"),
+	}
+}
+
+func (b *KeyBuilder) FolderNavigation() FolderNavigationKeySet {
+	return FolderNavigationKeySet{
+		GoToFolder:   b.key("go_to_folder", "ctrl+g", "ctrl+g", "key_help_go_to_folder"),
+		ParentFolder: b.keys("parent_folder", []string{"backspace", "-"}, "-", "key_help_parent_folder"),
+		RootFolder:   b.key("root_folder", "~", "~", "key_help_root_folder"),
+	}
+}
+
+func (b *KeyBuilder) ClipboardActions() ClipboardActionKeySet {
+	return ClipboardActionKeySet{
+		Duplicate: b.key("duplicate", "D", "D", "key_help_duplicate"),
+		Cut:       b.key("cut", "x", "x", "key_help_cut"),
+		Copy:      b.key("copy", "c", "c", "key_help_copy"),
+		Paste:     b.key("paste", "p", "p", "key_help_paste"),
+	}
+}
+
+func (b *KeyBuilder) Selection() SelectionKeySet {
+	return SelectionKeySet{
+		ToggleSelect:    b.key("toggle_select", " ", "space", "key_help_toggle_select"),
+		SelectAll:       b.key("select_all", "A", "A", "key_help_select_all"),
+		InvertSelection: b.key("invert_selection", "i", "i", "key_help_invert_selection"),
+		TagWorkflows:    b.key("tag_workflows", "t", "t", "key_help_tag_workflows"),
+	}
+}
+
+func (b *KeyBuilder) View() ViewKeySet {
+	return ViewKeySet{
+		CycleSort:          b.key("cycle_sort", "o", "o", "key_help_cycle_sort"),
+		ToggleFoldersFirst: b.key("toggle_folders_first", "O", "O", "key_help_toggle_folders_first"),
+		MoveEntryUp:        b.keys("move_entry_up", []string{"shift+up", "K"}, "K", "key_help_move_entry_up"),
+		MoveEntryDown:      b.keys("move_entry_down", []string{"shift+down", "J"}, "J", "key_help_move_entry_down"),
+		ToggleSidebar:      b.key("toggle_sidebar", "S", "S", "key_help_toggle_sidebar"),
+		FocusSidebar:       b.key("focus_sidebar", "tab", "tab", "key_help_focus_sidebar"),
+		CommandPalette:     b.keys("command_palette", []string{"ctrl+p", ":"}, "ctrl+p", "key_help_command_palette"),
+	}
+}
+
+func (b *KeyBuilder) key(id, keys, short, helpKey string) key.Binding {
+	return b.keys(id, []string{keys}, short, helpKey)
+}
+
+// keys binds the action id to keys, or to the keys the user configured for
+// it, in which case the first of them is shown in the help.
+func (b *KeyBuilder) keys(id string, keys []string, short, helpKey string) key.Binding {
+	knownActions[id] = true
+	if override, ok := b.overrides[id]; ok {
+		keys = override
+		short = keyLabel(override[0])
+	}
+
 	return key.NewBinding(
-		key.WithKeys(keys),
+		key.WithKeys(keys...),
 		key.WithHelp(short, b.i18n.Translate(helpKey)),
 	)
 }
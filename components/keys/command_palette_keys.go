@@ -0,0 +1,39 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type CommandPaletteKeyMap struct {
+	NavigationKeySet
+	Submit key.Binding
+	Close  key.Binding
+	Help   key.Binding
+	Quit   key.Binding
+}
+
+func (k CommandPaletteKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Submit, k.Close}
+}
+
+func (k CommandPaletteKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Submit, k.Close, k.Help, k.Quit},
+	}
+}
+
+func NewCommandPaletteKeys(i18n *services.I18nService) CommandPaletteKeyMap {
+	builder := NewKeyBuilder(i18n)
+	actions := builder.Actions()
+
+	return CommandPaletteKeyMap{
+		NavigationKeySet: builder.Navigation(),
+		Submit:           builder.key("run_action", "enter", "enter", "key_help_run_action"),
+		Close:            actions.Close,
+		Help:             actions.Help,
+		Quit:             actions.Quit,
+	}
+}
@@ -0,0 +1,41 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type FolderPickerKeyMap struct {
+	NavigationKeySet
+	Submit    key.Binding
+	Close     key.Binding
+	NewFolder key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+}
+
+func (k FolderPickerKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Submit, k.NewFolder, k.Close}
+}
+
+func (k FolderPickerKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Submit, k.NewFolder, k.Close, k.Help, k.Quit},
+	}
+}
+
+func NewFolderPickerKeys(i18n *services.I18nService) FolderPickerKeyMap {
+	builder := NewKeyBuilder(i18n)
+	actions := builder.Actions()
+
+	return FolderPickerKeyMap{
+		NavigationKeySet: builder.Navigation(),
+		Submit:           builder.key("pick_folder", "enter", "enter", "key_help_pick_folder"),
+		Close:            actions.Close,
+		NewFolder:        builder.key("picker_new_folder", "ctrl+n", "ctrl+n", "key_help_new_folder"),
+		Help:             actions.Help,
+		Quit:             actions.Quit,
+	}
+}
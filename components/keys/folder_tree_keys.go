@@ -0,0 +1,43 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type FolderTreeKeyMap struct {
+	NavigationKeySet
+	Open     key.Binding
+	Expand   key.Binding
+	Collapse key.Binding
+	Close    key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+}
+
+func (k FolderTreeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Open, k.Expand, k.Collapse, k.Close}
+}
+
+func (k FolderTreeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Expand, k.Collapse},
+		{k.Open, k.Close, k.Help, k.Quit},
+	}
+}
+
+func NewFolderTreeKeys(i18n *services.I18nService) FolderTreeKeyMap {
+	builder := NewKeyBuilder(i18n)
+	actions := builder.Actions()
+
+	return FolderTreeKeyMap{
+		NavigationKeySet: builder.Navigation(),
+		Open:             builder.key("tree_open", "enter", "enter", "key_help_open_folder"),
+		Expand:           builder.keys("tree_expand", []string{"right", "l"}, "→", "key_help_expand_folder"),
+		Collapse:         builder.keys("tree_collapse", []string{"left", "h"}, "←", "key_help_collapse_folder"),
+		Close:            builder.keys("tree_close", []string{"tab", "esc"}, "tab", "key_help_focus_list"),
+		Help:             actions.Help,
+		Quit:             actions.Quit,
+	}
+}
@@ -0,0 +1,41 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type GoToPromptKeyMap struct {
+	NavigationKeySet
+	Submit   key.Binding
+	Complete key.Binding
+	Close    key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+}
+
+func (k GoToPromptKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Submit, k.Complete, k.Close}
+}
+
+func (k GoToPromptKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Submit, k.Complete, k.Close, k.Help, k.Quit},
+	}
+}
+
+func NewGoToPromptKeys(i18n *services.I18nService) GoToPromptKeyMap {
+	builder := NewKeyBuilder(i18n)
+	actions := builder.Actions()
+
+	return GoToPromptKeyMap{
+		NavigationKeySet: builder.Navigation(),
+		Submit:           builder.key("go_to_open", "enter", "enter", "key_help_open_folder"),
+		Complete:         builder.key("complete_path", "tab", "tab", "key_help_complete_path"),
+		Close:            actions.Close,
+		Help:             actions.Help,
+		Quit:             actions.Quit,
+	}
+}
@@ -0,0 +1,42 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type HistoryKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Rerun key.Binding
+	Close key.Binding
+	Help  key.Binding
+	Quit  key.Binding
+}
+
+func (k HistoryKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Rerun, k.Close}
+}
+
+func (k HistoryKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Rerun, k.Close, k.Help, k.Quit},
+	}
+}
+
+func NewHistoryKeys(i18n *services.I18nService) HistoryKeyMap {
+	builder := NewKeyBuilder(i18n)
+	navigation := builder.Navigation()
+	actions := builder.Actions()
+
+	return HistoryKeyMap{
+		Up:    navigation.Up,
+		Down:  navigation.Down,
+		Rerun: builder.key("rerun", "enter", "enter", "key_help_rerun"),
+		Close: actions.Close,
+		Help:  actions.Help,
+		Quit:  actions.Quit,
+	}
+}
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
 
 	"github.com/evertonstz/go-workflows/shared/di/services"
 )
@@ -10,6 +11,11 @@ type ListKeyMap struct {
 	NavigationKeySet
 	ActionKeySet
 	WorkflowActionKeySet
+	FolderActionKeySet
+	FolderNavigationKeySet
+	ClipboardActionKeySet
+	SelectionKeySet
+	ViewKeySet
 }
 
 func (k ListKeyMap) ShortHelp() []key.Binding {
@@ -17,10 +23,14 @@ func (k ListKeyMap) ShortHelp() []key.Binding {
 }
 
 func (k ListKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{
-		{k.AddNewWorkflow, k.Delete, k.CopyWorkflow},
-		{k.Up, k.Down, k.Help, k.Quit},
+	groups := k.ActionGroups()
+	bindings := make([][]key.Binding, len(groups))
+	for i, group := range groups {
+		for _, action := range group {
+			bindings[i] = append(bindings[i], action.Binding)
+		}
 	}
+	return bindings
 }
 
 func NewListKeys(i18n *services.I18nService) ListKeyMap {
@@ -28,14 +38,35 @@ func NewListKeys(i18n *services.I18nService) ListKeyMap {
 	navigation := builder.Navigation()
 	actions := builder.Actions()
 	workflowActions := builder.WorkflowActions()
+	folderActions := builder.FolderActions()
+	folderNavigation := builder.FolderNavigation()
+	clipboardActions := builder.ClipboardActions()
+	selection := builder.Selection()
+	view := builder.View()
 
 	return ListKeyMap{
-		NavigationKeySet:     navigation,
-		ActionKeySet:         actions,
-		WorkflowActionKeySet: workflowActions,
+		NavigationKeySet:       navigation,
+		ActionKeySet:           actions,
+		WorkflowActionKeySet:   workflowActions,
+		FolderActionKeySet:     folderActions,
+		FolderNavigationKeySet: folderNavigation,
+		ClipboardActionKeySet:  clipboardActions,
+		SelectionKeySet:        selection,
+		ViewKeySet:             view,
 	}
 }
 
+// NewListModelKeys returns the keys the bubbles list showing the entries
+// handles when no action matched: its defaults, moving with the configured
+// up and down keys too, without "d", which deletes entries.
+func NewListModelKeys(up, down key.Binding) list.KeyMap {
+	keyMap := list.DefaultKeyMap()
+	keyMap.NextPage.SetKeys("right", "l", "pgdown", "f")
+	keyMap.CursorUp.SetKeys(append(up.Keys(), "k")...)
+	keyMap.CursorDown.SetKeys(append(down.Keys(), "j")...)
+	return keyMap
+}
+
 var LisKeys ListKeyMap
 
 func InitializeGlobalKeys(i18n *services.I18nService) {
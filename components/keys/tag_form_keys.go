@@ -0,0 +1,38 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type TagFormKeyMap struct {
+	Submit     key.Binding
+	Close      key.Binding
+	ToggleMode key.Binding
+	Help       key.Binding
+	Quit       key.Binding
+}
+
+func (k TagFormKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Submit, k.ToggleMode, k.Close}
+}
+
+func (k TagFormKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Submit, k.ToggleMode, k.Close, k.Help, k.Quit},
+	}
+}
+
+func NewTagFormKeys(i18n *services.I18nService) TagFormKeyMap {
+	builder := NewKeyBuilder(i18n)
+	actions := builder.Actions()
+
+	return TagFormKeyMap{
+		Submit:     actions.Submit,
+		Close:      actions.Close,
+		ToggleMode: builder.key("toggle_tag_mode", "tab", "tab", "key_help_toggle_tag_mode"),
+		Help:       actions.Help,
+		Quit:       actions.Quit,
+	}
+}
@@ -0,0 +1,48 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type VariableFormKeyMap struct {
+	NavigationKeySet
+	Submit             key.Binding
+	Complete           key.Binding
+	NextSuggestion     key.Binding
+	PreviousSuggestion key.Binding
+	RefreshSuggestions key.Binding
+	Close              key.Binding
+	Help               key.Binding
+	Quit               key.Binding
+}
+
+func (k VariableFormKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Submit, k.Complete, k.Close}
+}
+
+func (k VariableFormKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Left, k.Right},
+		{k.NextSuggestion, k.PreviousSuggestion, k.RefreshSuggestions},
+		{k.Submit, k.Complete, k.Close, k.Help, k.Quit},
+	}
+}
+
+func NewVariableFormKeys(i18n *services.I18nService) VariableFormKeyMap {
+	builder := NewKeyBuilder(i18n)
+	actions := builder.Actions()
+
+	return VariableFormKeyMap{
+		NavigationKeySet:   builder.Navigation(),
+		Submit:             actions.Submit,
+		Complete:           builder.key("complete_path", "tab", "tab", "key_help_complete_path"),
+		NextSuggestion:     builder.key("next_suggestion", "ctrl+n", "ctrl+n", "key_help_next_suggestion"),
+		PreviousSuggestion: builder.key("previous_suggestion", "ctrl+p", "ctrl+p", "key_help_previous_suggestion"),
+		RefreshSuggestions: builder.key("refresh_suggestions", "ctrl+r", "ctrl+r", "key_help_refresh_suggestions"),
+		Close:              actions.Close,
+		Help:               actions.Help,
+		Quit:               actions.Quit,
+	}
+}
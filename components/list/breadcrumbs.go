@@ -0,0 +1,40 @@
+package list
+
+import "strings"
+
+const breadcrumbSeparator = " › "
+
+// Crumb is one segment of the path leading to a folder.
+type Crumb struct {
+	Name string
+	Path string
+}
+
+// Breadcrumbs splits path into the folders leading to it, starting with the
+// root, which is named rootName.
+func Breadcrumbs(path, rootName string) []Crumb {
+	crumbs := []Crumb{{Name: rootName, Path: "/"}}
+	if IsVirtualPath(path) {
+		return append(crumbs, Crumb{Name: DisplayPath(path), Path: path})
+	}
+
+	current := ""
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		current += "/" + segment
+		crumbs = append(crumbs, Crumb{Name: segment, Path: current})
+	}
+	return crumbs
+}
+
+// RenderBreadcrumbs joins the breadcrumbs of path into a single line.
+func RenderBreadcrumbs(path, rootName string) string {
+	crumbs := Breadcrumbs(path, rootName)
+	names := make([]string, len(crumbs))
+	for i, crumb := range crumbs {
+		names[i] = crumb.Name
+	}
+	return strings.Join(names, breadcrumbSeparator)
+}
@@ -0,0 +1,95 @@
+package list
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/notification"
+	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+const (
+	cutMark  = "✂ "
+	copyMark = "⧉ "
+)
+
+type clipboardMode uint
+
+const (
+	clipboardCopy clipboardMode = iota
+	clipboardCut
+)
+
+// clipboard holds the entries marked with cut or copy until they are pasted.
+// Entries are identified by item ID or folder path so they survive navigation.
+type clipboard struct {
+	mode    clipboardMode
+	entries []entryRef
+}
+
+func (c clipboard) markFor(folderPath, itemID string) string {
+	if indexOfRef(c.entries, entryRef{folderPath: folderPath, itemID: itemID}) < 0 {
+		return ""
+	}
+	if c.mode == clipboardCut {
+		return cutMark
+	}
+	return copyMark
+}
+
+func (c *clipboard) toggle(mode clipboardMode, entry entryRef) {
+	if c.mode != mode {
+		c.entries = nil
+		c.mode = mode
+	}
+
+	c.entries = toggleRef(c.entries, entry)
+}
+
+func (m NavigableModel) HasClipboard() bool {
+	return len(m.clipboard.entries) > 0
+}
+
+// ClearClipboard drops every marked entry, typically after a cut has been
+// pasted.
+func (m *NavigableModel) ClearClipboard() {
+	m.clipboard = clipboard{}
+	m.refreshMarks()
+}
+
+func (m *NavigableModel) toggleClipboardMark(mode clipboardMode) tea.Cmd {
+	currentItem := m.CurrentItem()
+	if currentItem == nil || isVirtual(currentItem) {
+		return nil
+	}
+
+	m.clipboard.toggle(mode, entryFor(currentItem))
+	m.refreshMarks()
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	return notification.ShowNotificationCmd(i18n.TranslateWithData("notification_clipboard_marked", map[string]interface{}{
+		"Count": len(m.clipboard.entries),
+		"Key":   helpkeys.LisKeys.Paste.Help().Key,
+	}))
+}
+
+func (m NavigableModel) paste() tea.Cmd {
+	if !m.HasClipboard() {
+		return nil
+	}
+
+	itemIDs, folderPaths := splitRefs(m.clipboard.entries)
+	return shared.PasteCmd(itemIDs, folderPaths, m.clipboard.mode == clipboardCut, m.TargetPath())
+}
+
+func (m NavigableModel) duplicateCurrent() tea.Cmd {
+	currentItem := m.CurrentItem()
+	if currentItem == nil || isVirtual(currentItem) {
+		return nil
+	}
+
+	entry := entryFor(currentItem)
+	return shared.DuplicateCmd(entry.itemID, entry.folderPath)
+}
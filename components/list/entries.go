@@ -0,0 +1,73 @@
+package list
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// entryRef identifies a folder by path or a workflow by ID, so that marks and
+// selections survive reloads of the list.
+type entryRef struct {
+	folderPath string
+	itemID     string
+}
+
+func entryFor(item ListItemInterface) entryRef {
+	if item.IsFolder() {
+		return entryRef{folderPath: item.(FolderItem).GetFolder().Path}
+	}
+	return entryRef{itemID: item.(WorkflowItem).GetItem().ID}
+}
+
+func indexOfRef(refs []entryRef, ref entryRef) int {
+	for i, r := range refs {
+		if r == ref {
+			return i
+		}
+	}
+	return -1
+}
+
+func toggleRef(refs []entryRef, ref entryRef) []entryRef {
+	if i := indexOfRef(refs, ref); i >= 0 {
+		return append(refs[:i], refs[i+1:]...)
+	}
+	return append(refs, ref)
+}
+
+func splitRefs(refs []entryRef) (itemIDs, folderPaths []string) {
+	for _, ref := range refs {
+		if ref.itemID != "" {
+			itemIDs = append(itemIDs, ref.itemID)
+		} else {
+			folderPaths = append(folderPaths, ref.folderPath)
+		}
+	}
+	return itemIDs, folderPaths
+}
+
+func (m NavigableModel) markFor(ref entryRef) string {
+	mark := m.clipboard.markFor(ref.folderPath, ref.itemID)
+	if indexOfRef(m.selection, ref) >= 0 {
+		mark = selectedMark + mark
+	}
+	return mark
+}
+
+// refreshMarks re-renders the selection and clipboard marks of the loaded
+// entries without changing the cursor position.
+func (m *NavigableModel) refreshMarks() {
+	for i, listItem := range m.list.Items() {
+		switch item := listItem.(type) {
+		case FolderItem:
+			if item.IsVirtual() {
+				continue
+			}
+			item.mark = m.markFor(entryFor(item))
+			m.list.SetItem(i, list.Item(item))
+		case WorkflowItem:
+			item.mark = m.markFor(entryFor(item))
+			item.favorite = m.isFavorite(item.item.ID)
+			m.list.SetItem(i, list.Item(item))
+		}
+	}
+}
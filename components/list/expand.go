@@ -0,0 +1,51 @@
+package list
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/components/notification"
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+func (m *NavigableModel) SetPlatform(platform models.Platform) {
+	m.platform = platform
+}
+
+// expand returns item with the command that applies where it runs, in the
+// shell its interpreter gives it, and its includes expanded.
+func (m NavigableModel) expand(item models.ItemV2) (models.ItemV2, error) {
+	if m.database == nil {
+		return item.ForPlatform(m.platformOf(item)), nil
+	}
+	return m.database.ExpandIncludes(item, m.platformOf(item))
+}
+
+// activeVariant returns the index of the variant of item that applies where
+// it runs, or -1 for its command.
+func (m NavigableModel) activeVariant(item models.ItemV2) int {
+	return item.ActiveVariant(m.platformOf(item))
+}
+
+// describe returns item as the preview shows it, with its includes naming
+// workflows by path, and the first of them that cannot be expanded.
+func (m NavigableModel) describe(item models.ItemV2) (models.ItemV2, error) {
+	if m.database == nil {
+		return item, nil
+	}
+	return m.database.DescribeIncludes(item)
+}
+
+func (m NavigableModel) platformOf(item models.ItemV2) models.Platform {
+	interpreter := item.RunSettings.Interpreter
+	if m.database != nil {
+		interpreter = m.database.RunSettingsOf(item).Interpreter
+	}
+	return m.platform.WithInterpreter(interpreter)
+}
+
+func includeErrorCmd(err error) tea.Cmd {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	return notification.ShowNotificationCmd(i18n.TranslateWithData("error_include", map[string]interface{}{"Error": err.Error()}))
+}
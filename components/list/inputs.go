@@ -6,14 +6,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
-
-var (
-	focusedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	blurredStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
-	focusedButton = focusedStyle.Render("[ Submit ]")
-	blurredButton = fmt.Sprintf("[ %s ]", blurredStyle.Render("Submit"))
+	"github.com/evertonstz/go-workflows/components/theme"
 )
 
 type (
@@ -120,22 +114,26 @@ func (m inputsModel) Update(msg tea.Msg) (inputsModel, tea.Cmd) {
 }
 
 func (m inputsModel) View() string {
+	styles := theme.Current()
+	focusedButton := styles.Focused.Render("[ Submit ]")
+	blurredButton := fmt.Sprintf("[ %s ]", styles.Blurred.Render("Submit"))
+
 	switch m.selectedInput {
 	case title:
-		return lipgloss.JoinVertical(lipgloss.Top, focusedStyle.Render(m.Title.View()),
-			blurredStyle.Render(m.Description.View()),
+		return lipgloss.JoinVertical(lipgloss.Top, styles.Focused.Render(m.Title.View()),
+			styles.Blurred.Render(m.Description.View()),
 			blurredButton)
 	case description:
-		return lipgloss.JoinVertical(lipgloss.Top, blurredStyle.Render(m.Title.View()),
-			focusedStyle.Render(m.Description.View()),
+		return lipgloss.JoinVertical(lipgloss.Top, styles.Blurred.Render(m.Title.View()),
+			styles.Focused.Render(m.Description.View()),
 			blurredButton)
 	case submit:
-		return lipgloss.JoinVertical(lipgloss.Top, blurredStyle.Render(m.Title.View()),
-			blurredStyle.Render(m.Description.View()),
+		return lipgloss.JoinVertical(lipgloss.Top, styles.Blurred.Render(m.Title.View()),
+			styles.Blurred.Render(m.Description.View()),
 			focusedButton)
 	default:
-		return lipgloss.JoinVertical(lipgloss.Top, blurredStyle.Render(m.Title.View()),
-			blurredStyle.Render(m.Description.View()),
+		return lipgloss.JoinVertical(lipgloss.Top, styles.Blurred.Render(m.Title.View()),
+			styles.Blurred.Render(m.Description.View()),
 			blurredButton)
 	}
 }
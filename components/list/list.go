@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
 	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared"
 	"github.com/evertonstz/go-workflows/shared/messages"
@@ -71,14 +72,13 @@ func (m *Model) SetSize(width, height int) {
 }
 
 func (m Model) setCurrentItemCmd(cmds []tea.Cmd) []tea.Cmd {
-	cmds = append(cmds, shared.SetCurrentItemCmd(models.Item{
+	cmds = append(cmds, shared.SetCurrentItemCmd(models.ItemV2{
 		Title:       m.CurentItem().Title(),
 		Desc:        m.CurentItem().Description(),
 		Command:     m.CurentItem().Command(),
 		DateAdded:   m.CurentItem().DateAdded(),
 		DateUpdated: m.CurentItem().DateUpdated(),
-	},
-	))
+	}, models.Risk{}, models.RunSettings{}, -1, nil))
 	return cmds
 }
 
@@ -163,7 +163,7 @@ func (m Model) View() string {
 }
 
 func New() Model {
-	m := Model{list: list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0), inputs: newInputsModel()}
+	m := Model{list: list.New([]list.Item{}, theme.Current().ListDelegate(), 0, 0), inputs: newInputsModel()}
 	m.list.SetShowTitle(false)
 	m.list.SetShowHelp(false)
 	m.Init()
@@ -1,11 +1,14 @@
 package list
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 
 	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
 	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared"
 	"github.com/evertonstz/go-workflows/shared/di/services"
@@ -13,20 +16,47 @@ import (
 
 type FolderItem struct {
 	folder models.FolderV2
+	mark   string
+	icon   string
+}
+
+func (f FolderItem) Title() string {
+	if f.icon != "" {
+		return f.mark + f.icon + f.folder.Name
+	}
+	return f.mark + "📁 " + f.folder.Name
 }
 
-func (f FolderItem) Title() string              { return "📁 " + f.folder.Name }
 func (f FolderItem) Description() string        { return f.folder.Description }
 func (f FolderItem) FilterValue() string        { return f.folder.Name }
 func (f FolderItem) IsFolder() bool             { return true }
+func (f FolderItem) IsVirtual() bool            { return IsVirtualPath(f.folder.Path) }
 func (f FolderItem) GetFolder() models.FolderV2 { return f.folder }
 
 type WorkflowItem struct {
-	item models.ItemV2
+	item     models.ItemV2
+	mark     string
+	favorite bool
+	risk     models.Risk
+}
+
+func (w WorkflowItem) Title() string {
+	title := w.mark + "📄 " + w.item.Title + riskBadge(w.risk.Level)
+	if w.favorite {
+		return title + " ★"
+	}
+	return title
+}
+
+func (w WorkflowItem) Risk() models.Risk { return w.risk }
+
+func (w WorkflowItem) Description() string {
+	if len(w.item.Tags) == 0 {
+		return w.item.Desc
+	}
+	return w.item.Desc + " #" + strings.Join(w.item.Tags, " #")
 }
 
-func (w WorkflowItem) Title() string          { return "📄 " + w.item.Title }
-func (w WorkflowItem) Description() string    { return w.item.Desc }
 func (w WorkflowItem) FilterValue() string    { return w.item.Title }
 func (w WorkflowItem) IsFolder() bool         { return false }
 func (w WorkflowItem) GetItem() models.ItemV2 { return w.item }
@@ -41,6 +71,12 @@ type NavigableModel struct {
 	currentPath     string
 	lastSelectedIdx int
 	database        *services.DatabaseManagerV2
+	usage           *services.UsageService
+	views           *services.ViewStateService
+	risk            *services.RiskService
+	platform        models.Platform
+	clipboard       clipboard
+	selection       []entryRef
 }
 
 func (m NavigableModel) CurrentItem() ListItemInterface {
@@ -69,6 +105,12 @@ func (m NavigableModel) IsAtRoot() bool {
 	return m.currentPath == "/"
 }
 
+// IsFiltering reports whether the filter of the list is being typed, which
+// takes every key press.
+func (m NavigableModel) IsFiltering() bool {
+	return m.list.FilterState() == list.Filtering
+}
+
 func (m NavigableModel) AllItems() []ListItemInterface {
 	var items []ListItemInterface
 	for _, i := range m.list.Items() {
@@ -94,9 +136,34 @@ func (m *NavigableModel) SetDatabase(db *services.DatabaseManagerV2) {
 	m.lastSelectedIdx = 0
 }
 
-func (m *NavigableModel) ReloadCurrentFolder() {
+// ReloadCurrentFolder re-reads the current folder from the database and
+// returns the command that refreshes the preview of the selected entry. The
+// selected entry stays selected when it is still listed.
+func (m *NavigableModel) ReloadCurrentFolder() tea.Cmd {
+	selectedIdx := m.list.Index()
+	var selected *entryRef
+	if currentItem := m.CurrentItem(); currentItem != nil {
+		ref := entryFor(currentItem)
+		selected = &ref
+	}
+
 	m.loadFolderContents(m.currentPath)
-	m.lastSelectedIdx = -1
+	if selected != nil {
+		for i, listItem := range m.AllItems() {
+			if entryFor(listItem) == *selected {
+				selectedIdx = i
+				break
+			}
+		}
+	}
+	if selectedIdx >= len(m.list.Items()) {
+		selectedIdx = len(m.list.Items()) - 1
+	}
+	if selectedIdx > 0 {
+		m.list.Select(selectedIdx)
+	}
+	m.lastSelectedIdx = m.list.Index()
+	return tea.Batch(m.setCurrentItemCmd(nil)...)
 }
 
 func (m *NavigableModel) loadFolderContents(folderPath string) {
@@ -106,29 +173,45 @@ func (m *NavigableModel) loadFolderContents(folderPath string) {
 
 	var listItems []list.Item
 
+	if IsVirtualPath(folderPath) {
+		for _, item := range m.VirtualItems(folderPath) {
+			listItems = append(listItems, m.workflowItem(item))
+		}
+		m.list.SetItems(listItems)
+		m.list.Select(0)
+		return
+	}
+
 	subfolders, items, err := m.database.GetFolderContents(folderPath)
 	if err != nil {
 		m.list.SetItems([]list.Item{})
 		return
 	}
 
-	for _, folder := range subfolders {
-		listItems = append(listItems, FolderItem{folder: folder})
-	}
-
-	for _, item := range items {
-		listItems = append(listItems, WorkflowItem{item: item})
+	if folderPath == "/" {
+		listItems = append(listItems, m.virtualFolderItems()...)
 	}
+	listItems = append(listItems, m.sortedEntries(m.viewFor(folderPath), subfolders, items)...)
 
 	m.list.SetItems(listItems)
 	m.list.Select(0) // Reset selection to top
 }
 
+func (m NavigableModel) workflowItem(item models.ItemV2) WorkflowItem {
+	return WorkflowItem{
+		item:     item,
+		mark:     m.markFor(entryRef{itemID: item.ID}),
+		favorite: m.isFavorite(item.ID),
+		risk:     m.riskOf(item),
+	}
+}
+
 func (m *NavigableModel) NavigateToFolder(folderPath string) tea.Cmd {
 	m.currentPath = folderPath
+	m.selection = nil
 	m.loadFolderContents(folderPath)
 
-	m.lastSelectedIdx = -1 // Force trigger of setCurrentItemCmd on next update
+	m.lastSelectedIdx = m.list.Index()
 
 	var cmds []tea.Cmd
 	cmds = append(cmds, shared.NavigatedToFolderCmd(folderPath))
@@ -170,15 +253,13 @@ func (m NavigableModel) setCurrentItemCmd(cmds []tea.Cmd) []tea.Cmd {
 		folder := currentItem.(FolderItem).GetFolder()
 		cmds = append(cmds, shared.SetCurrentFolderCmd(folder))
 	} else {
-		item := currentItem.(WorkflowItem).GetItem()
-		v1Item := models.Item{
-			Title:       item.Title,
-			Desc:        item.Desc,
-			Command:     item.Command,
-			DateAdded:   item.DateAdded,
-			DateUpdated: item.DateUpdated,
+		item := currentItem.(WorkflowItem)
+		var inherited models.RunSettings
+		if m.database != nil {
+			inherited = m.database.FolderRunSettings(item.GetItem().FolderPath)
 		}
-		cmds = append(cmds, shared.SetCurrentItemCmd(v1Item))
+		described, includeErr := m.describe(item.GetItem())
+		cmds = append(cmds, shared.SetCurrentItemCmd(described, item.Risk(), inherited, m.activeVariant(item.GetItem()), includeErr))
 	}
 	return cmds
 }
@@ -207,13 +288,84 @@ func (m NavigableModel) Update(msg tea.Msg) (NavigableModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.IsFiltering() {
+			break
+		}
+
 		switch {
 		case key.Matches(msg, helpkeys.LisKeys.CopyWorkflow):
+			if m.HasSelection() {
+				return m, m.copySelection()
+			}
+			currentItem := m.CurrentItem()
+			if currentItem != nil && !currentItem.IsFolder() {
+				item, err := m.expand(currentItem.(WorkflowItem).GetItem())
+				if err != nil {
+					return m, includeErrorCmd(err)
+				}
+				return m, shared.WithVariablesCmd(item, func(values map[string]string) tea.Cmd {
+					expanded := item.WithValues(values)
+					text := models.LanguageByID(expanded.GetLanguage()).CopyText(expanded.Script())
+					return m.confirmRisky(shared.CopyToClipboardCmd(text, item.ID), expanded)
+				})
+			}
+
+		case key.Matches(msg, helpkeys.LisKeys.RunWorkflow):
 			currentItem := m.CurrentItem()
 			if currentItem != nil && !currentItem.IsFolder() {
-				workflowItem := currentItem.(WorkflowItem)
-				return m, shared.CopyToClipboardCmd(workflowItem.GetItem().Command)
+				return m, shared.RunWorkflowCmd(currentItem.(WorkflowItem).GetItem().ID, false)
 			}
+			return m, nil
+
+		case key.Matches(msg, helpkeys.LisKeys.ResumeWorkflow):
+			currentItem := m.CurrentItem()
+			if currentItem != nil && !currentItem.IsFolder() {
+				return m, shared.RunWorkflowCmd(currentItem.(WorkflowItem).GetItem().ID, true)
+			}
+			return m, nil
+
+		case key.Matches(msg, helpkeys.LisKeys.ToggleFavorite):
+			currentItem := m.CurrentItem()
+			if currentItem != nil && !currentItem.IsFolder() {
+				return m, shared.ToggleFavoriteCmd(currentItem.(WorkflowItem).GetItem().ID)
+			}
+			return m, nil
+
+		case key.Matches(msg, helpkeys.LisKeys.Duplicate):
+			return m, m.duplicateCurrent()
+
+		case key.Matches(msg, helpkeys.LisKeys.Cut):
+			return m, m.toggleClipboardMark(clipboardCut)
+
+		case key.Matches(msg, helpkeys.LisKeys.Copy):
+			return m, m.toggleClipboardMark(clipboardCopy)
+
+		case key.Matches(msg, helpkeys.LisKeys.Paste):
+			return m, m.paste()
+
+		case key.Matches(msg, helpkeys.LisKeys.CycleSort):
+			return m, m.cycleSortMode()
+
+		case key.Matches(msg, helpkeys.LisKeys.ToggleFoldersFirst):
+			return m, m.toggleFoldersFirst()
+
+		case key.Matches(msg, helpkeys.LisKeys.MoveEntryUp):
+			return m, m.moveCurrent(-1)
+
+		case key.Matches(msg, helpkeys.LisKeys.MoveEntryDown):
+			return m, m.moveCurrent(1)
+
+		case key.Matches(msg, helpkeys.LisKeys.ToggleSelect):
+			m.toggleSelection()
+			return m, nil
+
+		case key.Matches(msg, helpkeys.LisKeys.SelectAll):
+			m.selectAll()
+			return m, nil
+
+		case key.Matches(msg, helpkeys.LisKeys.InvertSelection):
+			m.invertSelection()
+			return m, nil
 
 		case key.Matches(msg, helpkeys.LisKeys.Enter):
 			currentItem := m.CurrentItem()
@@ -223,8 +375,21 @@ func (m NavigableModel) Update(msg tea.Msg) (NavigableModel, tea.Cmd) {
 				return m, cmd
 			}
 
+		case key.Matches(msg, helpkeys.LisKeys.ParentFolder):
+			return m, m.NavigateUp()
+
+		case key.Matches(msg, helpkeys.LisKeys.RootFolder):
+			if !m.IsAtRoot() {
+				return m, m.NavigateToFolder("/")
+			}
+			return m, nil
+
 		case key.Matches(msg, helpkeys.LisKeys.Esc):
-			// Navigate up if not at root
+			// Drop the selection first, then navigate up if not at root
+			if m.HasSelection() {
+				m.ClearSelection()
+				return m, nil
+			}
 			if !m.IsAtRoot() {
 				cmd := m.NavigateUp()
 				return m, cmd
@@ -249,7 +414,7 @@ func (m NavigableModel) View() string {
 }
 
 func NewNavigable() NavigableModel {
-	delegate := list.NewDefaultDelegate()
+	delegate := theme.Current().ListDelegate()
 
 	delegate.ShowDescription = true
 
@@ -260,6 +425,7 @@ func NewNavigable() NavigableModel {
 
 	m.list.SetShowTitle(false)
 	m.list.SetShowHelp(false)
+	m.list.KeyMap = helpkeys.NewListModelKeys(helpkeys.LisKeys.Up, helpkeys.LisKeys.Down)
 	m.Init()
 
 	return m
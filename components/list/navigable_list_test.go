@@ -0,0 +1,86 @@
+package list
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+func newTestNavigable(t *testing.T) NavigableModel {
+	t.Helper()
+
+	i18n, err := services.NewI18nService("en", "../../locales")
+	if err != nil {
+		t.Fatalf("Failed to load locales: %v", err)
+	}
+	di.RegisterService(di.I18nServiceKey, i18n)
+	helpkeys.InitializeGlobalKeys(i18n)
+
+	persistence, err := services.NewPersistenceServiceWithDataFile("go-workflows-test", filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create persistence service: %v", err)
+	}
+	database, err := services.NewDatabaseManagerV2(persistence, services.NewValidationService())
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+	if _, err := database.CreateFolder("ops", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	for _, title := range []string{"deploy-prod", "deploy-staging", "logs"} {
+		if _, err := database.CreateItem(title, "", "echo "+title, "/ops", nil, nil); err != nil {
+			t.Fatalf("Failed to create item: %v", err)
+		}
+	}
+
+	m := NewNavigable()
+	m.SetSize(80, 40)
+	m.SetDatabase(database)
+	m.NavigateToFolder("/ops")
+	return m
+}
+
+func typeKeys(m NavigableModel, msgs ...tea.KeyMsg) NavigableModel {
+	for _, msg := range msgs {
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func runes(s string) []tea.KeyMsg {
+	var msgs []tea.KeyMsg
+	for _, r := range s {
+		msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return msgs
+}
+
+func TestNavigableModel_Filter(t *testing.T) {
+	m := newTestNavigable(t)
+
+	m = typeKeys(m, runes("/")...)
+	if !m.IsFiltering() {
+		t.Fatal("Expected / to start filtering")
+	}
+
+	// The keys of list actions are typed into the filter.
+	m = typeKeys(m, runes("dxcp-pro~")...)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	if filter := m.list.FilterValue(); filter != "dxcp-pro" {
+		t.Errorf("Expected the filter to hold the typed keys, got %q", filter)
+	}
+	if m.CurrentPath() != "/ops" || m.HasClipboard() || m.HasSelection() {
+		t.Errorf("Expected no list action while filtering, got path %s, clipboard %v, selection %v", m.CurrentPath(), m.HasClipboard(), m.HasSelection())
+	}
+
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.IsFiltering() || m.CurrentPath() != "/" {
+		t.Errorf("Expected backspace to go to the parent folder after filtering, got %s", m.CurrentPath())
+	}
+}
@@ -0,0 +1,56 @@
+package list
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+// Badges appended to the titles of risky workflows.
+const (
+	highRiskBadge   = " ⚠"
+	mediumRiskBadge = " △"
+)
+
+func (m *NavigableModel) SetRisk(risk *services.RiskService) {
+	m.risk = risk
+}
+
+// riskOf analyzes the command or the steps of item, with its includes
+// expanded when they can be, finding no risk when no analyzer was set.
+func (m NavigableModel) riskOf(item models.ItemV2) models.Risk {
+	if m.risk == nil {
+		return models.Risk{}
+	}
+	expanded, err := m.expand(item)
+	if err != nil {
+		expanded = item.ForPlatform(m.platformOf(item))
+	}
+	return m.risk.Analyze(expanded.Script())
+}
+
+// confirmRisky returns cmd, or a request to confirm it first when the
+// command of one of items is high risk.
+func (m NavigableModel) confirmRisky(cmd tea.Cmd, items ...models.ItemV2) tea.Cmd {
+	var risk models.Risk
+	for _, item := range items {
+		risk = risk.Merge(m.riskOf(item))
+	}
+	if !risk.IsHigh() {
+		return cmd
+	}
+	return shared.ConfirmRiskCmd(risk, cmd)
+}
+
+func riskBadge(level string) string {
+	switch level {
+	case models.RiskHigh:
+		return highRiskBadge
+	case models.RiskMedium:
+		return mediumRiskBadge
+	default:
+		return ""
+	}
+}
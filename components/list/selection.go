@@ -0,0 +1,91 @@
+package list
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+const selectedMark = "◉ "
+
+func (m NavigableModel) HasSelection() bool {
+	return len(m.selection) > 0
+}
+
+func (m NavigableModel) SelectionCount() int {
+	return len(m.selection)
+}
+
+// SelectedEntries returns the IDs of the selected workflows and the paths of
+// the selected folders, in the order they were selected.
+func (m NavigableModel) SelectedEntries() (itemIDs, folderPaths []string) {
+	return splitRefs(m.selection)
+}
+
+// SelectedItems returns the selected workflows in list order.
+func (m NavigableModel) SelectedItems() []models.ItemV2 {
+	var items []models.ItemV2
+	for _, listItem := range m.AllItems() {
+		if workflowItem, ok := listItem.(WorkflowItem); ok && indexOfRef(m.selection, entryFor(workflowItem)) >= 0 {
+			items = append(items, workflowItem.GetItem())
+		}
+	}
+	return items
+}
+
+func (m *NavigableModel) ClearSelection() {
+	m.selection = nil
+	m.refreshMarks()
+}
+
+func (m *NavigableModel) toggleSelection() {
+	currentItem := m.CurrentItem()
+	if currentItem == nil || isVirtual(currentItem) {
+		return
+	}
+
+	m.selection = toggleRef(m.selection, entryFor(currentItem))
+	m.refreshMarks()
+}
+
+func (m *NavigableModel) selectAll() {
+	m.selection = nil
+	for _, listItem := range m.AllItems() {
+		if !isVirtual(listItem) {
+			m.selection = append(m.selection, entryFor(listItem))
+		}
+	}
+	m.refreshMarks()
+}
+
+func (m *NavigableModel) invertSelection() {
+	for _, listItem := range m.AllItems() {
+		if !isVirtual(listItem) {
+			m.selection = toggleRef(m.selection, entryFor(listItem))
+		}
+	}
+	m.refreshMarks()
+}
+
+// copySelection copies the commands of the selected workflows, one per line.
+func (m NavigableModel) copySelection() tea.Cmd {
+	var commands, ids []string
+	items := m.SelectedItems()
+	for index, item := range items {
+		item, err := m.expand(item)
+		if err != nil {
+			return includeErrorCmd(err)
+		}
+		items[index] = item
+		commands = append(commands, models.LanguageByID(item.GetLanguage()).CopyText(item.Script()))
+		ids = append(ids, item.ID)
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+
+	return m.confirmRisky(shared.CopyToClipboardCmd(strings.Join(commands, "\n"), ids...), items...)
+}
@@ -0,0 +1,148 @@
+package list
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+func (m *NavigableModel) SetViewState(views *services.ViewStateService) {
+	m.views = views
+}
+
+// FolderView returns how the current folder is listed.
+func (m NavigableModel) FolderView() models.FolderView {
+	return m.viewFor(m.currentPath)
+}
+
+func (m NavigableModel) viewFor(path string) models.FolderView {
+	if m.views == nil {
+		return models.DefaultFolderView()
+	}
+	return m.views.Get(path)
+}
+
+// sortedEntries orders the contents of a folder according to view.
+func (m NavigableModel) sortedEntries(view models.FolderView, subfolders []models.FolderV2, items []models.ItemV2) []list.Item {
+	type entry struct {
+		item list.Item
+		key  models.SortKey
+	}
+
+	entries := make([]entry, 0, len(subfolders)+len(items))
+	for _, folder := range subfolders {
+		entries = append(entries, entry{
+			item: FolderItem{folder: folder, mark: m.markFor(entryRef{folderPath: folder.Path})},
+			key: models.SortKey{
+				Name:        folder.Name,
+				DateAdded:   folder.DateAdded,
+				DateUpdated: folder.DateUpdated,
+				Position:    folder.Position,
+				Index:       len(entries),
+				IsFolder:    true,
+			},
+		})
+	}
+	for _, item := range items {
+		var usage float64
+		if m.usage != nil {
+			usage = m.usage.Frecency(item.ID)
+		}
+		entries = append(entries, entry{
+			item: m.workflowItem(item),
+			key: models.SortKey{
+				Name:        item.Title,
+				DateAdded:   item.DateAdded,
+				DateUpdated: item.DateUpdated,
+				Usage:       usage,
+				Position:    item.Position,
+				Index:       len(entries),
+			},
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return view.Less(entries[i].key, entries[j].key)
+	})
+
+	listItems := make([]list.Item, 0, len(entries))
+	for _, e := range entries {
+		listItems = append(listItems, e.item)
+	}
+	return listItems
+}
+
+func (m NavigableModel) cycleSortMode() tea.Cmd {
+	if m.IsVirtualFolder() {
+		return nil
+	}
+
+	view := m.FolderView()
+	view.SortMode = view.SortMode.Next()
+	return shared.SetFolderViewCmd(m.currentPath, view)
+}
+
+func (m NavigableModel) toggleFoldersFirst() tea.Cmd {
+	if m.IsVirtualFolder() {
+		return nil
+	}
+
+	view := m.FolderView()
+	view.FoldersFirst = !view.FoldersFirst
+	return shared.SetFolderViewCmd(m.currentPath, view)
+}
+
+// moveCurrent swaps the selected entry with its neighbour delta rows away and
+// requests the resulting order. With folders first, folders and workflows
+// cannot trade places.
+func (m NavigableModel) moveCurrent(delta int) tea.Cmd {
+	if m.IsVirtualFolder() {
+		return nil
+	}
+
+	var entries []ListItemInterface
+	for _, listItem := range m.AllItems() {
+		if !isVirtual(listItem) {
+			entries = append(entries, listItem)
+		}
+	}
+
+	currentItem := m.CurrentItem()
+	if currentItem == nil || isVirtual(currentItem) {
+		return nil
+	}
+	current := -1
+	for i, entry := range entries {
+		if entryFor(entry) == entryFor(currentItem) {
+			current = i
+			break
+		}
+	}
+
+	target := current + delta
+	if current < 0 || target < 0 || target >= len(entries) {
+		return nil
+	}
+	if m.FolderView().FoldersFirst && entries[current].IsFolder() != entries[target].IsFolder() {
+		return nil
+	}
+
+	entries[current], entries[target] = entries[target], entries[current]
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entryID(entry))
+	}
+	return shared.ReorderCmd(m.currentPath, ids)
+}
+
+func entryID(item ListItemInterface) string {
+	if folderItem, ok := item.(FolderItem); ok {
+		return folderItem.GetFolder().ID
+	}
+	return item.(WorkflowItem).GetItem().ID
+}
@@ -0,0 +1,114 @@
+package list
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+// Virtual folders list workflows from anywhere in the database. Their paths
+// can never collide with real folders, which always start with "/".
+const (
+	FavoritesPath = ":favorites"
+	RecentPath    = ":recent"
+
+	recentLimit = 20
+)
+
+func IsVirtualPath(path string) bool {
+	return path == FavoritesPath || path == RecentPath
+}
+
+// DisplayPath returns a human readable name for path, translating virtual
+// folders.
+func DisplayPath(path string) string {
+	switch path {
+	case FavoritesPath:
+		return di.GetService[*services.I18nService](di.I18nServiceKey).Translate("favorites_folder_name")
+	case RecentPath:
+		return di.GetService[*services.I18nService](di.I18nServiceKey).Translate("recent_folder_name")
+	}
+	return path
+}
+
+func (m *NavigableModel) SetUsage(usage *services.UsageService) {
+	m.usage = usage
+}
+
+func (m NavigableModel) IsVirtualFolder() bool {
+	return IsVirtualPath(m.currentPath)
+}
+
+// TargetPath is the folder that new and pasted entries go to. Virtual
+// folders cannot hold entries, so the root is used while browsing them.
+func (m NavigableModel) TargetPath() string {
+	if m.IsVirtualFolder() {
+		return "/"
+	}
+	return m.currentPath
+}
+
+// VirtualItems resolves the workflows listed by a virtual folder, skipping
+// usage records of workflows that no longer exist.
+func (m NavigableModel) VirtualItems(path string) []models.ItemV2 {
+	if m.usage == nil || m.database == nil {
+		return nil
+	}
+
+	var ids []string
+	switch path {
+	case FavoritesPath:
+		ids = m.usage.Favorites()
+	case RecentPath:
+		ids = m.usage.Recent(recentLimit)
+	}
+
+	database := m.database.GetDatabase()
+	var items []models.ItemV2
+	for _, id := range ids {
+		if item, found := database.GetItemByID(id); found {
+			items = append(items, *item)
+		}
+	}
+	return items
+}
+
+func (m NavigableModel) virtualFolderItems() []list.Item {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+
+	var listItems []list.Item
+	for _, virtual := range []struct{ path, icon, name string }{
+		{FavoritesPath, "★ ", "favorites_folder_name"},
+		{RecentPath, "🕘 ", "recent_folder_name"},
+	} {
+		count := len(m.VirtualItems(virtual.path))
+		if count == 0 {
+			continue
+		}
+
+		listItems = append(listItems, FolderItem{
+			folder: models.FolderV2{
+				Path: virtual.path,
+				Name: i18n.Translate(virtual.name),
+				Description: i18n.TranslateWithData("virtual_folder_description", map[string]interface{}{
+					"Count": count,
+				}),
+			},
+			icon: virtual.icon,
+		})
+	}
+	return listItems
+}
+
+func (m NavigableModel) isFavorite(id string) bool {
+	return m.usage != nil && m.usage.IsFavorite(id)
+}
+
+// isVirtual reports whether item is a virtual folder, which cannot be
+// selected, marked, edited or deleted.
+func isVirtual(item ListItemInterface) bool {
+	folderItem, ok := item.(FolderItem)
+	return ok && folderItem.IsVirtual()
+}
@@ -4,7 +4,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
+
+	"github.com/evertonstz/go-workflows/components/theme"
 )
 
 const (
@@ -31,12 +32,6 @@ func New(defaultText string) Model {
 	}
 }
 
-var style = lipgloss.NewStyle().
-	PaddingLeft(1).
-	PaddingRight(1).
-	Background(lipgloss.Color("62")).
-	Foreground(lipgloss.Color("230"))
-
 func (m Model) Init() tea.Cmd {
 	return nil
 }
@@ -66,9 +61,9 @@ func (m Model) View() string {
 		if m.defaultText == "" {
 			return ""
 		}
-		return style.SetString(m.defaultText).Render()
+		return theme.Current().Notification.SetString(m.defaultText).Render()
 	}
-	return style.SetString(m.Text).Render()
+	return theme.Current().Notification.SetString(m.Text).Render()
 }
 
 func (m *Model) SetDefaultText(text string) {
@@ -0,0 +1,101 @@
+package tagform
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).PaddingBottom(1)
+)
+
+type Model struct {
+	Keys        helpkeys.TagFormKeyMap
+	Title       string
+	AddLabel    string
+	RemoveLabel string
+	input       textinput.Model
+	remove      bool
+	width       int
+}
+
+func New(keys helpkeys.TagFormKeyMap, placeholder, addLabel, removeLabel string) Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.Prompt = "# "
+
+	return Model{
+		Keys:        keys,
+		AddLabel:    addLabel,
+		RemoveLabel: removeLabel,
+		input:       input,
+	}
+}
+
+// Open resets the form to add mode with an empty tag.
+func (m *Model) Open(title string) tea.Cmd {
+	m.Title = title
+	m.remove = false
+	m.input.SetValue("")
+	return m.input.Focus()
+}
+
+func (m *Model) SetSize(width, _ int) {
+	m.width = width
+	m.input.Width = width - 4
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.Keys.Close):
+		m.input.Blur()
+		return m, shared.CloseTagFormCmd()
+	case key.Matches(keyMsg, m.Keys.ToggleMode):
+		m.remove = !m.remove
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Submit):
+		tag := strings.TrimSpace(m.input.Value())
+		if tag == "" {
+			return m, nil
+		}
+		return m, shared.SubmitTagFormCmd(tag, m.remove)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(keyMsg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	addButton := theme.Current().Blurred.Render("[ " + m.AddLabel + " ]")
+	removeButton := theme.Current().Blurred.Render("[ " + m.RemoveLabel + " ]")
+	if m.remove {
+		removeButton = theme.Current().Focused.Render("[ " + m.RemoveLabel + " ]")
+	} else {
+		addButton = theme.Current().Focused.Render("[ " + m.AddLabel + " ]")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(m.Title),
+		m.input.View(),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Center, addButton, " ", removeButton),
+	)
+}
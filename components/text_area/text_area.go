@@ -2,37 +2,68 @@ package textarea
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/evertonstz/go-workflows/components/highlight"
+	"github.com/evertonstz/go-workflows/components/theme"
 	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
 
 	"github.com/dustin/go-humanize"
 )
 
-var (
-	highlightedTextStyle = lipgloss.NewStyle()
-	dateCellStyle        = lipgloss.NewStyle().
-				Foreground(lipgloss.AdaptiveColor{
-			Light: "#909090",
-			Dark:  "#626262",
-		}).
-		PaddingRight(2)
-)
+var highlightedTextStyle = lipgloss.NewStyle()
 var dateContainerStyle = lipgloss.NewStyle().Align(lipgloss.Right)
 
 type Model struct {
 	TextArea        textarea.Model
 	highlightedText string
 	currentItem     models.Item
+	language        string
+	risk            models.Risk
+	includeErr      error
+	includeLabel    string
+	riskLabels      map[string]string
+	runSettings     models.RunSettings
+	inheritedRun    models.RunSettings
+	runLabels       runSettingsLabels
+	steps           []models.Step
+	variants        []models.Variant
+	activeVariant   int
+	shownVariant    int
+	variantLabels   variantLabels
+	stepStatuses    []models.StepStatus
+	continueLabel   string
 	currentFolder   *models.FolderV2
 	editing         bool
 	err             error
 }
 
+type runSettingsLabels struct {
+	dir, interpreter, env, inherited string
+}
+
+type variantLabels struct {
+	variant, fallback, active string
+}
+
+// Marks shown before each step of a multi-step workflow, by the outcome of
+// its last run.
+var stepMarks = map[models.StepStatus]string{
+	models.StepPending:   "☐",
+	models.StepSucceeded: "✓",
+	models.StepFailed:    "✗",
+	models.StepIgnored:   "!",
+}
+
 func (m *Model) SetEditing(editing bool) {
 	switch editing {
 	case true:
@@ -49,12 +80,31 @@ func New() Model {
 	ti.Placeholder = "Paste or type your command here..."
 	ti.Prompt = ""
 
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+
 	return Model{
 		TextArea:        ti,
 		highlightedText: ti.Placeholder,
 		currentFolder:   nil,
 		editing:         false,
 		err:             nil,
+		riskLabels: map[string]string{
+			models.RiskHigh:   i18n.Translate("risk_high"),
+			models.RiskMedium: i18n.Translate("risk_medium"),
+		},
+		continueLabel: i18n.Translate("step_continues_on_error"),
+		includeLabel:  i18n.Translate("include_broken"),
+		runLabels: runSettingsLabels{
+			dir:         i18n.Translate("run_settings_dir"),
+			interpreter: i18n.Translate("run_settings_interpreter"),
+			env:         i18n.Translate("run_settings_env"),
+			inherited:   i18n.Translate("run_settings_inherited"),
+		},
+		variantLabels: variantLabels{
+			variant:  i18n.Translate("variant_label"),
+			fallback: i18n.Translate("variant_default"),
+			active:   i18n.Translate("variant_active"),
+		},
 	}
 }
 
@@ -67,6 +117,31 @@ func (m *Model) SetSize(width, height int) {
 	m.TextArea.SetHeight(height)
 }
 
+// SetStepStatuses shows the outcome of the last run of the current
+// multi-step workflow. Nil shows every step as pending.
+func (m *Model) SetStepStatuses(statuses []models.StepStatus) {
+	m.stepStatuses = statuses
+}
+
+// HasVariants reports whether the current workflow has commands for other
+// systems or shells.
+func (m Model) HasVariants() bool {
+	return m.currentFolder == nil && len(m.variants) > 0
+}
+
+// CycleVariant shows the next command of the current workflow, going from
+// its default command through each of its variants.
+func (m *Model) CycleVariant() {
+	if !m.HasVariants() {
+		return
+	}
+	m.shownVariant++
+	if m.shownVariant >= len(m.variants) {
+		m.shownVariant = -1
+	}
+	m.TextArea.SetValue(models.ItemV2{Command: m.currentItem.Command, Variants: m.variants}.VariantCommand(m.shownVariant))
+}
+
 func (m *Model) SetCurrentFolder(folder models.FolderV2) {
 	m.currentFolder = &folder
 	m.currentItem = models.Item{} // Clear item when folder is set
@@ -79,8 +154,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case shared.DidSetCurrentItemMsg:
 		m.currentItem = msg.Item
+		m.language = msg.Language
+		m.risk = msg.Risk
+		m.includeErr = msg.IncludeError
+		m.steps = msg.Steps
+		m.variants = msg.Variants
+		m.activeVariant = msg.ActiveVariant
+		m.shownVariant = msg.ActiveVariant
+		m.runSettings = msg.RunSettings
+		m.inheritedRun = msg.InheritedRunSettings
 		m.currentFolder = nil // Clear folder when item is set
-		m.TextArea.SetValue(m.currentItem.Command)
+		item := models.ItemV2{Command: msg.Item.Command, Steps: msg.Steps, Variants: msg.Variants}
+		if len(msg.Steps) > 0 {
+			m.TextArea.SetValue(item.Script())
+		} else {
+			m.TextArea.SetValue(item.VariantCommand(msg.ActiveVariant))
+		}
 	case shared.DidSetCurrentFolderMsg:
 		m.currentFolder = &msg.Folder
 		m.currentItem = models.Item{}
@@ -117,20 +206,163 @@ func (m Model) View() string {
 	}
 
 	rawText := m.TextArea.Value()
-	highlightedText := SyntaxHighlight(rawText)
+	var highlightedText string
+	if m.currentFolder == nil && len(m.steps) > 0 {
+		highlightedText = m.stepChecklist()
+	} else {
+		language := m.language
+		if language == "" {
+			language = models.DetectLanguage(rawText)
+		}
+		highlightedText = highlight.Code(language, rawText)
+	}
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
+	var blocks []string
+	textHeight := m.TextArea.Height() - 2
+	if m.currentFolder == nil && m.risk.Level != models.RiskNone {
+		warning := m.riskWarning()
+		blocks = append(blocks, warning)
+		textHeight -= lipgloss.Height(warning)
+	}
+	if m.HasVariants() {
+		header := m.variantHeader()
+		blocks = append(blocks, header)
+		textHeight -= lipgloss.Height(header)
+	}
+	if m.currentFolder == nil && m.includeErr != nil {
+		warning := theme.Current().Warning.
+			Width(m.TextArea.Width()).
+			Render(fmt.Sprintf("⚠ %s: %s", m.includeLabel, m.includeErr))
+		blocks = append(blocks, warning)
+		textHeight -= lipgloss.Height(warning)
+	}
+	if summary := m.runSettingsSummary(); summary != "" {
+		blocks = append(blocks, summary)
+		textHeight -= lipgloss.Height(summary)
+	}
+
+	blocks = append(blocks,
 		highlightedTextStyle.
 			Width(m.TextArea.Width()).
-			Height(m.TextArea.Height()-2).
+			Height(textHeight).
 			Render(highlightedText),
 		dateContainerStyle.
 			Width(m.TextArea.Width()).
 			Height(2).
 			Render(lipgloss.JoinVertical(
 				lipgloss.Right,
-				dateCellStyle.Render(dateAdded),
-				dateCellStyle.Render(lastUpdated))),
+				dateCellStyle().Render(dateAdded),
+				dateCellStyle().Render(lastUpdated))),
 	)
+	return lipgloss.JoinVertical(lipgloss.Left, blocks...)
+}
+
+// riskWarning lists why the current command is risky.
+func (m Model) riskWarning() string {
+	reasons := make([]string, len(m.risk.Rules))
+	for i, rule := range m.risk.Rules {
+		reasons[i] = rule.Reason()
+	}
+	return theme.Current().Warning.
+		Width(m.TextArea.Width()).
+		Render(fmt.Sprintf("⚠ %s: %s", m.riskLabels[m.risk.Level], strings.Join(reasons, ", ")))
+}
+
+// variantHeader names the system and shell the shown command is for, and
+// whether it is the one that runs here.
+func (m Model) variantHeader() string {
+	label := m.variantLabels.fallback
+	if m.shownVariant >= 0 && m.shownVariant < len(m.variants) {
+		label = m.variants[m.shownVariant].Label()
+	}
+	if m.shownVariant == m.activeVariant {
+		label += " (" + m.variantLabels.active + ")"
+	}
+	styles := theme.Current()
+	return lipgloss.NewStyle().Width(m.TextArea.Width()).Render(
+		styles.Subtle.Render(m.variantLabels.variant+": ") + label +
+			styles.Subtle.Render(fmt.Sprintf(" %d/%d", m.shownVariant+2, len(m.variants)+1)))
+}
+
+// runSettingsSummary lists where and how the current workflow runs, marking
+// the settings it inherits from its folders. For a folder, it lists the
+// defaults the folder gives.
+func (m Model) runSettingsSummary() string {
+	own, inherited := m.runSettings, m.inheritedRun
+	if m.currentFolder != nil {
+		own, inherited = m.currentFolder.RunSettings, models.RunSettings{}
+	}
+	styles := theme.Current()
+	inheritedMark := " " + styles.Subtle.Render("("+m.runLabels.inherited+")")
+
+	var lines []string
+	setting := func(label, value, inheritedValue string) {
+		switch {
+		case value != "":
+			lines = append(lines, styles.Subtle.Render(label+": ")+value)
+		case inheritedValue != "":
+			lines = append(lines, styles.Subtle.Render(label+": ")+inheritedValue+inheritedMark)
+		}
+	}
+	setting(m.runLabels.dir, own.WorkingDir, inherited.WorkingDir)
+	setting(m.runLabels.interpreter, own.Interpreter, inherited.Interpreter)
+
+	effective := own.Inherit(inherited)
+	var env []string
+	for _, name := range slices.Sorted(maps.Keys(effective.Env)) {
+		pair := name + "=" + effective.Env[name]
+		if _, ok := own.Env[name]; !ok {
+			pair += inheritedMark
+		}
+		env = append(env, pair)
+	}
+	if len(env) > 0 {
+		lines = append(lines, styles.Subtle.Render(m.runLabels.env+": ")+strings.Join(env, ", "))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Width(m.TextArea.Width()).Render(strings.Join(lines, "\n"))
+}
+
+// stepChecklist numbers the steps of the current workflow, marking each
+// one with the outcome of its last run.
+func (m Model) stepChecklist() string {
+	language := m.language
+	if language == "" {
+		language = models.ItemV2{Steps: m.steps}.GetLanguage()
+	}
+	styles := theme.Current()
+
+	blocks := make([]string, len(m.steps))
+	for i, step := range m.steps {
+		status := models.StepPending
+		if i < len(m.stepStatuses) {
+			status = m.stepStatuses[i]
+		}
+		mark := stepMarks[status]
+		if status == models.StepFailed || status == models.StepIgnored {
+			mark = styles.Warning.Render(mark)
+		}
+
+		lines := []string{fmt.Sprintf("%s %d. %s", mark, i+1, step.Title)}
+		if step.ContinueOnError {
+			lines[0] += " " + styles.Subtle.Render("("+m.continueLabel+")")
+		}
+		for _, line := range strings.Split(step.Description, "\n") {
+			if line != "" {
+				lines = append(lines, "   "+styles.Subtle.Render(line))
+			}
+		}
+		for _, line := range strings.Split(highlight.Code(language, step.Command), "\n") {
+			lines = append(lines, "   "+line)
+		}
+		blocks[i] = strings.Join(lines, "\n")
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func dateCellStyle() lipgloss.Style {
+	return theme.Current().Subtle.PaddingRight(2)
 }
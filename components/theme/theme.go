@@ -0,0 +1,231 @@
+package theme
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+const (
+	Dark         = "dark"
+	Light        = "light"
+	HighContrast = "high-contrast"
+)
+
+// BuiltIn holds the themes that ship with the application. The dark theme
+// keeps the colors the application always had, with commands highlighted in
+// the basic colors of the terminal.
+var BuiltIn = map[string]models.Theme{
+	Dark: {
+		Accent:                 "205",
+		Muted:                  "240",
+		Subtle:                 "#626262",
+		Warning:                "208",
+		NotificationForeground: "230",
+		NotificationBackground: "62",
+		SyntaxCommand:          "2",
+		SyntaxKeyword:          "4",
+		SyntaxFlag:             "2",
+		SyntaxString:           "3",
+		SyntaxVariable:         "5",
+		SyntaxNumber:           "6",
+		SyntaxOperator:         "1",
+		SyntaxSpecial:          "7",
+		SyntaxComment:          "8",
+	},
+	Light: {
+		Accent:                 "162",
+		Muted:                  "245",
+		Subtle:                 "#909090",
+		Warning:                "166",
+		NotificationForeground: "230",
+		NotificationBackground: "62",
+		SyntaxCommand:          "25",
+		SyntaxKeyword:          "28",
+		SyntaxFlag:             "25",
+		SyntaxString:           "130",
+		SyntaxVariable:         "90",
+		SyntaxNumber:           "30",
+		SyntaxOperator:         "160",
+		SyntaxSpecial:          "240",
+		SyntaxComment:          "245",
+	},
+	HighContrast: {
+		Accent:                 "11",
+		Muted:                  "15",
+		Subtle:                 "7",
+		Warning:                "9",
+		NotificationForeground: "0",
+		NotificationBackground: "11",
+		SyntaxCommand:          "14",
+		SyntaxKeyword:          "10",
+		SyntaxFlag:             "14",
+		SyntaxString:           "11",
+		SyntaxVariable:         "13",
+		SyntaxNumber:           "12",
+		SyntaxOperator:         "9",
+		SyntaxSpecial:          "15",
+		SyntaxComment:          "7",
+	},
+}
+
+// Styles are the lipgloss styles built from a theme. Components read them
+// through Current when rendering, so that they follow the configured theme.
+type Styles struct {
+	Focused       lipgloss.Style
+	Blurred       lipgloss.Style
+	Selected      lipgloss.Style
+	Subtle        lipgloss.Style
+	Warning       lipgloss.Style
+	FocusedBorder lipgloss.Style
+	BlurredBorder lipgloss.Style
+	Notification  lipgloss.Style
+
+	Syntax SyntaxStyles
+
+	accent lipgloss.Color
+	subtle lipgloss.Color
+}
+
+type SyntaxStyles struct {
+	Command  lipgloss.Style
+	Keyword  lipgloss.Style
+	Flag     lipgloss.Style
+	String   lipgloss.Style
+	Variable lipgloss.Style
+	Number   lipgloss.Style
+	Operator lipgloss.Style
+	Special  lipgloss.Style
+	Comment  lipgloss.Style
+}
+
+var current = NewStyles(BuiltIn[Dark])
+
+// Current returns the styles of the configured theme.
+func Current() Styles {
+	return current
+}
+
+// Configure selects the theme called name, which is one of the built-in
+// themes, one of userThemes or models.ThemeAuto to follow the background of
+// the terminal. It fails, leaving the current theme in place, when the theme
+// or one of the themes it is based on does not exist.
+func Configure(name string, userThemes map[string]models.Theme) error {
+	theme, err := Resolve(name, userThemes)
+	if err != nil {
+		return err
+	}
+	current = NewStyles(theme)
+	return nil
+}
+
+// Resolve returns the colors of the theme called name, with the colors it
+// leaves empty taken from its base themes. A user theme without a base
+// starts from the theme that fits the background of the terminal.
+func Resolve(name string, userThemes map[string]models.Theme) (models.Theme, error) {
+	seen := map[string]bool{}
+	var chain []models.Theme
+	for {
+		if name == "" || name == models.ThemeAuto {
+			name = backgroundTheme()
+		}
+		if seen[name] {
+			return models.Theme{}, fmt.Errorf("invalid theme: %q is part of a cycle of base themes", name)
+		}
+		seen[name] = true
+
+		userTheme, ok := userThemes[name]
+		if !ok {
+			break
+		}
+		chain = append(chain, userTheme)
+		if _, builtIn := BuiltIn[name]; builtIn && userTheme.Base == "" {
+			// A user theme named after a built-in one tweaks it.
+			break
+		}
+		name = userTheme.Base
+	}
+
+	theme, ok := BuiltIn[name]
+	if !ok {
+		return models.Theme{}, fmt.Errorf("invalid theme: unknown theme %q (available: %s)", name, strings.Join(names(userThemes), ", "))
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		theme = chain[i].Inherit(theme)
+	}
+	return theme, nil
+}
+
+func NewStyles(theme models.Theme) Styles {
+	color := func(c string) lipgloss.Color { return lipgloss.Color(c) }
+	foreground := func(c string) lipgloss.Style { return lipgloss.NewStyle().Foreground(color(c)) }
+	border := func(c string) lipgloss.Style {
+		return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(color(c))
+	}
+
+	return Styles{
+		Focused:       foreground(theme.Accent),
+		Blurred:       foreground(theme.Muted),
+		Selected:      lipgloss.NewStyle().Bold(true),
+		Subtle:        foreground(theme.Subtle),
+		Warning:       foreground(theme.Warning).Bold(true),
+		FocusedBorder: border(theme.Accent),
+		BlurredBorder: border(theme.Muted),
+		Notification: lipgloss.NewStyle().
+			PaddingLeft(1).
+			PaddingRight(1).
+			Background(color(theme.NotificationBackground)).
+			Foreground(color(theme.NotificationForeground)),
+		accent: color(theme.Accent),
+		subtle: color(theme.Subtle),
+		Syntax: SyntaxStyles{
+			Command:  foreground(theme.SyntaxCommand),
+			Keyword:  foreground(theme.SyntaxKeyword),
+			Flag:     foreground(theme.SyntaxFlag),
+			String:   foreground(theme.SyntaxString),
+			Variable: foreground(theme.SyntaxVariable),
+			Number:   foreground(theme.SyntaxNumber),
+			Operator: foreground(theme.SyntaxOperator),
+			Special:  foreground(theme.SyntaxSpecial),
+			Comment:  foreground(theme.SyntaxComment),
+		},
+	}
+}
+
+// ListDelegate returns the delegate of the bubbles list styled with the
+// theme.
+func (s Styles) ListDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.Foreground(s.accent).BorderForeground(s.accent)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.Foreground(s.accent).BorderForeground(s.accent)
+	delegate.Styles.DimmedDesc = delegate.Styles.DimmedDesc.Foreground(s.subtle)
+	return delegate
+}
+
+// backgroundTheme asks the terminal for its background color. Terminals
+// that do not answer are assumed to be dark.
+func backgroundTheme() string {
+	if lipgloss.HasDarkBackground() {
+		return Dark
+	}
+	return Light
+}
+
+func names(userThemes map[string]models.Theme) []string {
+	names := []string{models.ThemeAuto}
+	for name := range BuiltIn {
+		names = append(names, name)
+	}
+	for name := range userThemes {
+		if _, builtIn := BuiltIn[name]; !builtIn {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
@@ -0,0 +1,463 @@
+package variableform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+	"github.com/evertonstz/go-workflows/shared/runner"
+)
+
+const maxSuggestions = 5
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).PaddingBottom(1)
+)
+
+// field is the input of one variable. Enum and bool variables pick one of
+// their options; the others are typed, picking from the output of their
+// suggestion command when they have one.
+type field struct {
+	variable    models.Variable
+	input       textinput.Model
+	options     []string
+	choice      int
+	completions []string
+	err         string
+
+	// suggestCommand is the suggestion command with the values of the other
+	// variables, as last run.
+	suggestCommand string
+	suggestions    []string
+	selected       int
+	loading        bool
+	suggestErr     string
+	// suggestRisk is why the suggestion command was not run, as it is high
+	// risk and only runs when reloaded.
+	suggestRisk models.Risk
+}
+
+func (f field) isChoice() bool {
+	return f.options != nil
+}
+
+func (f field) value() string {
+	if f.isChoice() {
+		return f.options[f.choice]
+	}
+	return strings.TrimSpace(f.input.Value())
+}
+
+// matches returns the suggestions containing the typed value.
+func (f field) matches() []string {
+	filter := strings.ToLower(f.value())
+	var matches []string
+	for _, suggestion := range f.suggestions {
+		if strings.Contains(strings.ToLower(suggestion), filter) {
+			matches = append(matches, suggestion)
+		}
+	}
+	return matches
+}
+
+// Model asks for the values of the variables of a workflow, one field per
+// variable, and validates them before submitting.
+type Model struct {
+	Keys             helpkeys.VariableFormKeyMap
+	Title            string
+	RequiredLabel    string
+	NoneLabel        string
+	LoadingLabel     string
+	FailedLabel      string
+	NoMatchesLabel   string
+	RiskyLabel       string
+	fields           []field
+	cursor           int
+	validation       *services.ValidationService
+	suggestionRunner *runner.Suggestions
+	risk             *services.RiskService
+	width            int
+}
+
+func New(keys helpkeys.VariableFormKeyMap, validation *services.ValidationService, suggestions *runner.Suggestions, risk *services.RiskService) Model {
+	return Model{
+		Keys:             keys,
+		validation:       validation,
+		suggestionRunner: suggestions,
+		risk:             risk,
+	}
+}
+
+// Open shows a field for every variable, holding its value from values.
+func (m *Model) Open(title string, variables []models.Variable, values map[string]string) tea.Cmd {
+	m.Title = title
+	m.cursor = 0
+	m.fields = make([]field, len(variables))
+	for i, variable := range variables {
+		f := field{variable: variable}
+		value := values[variable.Name]
+
+		switch variable.Kind() {
+		case models.VariableEnum:
+			f.options = append([]string{}, variable.Choices...)
+			if !variable.Required {
+				f.options = append([]string{""}, f.options...)
+			}
+		case models.VariableBool:
+			f.options = []string{"false", "true"}
+		default:
+			f.input = textinput.New()
+			f.input.Prompt = "> "
+			f.input.Width = m.width - 4
+			f.input.SetValue(value)
+		}
+		for index, option := range f.options {
+			if option == value {
+				f.choice = index
+			}
+		}
+
+		m.fields[i] = f
+	}
+	return m.focus(0)
+}
+
+func (m *Model) SetSize(width, _ int) {
+	m.width = width
+	for i := range m.fields {
+		m.fields[i].input.Width = width - 4
+	}
+}
+
+// focus moves the cursor to the field at index, loading its suggestions.
+func (m *Model) focus(index int) tea.Cmd {
+	if index < 0 || index >= len(m.fields) {
+		return nil
+	}
+	m.fields[m.cursor].input.Blur()
+	m.fields[m.cursor].completions = nil
+	m.cursor = index
+	if m.fields[index].isChoice() {
+		return nil
+	}
+	return tea.Batch(m.fields[index].input.Focus(), m.loadSuggestions(false))
+}
+
+// values returns the value of every field, by variable name.
+func (m Model) values() map[string]string {
+	values := map[string]string{}
+	for _, f := range m.fields {
+		values[f.variable.Name] = f.value()
+	}
+	return values
+}
+
+// loadSuggestions runs the suggestion command of the current field in the
+// background, unless its output for the current values of the other fields is
+// already shown. Reloading runs it again, bypassing the cache. Suggestion
+// commands come with the workflows, which may be synced from elsewhere, so
+// high risk ones only run when reloaded.
+func (m *Model) loadSuggestions(reload bool) tea.Cmd {
+	f := &m.fields[m.cursor]
+	if f.variable.Suggest == "" || m.suggestionRunner == nil {
+		return nil
+	}
+	command := models.ExpandVariables(f.variable.Suggest, m.values())
+	if command == f.suggestCommand && !reload {
+		return nil
+	}
+
+	f.suggestCommand = command
+	f.suggestErr = ""
+	f.suggestRisk = models.Risk{}
+	f.selected = 0
+	if reload {
+		m.suggestionRunner.Forget(command)
+	} else if suggestions, ok := m.suggestionRunner.Cached(command); ok {
+		f.suggestions = suggestions
+		f.loading = false
+		return nil
+	}
+	f.suggestions = nil
+	if m.risk != nil && !reload {
+		if risk := m.risk.Analyze(command); risk.IsHigh() {
+			f.suggestRisk = risk
+			f.loading = false
+			return nil
+		}
+	}
+	f.loading = true
+	return shared.LoadSuggestionsCmd(m.suggestionRunner, command)
+}
+
+// setSuggestions shows the output of a suggestion command in the fields that
+// are still waiting for it.
+func (m *Model) setSuggestions(msg shared.DidLoadSuggestionsMsg) {
+	for i := range m.fields {
+		f := &m.fields[i]
+		if !f.loading || f.suggestCommand != msg.Command {
+			continue
+		}
+		f.loading = false
+		if msg.Err != nil {
+			f.suggestErr = msg.Err.Error()
+			continue
+		}
+		f.suggestions = msg.Suggestions
+	}
+}
+
+// moveSelection moves the selected suggestion by delta, wrapping around.
+func (m *Model) moveSelection(delta int) {
+	f := &m.fields[m.cursor]
+	if matches := len(f.matches()); matches > 0 {
+		f.selected = ((f.selected+delta)%matches + matches) % matches
+	}
+}
+
+// submit validates every field, submitting the values when all are valid.
+func (m *Model) submit() tea.Cmd {
+	values := map[string]string{}
+	firstInvalid := -1
+	for i := range m.fields {
+		f := &m.fields[i]
+		f.err = ""
+		if err := m.validation.ValidateVariable(f.variable, f.value()); err != nil {
+			f.err = err.Error()
+			if firstInvalid < 0 {
+				firstInvalid = i
+			}
+		}
+		values[f.variable.Name] = f.value()
+	}
+
+	if firstInvalid >= 0 {
+		return m.focus(firstInvalid)
+	}
+	m.fields[m.cursor].input.Blur()
+	return shared.SubmitVariableFormCmd(values)
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(shared.DidLoadSuggestionsMsg); ok {
+		m.setSuggestions(msg)
+		return m, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.fields) == 0 {
+		return m, nil
+	}
+	current := &m.fields[m.cursor]
+
+	switch {
+	case key.Matches(keyMsg, m.Keys.Close):
+		current.input.Blur()
+		return m, shared.CloseVariableFormCmd()
+	case key.Matches(keyMsg, m.Keys.Submit):
+		return m, m.submit()
+	case key.Matches(keyMsg, m.Keys.Up):
+		return m, m.focus(m.cursor - 1)
+	case key.Matches(keyMsg, m.Keys.Down):
+		return m, m.focus(m.cursor + 1)
+	case key.Matches(keyMsg, m.Keys.NextSuggestion):
+		m.moveSelection(1)
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.PreviousSuggestion):
+		m.moveSelection(-1)
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.RefreshSuggestions):
+		return m, m.loadSuggestions(true)
+	case key.Matches(keyMsg, m.Keys.Complete):
+		if matches := current.matches(); len(matches) > 0 && current.value() != matches[current.selected] {
+			current.input.SetValue(matches[current.selected])
+			current.input.CursorEnd()
+			current.selected = 0
+			current.err = ""
+			return m, nil
+		}
+		if current.variable.Kind() == models.VariablePath {
+			completed, completions := completePath(current.input.Value())
+			current.input.SetValue(completed)
+			current.input.CursorEnd()
+			current.completions = completions
+			return m, nil
+		}
+		return m, m.focus((m.cursor + 1) % len(m.fields))
+	case current.isChoice() && key.Matches(keyMsg, m.Keys.Left):
+		current.choice = (current.choice - 1 + len(current.options)) % len(current.options)
+		current.err = ""
+		return m, nil
+	case current.isChoice() && key.Matches(keyMsg, m.Keys.Right):
+		current.choice = (current.choice + 1) % len(current.options)
+		current.err = ""
+		return m, nil
+	}
+
+	if current.isChoice() {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	current.input, cmd = current.input.Update(keyMsg)
+	current.completions = nil
+	current.selected = 0
+	current.err = ""
+	return m, cmd
+}
+
+func (m Model) View() string {
+	styles := theme.Current()
+	lines := []string{titleStyle.Render(m.Title)}
+
+	for i, f := range m.fields {
+		label := fmt.Sprintf("%s (%s)", f.variable.Name, f.variable.Kind())
+		if f.variable.Required {
+			label += " " + m.RequiredLabel
+		}
+		if i == m.cursor {
+			lines = append(lines, styles.Focused.Render(label))
+		} else {
+			lines = append(lines, styles.Blurred.Render(label))
+		}
+		if f.variable.Description != "" {
+			lines = append(lines, styles.Subtle.Render(f.variable.Description))
+		}
+
+		if f.isChoice() {
+			option := f.options[f.choice]
+			if option == "" {
+				option = m.NoneLabel
+			}
+			if i == m.cursor {
+				lines = append(lines, styles.Focused.Render("‹ "+option+" ›"))
+			} else {
+				lines = append(lines, "  "+option)
+			}
+		} else {
+			lines = append(lines, f.input.View())
+		}
+
+		for _, completion := range f.completions {
+			lines = append(lines, styles.Subtle.Render("  "+completion))
+		}
+		if i == m.cursor {
+			lines = append(lines, m.suggestionLines(f)...)
+		}
+		if f.err != "" {
+			lines = append(lines, styles.Warning.Render(f.err))
+		}
+		lines = append(lines, "")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// suggestionLines shows the suggestions matching the value of f, as a list
+// scrolled to keep the selected one visible.
+func (m Model) suggestionLines(f field) []string {
+	styles := theme.Current()
+	switch {
+	case f.loading:
+		return []string{styles.Subtle.Render(m.LoadingLabel)}
+	case f.suggestErr != "":
+		return []string{styles.Warning.Render(m.FailedLabel + ": " + f.suggestErr)}
+	case f.suggestRisk.IsHigh():
+		reasons := make([]string, len(f.suggestRisk.Rules))
+		for i, rule := range f.suggestRisk.Rules {
+			reasons[i] = rule.Reason()
+		}
+		return []string{
+			styles.Warning.Render(fmt.Sprintf("⚠ %s: %s", m.RiskyLabel, strings.Join(reasons, ", "))),
+			styles.Subtle.Render("  $ " + f.suggestCommand),
+		}
+	case f.suggestions == nil:
+		return nil
+	}
+
+	matches := f.matches()
+	if len(matches) == 0 {
+		return []string{styles.Subtle.Render(m.NoMatchesLabel)}
+	}
+	start := max(0, min(f.selected-maxSuggestions/2, len(matches)-maxSuggestions))
+	end := min(len(matches), start+maxSuggestions)
+
+	var lines []string
+	for index := start; index < end; index++ {
+		if index == f.selected {
+			lines = append(lines, styles.Focused.Render("› "+matches[index]))
+		} else {
+			lines = append(lines, styles.Subtle.Render("  "+matches[index]))
+		}
+	}
+	if len(matches) > maxSuggestions {
+		lines = append(lines, styles.Subtle.Render(fmt.Sprintf("  %d/%d", f.selected+1, len(matches))))
+	}
+	return lines
+}
+
+// completePath completes the last element of path to the longest prefix
+// shared by the entries it matches, returning them when there is more than
+// one.
+func completePath(path string) (string, []string) {
+	dir, prefix := filepath.Split(path)
+	listed := dir
+	if listed == "" {
+		listed = "."
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if listed == "~" || strings.HasPrefix(listed, "~/") {
+			listed = home + strings.TrimPrefix(listed, "~")
+		}
+	}
+
+	entries, err := os.ReadDir(listed)
+	if err != nil {
+		return path, nil
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+		if entry.IsDir() {
+			name += string(filepath.Separator)
+		}
+		matches = append(matches, name)
+	}
+	if len(matches) == 0 {
+		return path, nil
+	}
+	sort.Strings(matches)
+
+	common := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, common) {
+			common = common[:len(common)-1]
+		}
+	}
+
+	if len(matches) == 1 {
+		return dir + common, nil
+	}
+	if len(matches) > maxSuggestions {
+		matches = append(matches[:maxSuggestions], "…")
+	}
+	return dir + common, matches
+}
@@ -5,52 +5,86 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/adrg/xdg"
-
+	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared/di"
 	"github.com/evertonstz/go-workflows/shared/di/services"
 )
 
-func ParseFlags(i18nService *services.I18nService) (showVersion, showHelp, showConfig bool) {
+type Flags struct {
+	ShowVersion bool
+	ShowHelp    bool
+	ShowConfig  bool
+	// Config holds the settings given on the command line, which take
+	// precedence over the environment and the config file.
+	Config models.Config
+}
+
+func ParseFlags(i18nService *services.I18nService) Flags {
 	versionFlag := flag.Bool("version", false, i18nService.Translate("flags_version"))
 	versionShortFlag := flag.Bool("v", false, i18nService.Translate("flags_version"))
 	helpFlag := flag.Bool("help", false, i18nService.Translate("flags_help"))
 	helpShortFlag := flag.Bool("h", false, i18nService.Translate("flags_help"))
 	configFlag := flag.Bool("print-config", false, i18nService.Translate("flags_print_config"))
 
-	flag.Parse()
+	var config models.Config
+	flag.StringVar(&config.DataFile, "data-file", "", i18nService.Translate("flags_data_file"))
+	flag.StringVar(&config.Language, "lang", "", i18nService.Translate("flags_language"))
+	flag.StringVar(&config.Theme, "theme", "", i18nService.Translate("flags_theme"))
+	flag.StringVar(&config.DefaultFolder, "folder", "", i18nService.Translate("flags_default_folder"))
+	flag.StringVar(&config.Shell, "shell", "", i18nService.Translate("flags_shell"))
+	flag.StringVar(&config.Clipboard, "clipboard", "", i18nService.Translate("flags_clipboard"))
+	flag.StringVar(&config.Keybindings.Preset, "keymap", "", i18nService.Translate("flags_keymap"))
 
-	showVersion = *versionFlag || *versionShortFlag
-	showHelp = *helpFlag || *helpShortFlag
-	showConfig = *configFlag
+	flag.Parse()
 
-	return
+	return Flags{
+		ShowVersion: *versionFlag || *versionShortFlag,
+		ShowHelp:    *helpFlag || *helpShortFlag,
+		ShowConfig:  *configFlag,
+		Config:      config,
+	}
 }
 
-func HandleFlags(showVersion, showHelp, showConfig bool) {
+// HandleFlags prints the version or the usage and exits when asked to. It
+// needs no configuration, so it runs even when the config file is invalid.
+func HandleFlags(flags Flags) {
 	i18nService := di.GetService[*services.I18nService](di.I18nServiceKey)
 
-	if showVersion {
+	if flags.ShowVersion {
 		fmt.Printf("%s: %s\n", i18nService.Translate("flags_version"), Version)
 		os.Exit(0)
 	}
 
-	if showConfig {
-		appName := "go-workflows"
-		dataFile, err := xdg.DataFile(fmt.Sprintf("%s/data.json", appName))
-		if err != nil {
-			fmt.Printf("Error determining the location of the configuration file: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Printf("%s: %s\n", i18nService.Translate("flags_print_config"), dataFile)
-		os.Exit(0)
-	}
-
-	if showHelp {
+	if flags.ShowHelp {
 		fmt.Printf("%s\n", i18nService.Translate("flags_usage"))
 		fmt.Printf("  --version, -v       %s\n", i18nService.Translate("flags_version"))
 		fmt.Printf("  --help, -h          %s\n", i18nService.Translate("flags_help"))
 		fmt.Printf("  --print-config      %s\n", i18nService.Translate("flags_print_config"))
+		fmt.Printf("  --data-file PATH    %s\n", i18nService.Translate("flags_data_file"))
+		fmt.Printf("  --lang LANG         %s\n", i18nService.Translate("flags_language"))
+		fmt.Printf("  --theme THEME       %s\n", i18nService.Translate("flags_theme"))
+		fmt.Printf("  --folder PATH       %s\n", i18nService.Translate("flags_default_folder"))
+		fmt.Printf("  --shell PATH        %s\n", i18nService.Translate("flags_shell"))
+		fmt.Printf("  --clipboard NAME    %s\n", i18nService.Translate("flags_clipboard"))
+		fmt.Printf("  --keymap PRESET     %s\n", i18nService.Translate("flags_keymap"))
+		fmt.Printf("\n%s\n", i18nService.Translate("flags_commands"))
+		fmt.Printf("  config show         %s\n", i18nService.Translate("command_config_show"))
+		fmt.Printf("  config edit         %s\n", i18nService.Translate("command_config_edit"))
+		fmt.Printf("  history [FILTER]    %s\n", i18nService.Translate("command_history"))
+		fmt.Printf("    --json            %s\n", i18nService.Translate("flags_history_json"))
+		fmt.Printf("    --limit N         %s\n", i18nService.Translate("flags_history_limit"))
 		os.Exit(0)
 	}
 }
+
+// HandlePrintConfig prints the path of the data file and exits when asked to.
+func HandlePrintConfig(flags Flags) {
+	if !flags.ShowConfig {
+		return
+	}
+
+	i18nService := di.GetService[*services.I18nService](di.I18nServiceKey)
+	persistenceService := di.GetService[*services.PersistenceService](di.PersistenceServiceKey)
+	fmt.Printf("%s: %s\n", i18nService.Translate("flags_print_config"), persistenceService.GetDataFilePath())
+	os.Exit(0)
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
 	"github.com/evertonstz/go-workflows/shared/di"
 	"github.com/evertonstz/go-workflows/shared/di/services"
 )
@@ -13,31 +15,113 @@ import (
 var Version string
 
 func main() {
+	appName := "go-workflows"
 	localesDir := "locales"
-	i18nService, err := services.NewI18nServiceWithAutoDetection(localesDir)
+
+	validationService := services.NewValidationService()
+	di.RegisterService(di.ValidationServiceKey, validationService)
+
+	configService, err := services.NewConfigService(appName, validationService)
+	if err != nil {
+		log.Fatalf("Error loading configuration: %v", err)
+	}
+	// A config file that fails to load must not keep --help, --version and
+	// the config commands from running, so the error waits until after them.
+	configErr := configService.Load()
+
+	i18nService, err := newI18nService(configService.Config().Language, localesDir)
 	if err != nil {
 		log.Fatalf("Error initializing i18n service: %v", err)
 	}
 
+	flags := ParseFlags(i18nService)
+	if configErr == nil {
+		configErr = configService.ApplyFlags(flags.Config)
+	}
+	if flags.Config.Language != "" {
+		i18nService, err = newI18nService(flags.Config.Language, localesDir)
+		if err != nil {
+			log.Fatalf("Error initializing i18n service: %v", err)
+		}
+	}
+
+	di.RegisterService(di.ConfigServiceKey, configService)
 	di.RegisterService(di.I18nServiceKey, i18nService)
 
-	validationService := services.NewValidationService()
-	di.RegisterService(di.ValidationServiceKey, validationService)
+	HandleFlags(flags)
+	HandleConfigCommand(flag.Args(), configErr)
+
+	if configErr != nil {
+		log.Fatalf("Error loading configuration: %v", configErr)
+	}
+	config := configService.Config()
 
+	if err := helpkeys.Configure(i18nService, config.Keybindings); err != nil {
+		log.Fatalf("Error loading keybindings: %v", err)
+	}
 	helpkeys.InitializeGlobalKeys(i18nService)
 
-	appName := "go-workflows"
-	persistenceService, err := services.NewPersistenceService(appName)
+	if err := theme.Configure(config.Theme, config.Themes); err != nil {
+		log.Fatalf("Error loading theme: %v", err)
+	}
+
+	riskService, err := services.NewRiskService(config.Risk)
+	if err != nil {
+		log.Fatalf("Error loading risk rules: %v", err)
+	}
+	di.RegisterService(di.RiskServiceKey, riskService)
+
+	persistenceService, err := services.NewPersistenceServiceWithDataFile(appName, config.DataFile)
 	if err != nil {
 		log.Fatalf("Error initializing persistence service: %v", err)
 	}
+	persistenceService.SetBackupRetention(config.BackupRetention)
 	di.RegisterService(di.PersistenceServiceKey, persistenceService)
 
-	showVersion, showHelp, showConfig := ParseFlags(i18nService)
-	HandleFlags(showVersion, showHelp, showConfig)
+	usageService, err := services.NewUsageService(appName)
+	if err != nil {
+		log.Fatalf("Error initializing usage service: %v", err)
+	}
+	di.RegisterService(di.UsageServiceKey, usageService)
+
+	viewStateService, err := services.NewViewStateService(appName)
+	if err != nil {
+		log.Fatalf("Error initializing view state service: %v", err)
+	}
+	di.RegisterService(di.ViewStateServiceKey, viewStateService)
+
+	variableValuesService, err := services.NewVariableValuesService(appName)
+	if err != nil {
+		log.Fatalf("Error initializing variable values service: %v", err)
+	}
+	di.RegisterService(di.VariableValuesServiceKey, variableValuesService)
+
+	historyService, err := services.NewHistoryService(appName)
+	if err != nil {
+		log.Fatalf("Error initializing history service: %v", err)
+	}
+	di.RegisterService(di.HistoryServiceKey, historyService)
+
+	clipboardService, err := services.NewClipboardService(appName, config.Clipboard)
+	if err != nil {
+		log.Fatalf("Error initializing clipboard service: %v", err)
+	}
+	di.RegisterService(di.ClipboardServiceKey, clipboardService)
+
+	HandlePrintConfig(flags)
+	HandleCommand(flag.Args())
 
 	p := tea.NewProgram(new(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		log.Fatalf("Error starting app: %v", err)
 	}
 }
+
+// newI18nService uses language, or the language of the system when it is
+// empty.
+func newI18nService(language, localesDir string) (*services.I18nService, error) {
+	if language == "" {
+		return services.NewI18nServiceWithAutoDetection(localesDir)
+	}
+	return services.NewI18nService(language, localesDir)
+}
@@ -6,9 +6,7 @@ import (
 	"github.com/charmbracelet/bubbles/help"
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/evertonstz/go-workflows/shared"
-	"github.com/evertonstz/go-workflows/shared/di"
-	"github.com/evertonstz/go-workflows/shared/di/services"
+	"github.com/evertonstz/go-workflows/components/list"
 	"github.com/evertonstz/go-workflows/shared/messages"
 )
 
@@ -17,17 +15,19 @@ const (
 )
 
 func (m model) notificationTitle() string {
-	if m.currentPath == "/" {
-		return "Workflows"
-	}
-	return m.currentPath
+	return list.RenderBreadcrumbs(m.currentPath, "Workflows")
 }
 
 func (m model) getHelpKeys() help.KeyMap {
-	if m.screenState == addNew {
+	switch m.screenState {
+	case addNew:
 		return m.addNewScreen.Keys
+	case folderForm:
+		return m.folderFormScreen.Keys
+	case runHistory:
+		return m.historyScreen.Keys
 	}
-	return m.listScreen.Keys
+	return m.listScreen.HelpKeys()
 }
 
 func (m model) isSmallWidth() bool {
@@ -39,6 +39,8 @@ func (m *model) updatePanelSizes() {
 	m.currentHelpHeight = strings.Count(m.help.View(m.getHelpKeys()), "\n") + 1
 
 	m.addNewScreen.SetSize(m.termDimensions.width/2, m.termDimensions.height/2-(m.currentHelpHeight+currentNotificationHeight))
+	m.folderFormScreen.SetSize(m.termDimensions.width/2, m.termDimensions.height/2-(m.currentHelpHeight+currentNotificationHeight))
+	m.historyScreen.SetSize(m.termDimensions.width*3/4, m.termDimensions.height-(m.currentHelpHeight+currentNotificationHeight))
 	m.listScreen.SetSize(m.termDimensions.width, m.termDimensions.height-(m.currentHelpHeight+currentNotificationHeight+1), m.isSmallWidth())
 }
 
@@ -47,14 +49,10 @@ func (m *model) toggleHelpShowAll() {
 	m.updatePanelSizes()
 }
 
-func (m model) persistItemsV2() tea.Cmd {
-	persistence := di.GetService[*services.PersistenceService](di.PersistenceServiceKey)
-	validation := di.GetService[*services.ValidationService](di.ValidationServiceKey)
-	databaseManager, err := services.NewDatabaseManagerV2(persistence, validation)
-	if err != nil {
-		return shared.ErrorCmd(err)
+// persistedItemsV2 reports that the list screen saved a change. The database
+// manager writes the file as it changes, so there is nothing left to save.
+func (m model) persistedItemsV2() tea.Cmd {
+	return func() tea.Msg {
+		return messages.PersistedFileV2Msg{}
 	}
-
-	database := databaseManager.GetDatabase()
-	return messages.PersistListDataV2Cmd(database)
 }
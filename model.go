@@ -9,6 +9,8 @@ import (
 	"github.com/evertonstz/go-workflows/components/notification"
 	addnew "github.com/evertonstz/go-workflows/screens/add_new"
 	commandlist "github.com/evertonstz/go-workflows/screens/command_list"
+	folderform "github.com/evertonstz/go-workflows/screens/folder_form"
+	"github.com/evertonstz/go-workflows/screens/history"
 	"github.com/evertonstz/go-workflows/shared/messages"
 )
 
@@ -36,6 +38,8 @@ type (
 		help              help.Model
 		screenState       screenState
 		addNewScreen      addnew.Model
+		folderFormScreen  folderform.Model
+		historyScreen     history.Model
 		listScreen        commandlist.Model
 		persistPath       string
 		currentPath       string
@@ -50,6 +54,8 @@ type (
 const (
 	addNew screenState = iota
 	newList
+	folderForm
+	runHistory
 )
 
 func (m model) Init() tea.Cmd {
@@ -60,13 +66,15 @@ func new() model {
 	listScreen := commandlist.New()
 	listScreen.InitializeDatabase()
 
-	return model{
+	m := model{
 		confirmationModal: confirmationmodal.NewConfirmationModal("", "", "", nil, nil),
 		help:              help.New(),
 		addNewScreen:      addnew.New(),
+		folderFormScreen:  folderform.New(),
+		historyScreen:     history.New(),
 		listScreen:        listScreen,
-		currentPath:       "/",
-		notification:      notification.New("Workflows"),
+		currentPath:       listScreen.GetCurrentPath(),
+		notification:      notification.New(""),
 		panelsStyle: panelsStyle{
 			helpPanelStyle:         helpPanelStyle,
 			notificationPanelStyle: notificationPanelStyle,
@@ -74,4 +82,7 @@ func new() model {
 		currentHelpHeight: 0,
 		screenState:       newList,
 	}
+	m.notification.SetDefaultText(m.notificationTitle())
+
+	return m
 }
@@ -0,0 +1,56 @@
+package models
+
+const (
+	ThemeAuto     = "auto"
+	ClipboardAuto = "auto"
+)
+
+// Config holds the user's settings. Empty values fall back to the behaviour
+// that applies without a config file: the data file in the XDG data
+// directory, the language of the system and the user's login shell.
+type Config struct {
+	DataFile        string           `toml:"data_file"`
+	Language        string           `toml:"language" validate:"omitempty,oneof=en pt-BR"`
+	Theme           string           `toml:"theme"`
+	Themes          map[string]Theme `toml:"themes" validate:"dive"`
+	DefaultFolder   string           `toml:"default_folder" validate:"folder_path"`
+	Clipboard       string           `toml:"clipboard" validate:"omitempty,oneof=auto osc52 tmux wl-copy xclip system file"`
+	Shell           string           `toml:"shell"`
+	BackupRetention int              `toml:"backup_retention" validate:"min=0"`
+	Keybindings     KeyBindings      `toml:"keybindings"`
+	Risk            RiskSettings     `toml:"risk"`
+	History         HistorySettings  `toml:"history"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Theme:         ThemeAuto,
+		DefaultFolder: "/",
+		Clipboard:     ClipboardAuto,
+	}
+}
+
+// Merge returns c with every non-empty setting of overrides applied on top.
+func (c Config) Merge(overrides Config) Config {
+	overrideString(&c.DataFile, overrides.DataFile)
+	overrideString(&c.Language, overrides.Language)
+	overrideString(&c.Theme, overrides.Theme)
+	overrideString(&c.DefaultFolder, overrides.DefaultFolder)
+	overrideString(&c.Clipboard, overrides.Clipboard)
+	overrideString(&c.Shell, overrides.Shell)
+	overrideString(&c.Keybindings.Preset, overrides.Keybindings.Preset)
+
+	if overrides.BackupRetention != 0 {
+		c.BackupRetention = overrides.BackupRetention
+	}
+	if len(overrides.Keybindings.Keys) > 0 {
+		c.Keybindings.Keys = overrides.Keybindings.Keys
+	}
+	return c
+}
+
+func overrideString(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
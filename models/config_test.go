@@ -0,0 +1,30 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Merge(t *testing.T) {
+	base := DefaultConfig()
+	base.Shell = "/bin/zsh"
+	base.Keybindings = KeyBindings{Preset: "vim", Keys: map[string][]string{"help": {"f1"}}}
+
+	merged := base.Merge(Config{
+		Language:        "pt-BR",
+		BackupRetention: 3,
+		Keybindings:     KeyBindings{Preset: "emacs"},
+	})
+
+	expected := base
+	expected.Language = "pt-BR"
+	expected.BackupRetention = 3
+	expected.Keybindings = KeyBindings{Preset: "emacs", Keys: map[string][]string{"help": {"f1"}}}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, merged)
+	}
+
+	if !reflect.DeepEqual(base.Merge(Config{}), base) {
+		t.Error("Expected merging an empty config to change nothing")
+	}
+}
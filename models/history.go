@@ -0,0 +1,93 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistorySettings configure the execution history.
+type HistorySettings struct {
+	// OutputLines is the number of last lines of output kept with each run.
+	// Keeping them pipes the output of workflows, which then no longer
+	// write to a terminal, so it is off by default.
+	OutputLines int `toml:"output_lines" validate:"min=0,max=1000"`
+}
+
+// HistoryOutputLimit caps the bytes of output kept with a run, however long
+// its lines are.
+const HistoryOutputLimit = 8 * 1024
+
+// HistoryRecord is a run of a workflow. Records are appended to a history
+// file in the state directory, so that running a workflow never rewrites the
+// data file.
+type HistoryRecord struct {
+	ItemID     string            `json:"item_id"`
+	Title      string            `json:"title"`
+	Command    string            `json:"command"` // With the values of the variables in place
+	Variables  map[string]string `json:"variables,omitempty"`
+	Cwd        string            `json:"cwd"`
+	StartedAt  time.Time         `json:"started_at"`
+	DurationMS int64             `json:"duration_ms"`
+	ExitCode   int               `json:"exit_code"` // -1 when the command could not start
+	Output     string            `json:"output,omitempty"`
+}
+
+func (r HistoryRecord) Duration() time.Duration {
+	return time.Duration(r.DurationMS) * time.Millisecond
+}
+
+func (r HistoryRecord) Succeeded() bool {
+	return r.ExitCode == 0
+}
+
+// Matches reports whether the record contains every word of filter, ignoring
+// case, in its title, command, item ID or variables. The word "failed"
+// matches the runs that did not succeed and "exit=N" the runs that exited
+// with N.
+func (r HistoryRecord) Matches(filter string) bool {
+	var text strings.Builder
+	for _, part := range []string{r.Title, r.Command, r.ItemID, r.Cwd} {
+		text.WriteString(strings.ToLower(part))
+		text.WriteByte('\n')
+	}
+	for name, value := range r.Variables {
+		text.WriteString(strings.ToLower(name + "=" + value))
+		text.WriteByte('\n')
+	}
+
+	for _, word := range strings.Fields(strings.ToLower(filter)) {
+		if word == "failed" && !r.Succeeded() {
+			continue
+		}
+		if code, ok := strings.CutPrefix(word, "exit="); ok && code == strconv.Itoa(r.ExitCode) {
+			continue
+		}
+		if !strings.Contains(text.String(), word) {
+			return false
+		}
+	}
+	return true
+}
+
+// TailLines returns the last lines of output, at most limit bytes of them.
+// A partial first line is dropped when the limit cuts through it.
+func TailLines(output string, lines, limit int) string {
+	output = strings.TrimRight(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	if lines <= 0 || output == "" {
+		return ""
+	}
+
+	all := strings.Split(output, "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	tail := strings.Join(all, "\n")
+	if limit > 0 && len(tail) > limit {
+		tail = tail[len(tail)-limit:]
+		if _, rest, ok := strings.Cut(tail, "\n"); ok {
+			tail = rest
+		}
+	}
+	return strings.ToValidUTF8(tail, "")
+}
@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+func TestHistoryRecord_Matches(t *testing.T) {
+	record := HistoryRecord{
+		ItemID:    "item_1",
+		Title:     "Deploy API",
+		Command:   "make deploy STAGE=prod",
+		Variables: map[string]string{"stage": "prod"},
+		Cwd:       "/srv/api",
+		ExitCode:  2,
+	}
+
+	tests := []struct {
+		filter   string
+		expected bool
+	}{
+		{"", true},
+		{"deploy", true},
+		{"DEPLOY api", true},
+		{"stage=prod", true},
+		{"/srv", true},
+		{"failed", true},
+		{"exit=2 deploy", true},
+		{"exit=1", false},
+		{"deploy staging", false},
+	}
+
+	for _, tt := range tests {
+		if got := record.Matches(tt.filter); got != tt.expected {
+			t.Errorf("Matches(%q): expected %v, got %v", tt.filter, tt.expected, got)
+		}
+	}
+
+	record.ExitCode = 0
+	if record.Matches("failed") {
+		t.Error("Expected a successful run not to match failed")
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		lines    int
+		limit    int
+		expected string
+	}{
+		{"last lines", "a\nb\nc\nd\n", 2, 100, "c\nd"},
+		{"fewer lines", "a\nb", 5, 100, "a\nb"},
+		{"no lines", "a\nb", 0, 100, ""},
+		{"byte limit drops the partial line", "first line\nsecond\nthird", 3, 10, "third"},
+		{"byte limit within a line", "abcdefghij", 1, 4, "ghij"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TailLines(tt.output, tt.lines, tt.limit); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// An include, as in {{include /ops/kube context}} or {{include item_123}},
+// stands for the command of another workflow, named by its path or its ID.
+var includePattern = regexp.MustCompile(`\{\{\s*include\s+([^{}]+?)\s*\}\}`)
+
+// IncludeRefs returns the paths or IDs of the workflows included in text, in
+// the order they appear.
+func IncludeRefs(text string) []string {
+	var refs []string
+	for _, match := range includePattern.FindAllStringSubmatch(text, -1) {
+		refs = append(refs, includeRef(match[1]))
+	}
+	return refs
+}
+
+func includeRef(ref string) string {
+	return strings.Trim(strings.TrimSpace(ref), `"`)
+}
+
+// replaceIncludes calls replace with the reference of every include of text
+// and puts what it returns in place of the include, stopping at the first
+// error.
+func replaceIncludes(text string, replace func(ref string) (string, error)) (string, error) {
+	var err error
+	result := includePattern.ReplaceAllStringFunc(text, func(include string) string {
+		if err != nil {
+			return include
+		}
+		replacement, replaceErr := replace(includeRef(includePattern.FindStringSubmatch(include)[1]))
+		if replaceErr != nil {
+			err = replaceErr
+			return include
+		}
+		return replacement
+	})
+	return result, err
+}
+
+// FindItem returns the item with the ID ref or, failing that, the item at
+// the path ref, with or without the leading slash.
+func (db DatabaseV2) FindItem(ref string) (*ItemV2, bool) {
+	if item, found := db.GetItemByID(ref); found {
+		return item, true
+	}
+	path := strings.Trim(ref, "/")
+	for i, item := range db.Items {
+		if strings.Trim(item.GetFullPath(), "/") == path {
+			return &db.Items[i], true
+		}
+	}
+	return nil, false
+}
+
+// LinkIncludes returns a copy of item whose includes name the workflows they
+// include by ID, so that renaming or moving those keeps them working. It
+// fails when an include names no workflow or the includes form a cycle.
+func (db DatabaseV2) LinkIncludes(item ItemV2) (ItemV2, error) {
+	linked := item.Clone()
+	link := func(ref string) (string, error) {
+		included, err := db.resolveInclude(ref, []ItemV2{item})
+		if err != nil {
+			return "", err
+		}
+		return "{{include " + included.ID + "}}", nil
+	}
+
+	var err error
+	if linked.Command, err = replaceIncludes(linked.Command, link); err != nil {
+		return ItemV2{}, err
+	}
+	for index := range linked.Steps {
+		if linked.Steps[index].Command, err = replaceIncludes(linked.Steps[index].Command, link); err != nil {
+			return ItemV2{}, err
+		}
+	}
+	for index := range linked.Variants {
+		if linked.Variants[index].Command, err = replaceIncludes(linked.Variants[index].Command, link); err != nil {
+			return ItemV2{}, err
+		}
+	}
+
+	if err := db.CheckIncludes(linked); err != nil {
+		return ItemV2{}, err
+	}
+	return linked, nil
+}
+
+// CheckIncludes reports the first include of item, of its steps or of its
+// variants, or of the workflows they include in turn, that names no workflow
+// or leads back to a workflow including it.
+func (db DatabaseV2) CheckIncludes(item ItemV2) error {
+	return db.checkIncludes(item, nil)
+}
+
+func (db DatabaseV2) checkIncludes(item ItemV2, chain []ItemV2) error {
+	chain = append(slices.Clip(chain), item)
+	for _, command := range item.commands() {
+		for _, ref := range IncludeRefs(command) {
+			included, err := db.resolveInclude(ref, chain)
+			if err != nil {
+				return err
+			}
+			if err := db.checkIncludes(*included, chain); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ExpandIncludes returns a copy of item with the command that applies on p,
+// without variants, where every include is replaced by the command the
+// included workflow has on p. The variables the included workflows declare
+// are added to the ones of item.
+func (db DatabaseV2) ExpandIncludes(item ItemV2, p Platform) (ItemV2, error) {
+	expanded := item.ForPlatform(p)
+
+	var expand func(text string, chain []ItemV2) (string, error)
+	expand = func(text string, chain []ItemV2) (string, error) {
+		return replaceIncludes(text, func(ref string) (string, error) {
+			included, err := db.resolveInclude(ref, chain)
+			if err != nil {
+				return "", err
+			}
+			for _, variable := range included.Variables {
+				declared := slices.ContainsFunc(expanded.Variables, func(v Variable) bool { return v.Name == variable.Name })
+				if !declared {
+					expanded.Variables = append(expanded.Variables, variable)
+				}
+			}
+			return expand(included.ForPlatform(p).Command, append(slices.Clip(chain), *included))
+		})
+	}
+
+	var err error
+	if expanded.Command, err = expand(expanded.Command, []ItemV2{item}); err != nil {
+		return ItemV2{}, err
+	}
+	for index := range expanded.Steps {
+		if expanded.Steps[index].Command, err = expand(expanded.Steps[index].Command, []ItemV2{item}); err != nil {
+			return ItemV2{}, err
+		}
+	}
+	return expanded, nil
+}
+
+// DescribeIncludes returns a copy of item whose includes name the workflows
+// they include by path, for showing. Includes naming no workflow are kept.
+func (db DatabaseV2) DescribeIncludes(item ItemV2) ItemV2 {
+	described := item.Clone()
+	describe := func(text string) string {
+		result, _ := replaceIncludes(text, func(ref string) (string, error) {
+			included, found := db.FindItem(ref)
+			if !found {
+				return "{{include " + ref + "}}", nil
+			}
+			return "{{include /" + strings.TrimPrefix(included.GetFullPath(), "/") + "}}", nil
+		})
+		return result
+	}
+
+	described.Command = describe(described.Command)
+	for index := range described.Steps {
+		described.Steps[index].Command = describe(described.Steps[index].Command)
+	}
+	for index := range described.Variants {
+		described.Variants[index].Command = describe(described.Variants[index].Command)
+	}
+	return described
+}
+
+// resolveInclude returns the workflow ref names, which chain, the workflows
+// whose includes led to it, must not hold.
+func (db DatabaseV2) resolveInclude(ref string, chain []ItemV2) (*ItemV2, error) {
+	included, found := db.FindItem(ref)
+	if !found {
+		return nil, fmt.Errorf("include %q: no workflow has this ID or path", ref)
+	}
+	if included.IsMultiStep() {
+		return nil, fmt.Errorf("include %q: %s has steps, only single commands can be included", ref, included.Title)
+	}
+	if start := slices.IndexFunc(chain, func(item ItemV2) bool { return item.ID != "" && item.ID == included.ID }); start >= 0 {
+		var titles []string
+		for _, item := range chain[start:] {
+			titles = append(titles, item.Title)
+		}
+		return nil, fmt.Errorf("include cycle: %s → %s", strings.Join(titles, " → "), included.Title)
+	}
+	return included, nil
+}
+
+// commands returns the command of the item, of each of its steps and of each
+// of its variants.
+func (i ItemV2) commands() []string {
+	commands := []string{i.Command}
+	for _, step := range i.Steps {
+		commands = append(commands, step.Command)
+	}
+	for _, variant := range i.Variants {
+		commands = append(commands, variant.Command)
+	}
+	return commands
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func newIncludeDatabase() DatabaseV2 {
+	db := NewDatabaseV2()
+	db.Items = []ItemV2{
+		{ID: "item_1", Title: "auth", FolderPath: "/ops", Command: "export TOKEN=$(vault read {{role}})",
+			Variables: []Variable{{Name: "role", Default: "reader"}}},
+		{ID: "item_2", Title: "kube context", FolderPath: "/ops", Command: "{{include item_1}}\nkubectl config use-context prod",
+			Variants: []Variant{{Shell: "fish", Command: "kubectl config use-context prod"}}},
+		{ID: "item_3", Title: "pods", FolderPath: "/", Command: "{{ include /ops/kube context }}\nkubectl get pods"},
+		{ID: "item_4", Title: "deploy", FolderPath: "/", Steps: []Step{{Title: "build", Command: "make"}}},
+	}
+	return db
+}
+
+func TestDatabaseV2_ExpandIncludes(t *testing.T) {
+	db := newIncludeDatabase()
+	item, _ := db.GetItemByID("item_3")
+
+	expanded, err := db.ExpandIncludes(*item, Platform{OS: "linux", Shell: "bash"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "export TOKEN=$(vault read {{role}})\nkubectl config use-context prod\nkubectl get pods"
+	if expanded.Command != expected {
+		t.Errorf("Expected %q, got %q", expected, expanded.Command)
+	}
+	if len(expanded.Variables) != 1 || expanded.Variables[0].Default != "reader" {
+		t.Errorf("Expected the variables of the included workflows, got %+v", expanded.Variables)
+	}
+
+	expanded, err = db.ExpandIncludes(*item, Platform{OS: "linux", Shell: "fish"})
+	if err != nil || expanded.Command != "kubectl config use-context prod\nkubectl get pods" {
+		t.Errorf("Expected the variant of the included workflow, got %q (%v)", expanded.Command, err)
+	}
+	if item.Command != "{{ include /ops/kube context }}\nkubectl get pods" || item.Variables != nil {
+		t.Errorf("Expected the item not to change, got %+v", item)
+	}
+}
+
+func TestDatabaseV2_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		command       string
+		errorContains string
+	}{
+		{"dangling path", "{{include /ops/missing}}", `include "/ops/missing": no workflow has this ID or path`},
+		{"dangling ID", "{{include item_9}}", `include "item_9": no workflow has this ID or path`},
+		{"multi-step", "{{include deploy}}", "deploy has steps"},
+		{"cycle", "{{include item_5}}", "include cycle: loop → loop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newIncludeDatabase()
+			item := ItemV2{ID: "item_5", Title: "loop", FolderPath: "/", Command: tt.command}
+			db.Items = append(db.Items, item)
+
+			if _, err := db.ExpandIncludes(item, Platform{}); err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected an expansion error containing %q, got %v", tt.errorContains, err)
+			}
+			if err := db.CheckIncludes(item); err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected a check error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestDatabaseV2_LinkIncludes(t *testing.T) {
+	db := newIncludeDatabase()
+
+	linked, err := db.LinkIncludes(ItemV2{ID: "item_5", Title: "logs", Command: "{{include ops/auth}} && {{include \"/pods\"}}",
+		Variants: []Variant{{OS: "darwin", Command: "{{include /ops/kube context}}"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if linked.Command != "{{include item_1}} && {{include item_3}}" || linked.Variants[0].Command != "{{include item_2}}" {
+		t.Errorf("Expected the includes to name IDs, got %q and %q", linked.Command, linked.Variants[0].Command)
+	}
+
+	described := db.DescribeIncludes(linked)
+	if described.Command != "{{include /ops/auth}} && {{include /pods}}" {
+		t.Errorf("Expected the includes to name paths, got %q", described.Command)
+	}
+
+	auth, _ := db.GetItemByID("item_1")
+	auth.Title, auth.FolderPath = "login", "/"
+	if _, err := db.ExpandIncludes(linked, Platform{}); err != nil {
+		t.Errorf("Expected the includes to follow the moved workflow, got %v", err)
+	}
+	if described := db.DescribeIncludes(linked); described.Command != "{{include /login}} && {{include /pods}}" {
+		t.Errorf("Expected the new path, got %q", described.Command)
+	}
+
+	auth.Command = "{{include item_3}}"
+	if _, err := db.LinkIncludes(*auth); err == nil || !strings.Contains(err.Error(), "include cycle: login → pods → kube context → login") {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package models
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,12 +23,18 @@ type (
 		ID          string            `json:"id" validate:"required"`
 		Title       string            `json:"title" validate:"required,min=1,max=255"`
 		Desc        string            `json:"description" validate:"max=1000"`
-		Command     string            `json:"command" validate:"required,min=1,max=5000"`
+		Command     string            `json:"command" validate:"required_without=Steps,max=5000"`
+		Language    string            `json:"language,omitempty" validate:"omitempty,oneof=shell python sql yaml jq"` // Detected from the command when empty
+		Steps       []Step            `json:"steps,omitempty" validate:"dive"`                                        // Run in order instead of Command
+		Variants    []Variant         `json:"variants,omitempty" validate:"excluded_with=Steps,dive"`                 // Replace Command where they apply
+		Variables   []Variable        `json:"variables,omitempty" validate:"dive"`
 		DateAdded   time.Time         `json:"date_added" validate:"required"`
 		DateUpdated time.Time         `json:"date_updated" validate:"required"`
 		Tags        []string          `json:"tags,omitempty" validate:"dive,min=1,max=50,alphanum_space_dash_underscore"`
 		Metadata    map[string]string `json:"metadata,omitempty" validate:"dive,keys,min=1,max=100,endkeys,min=0,max=500"`
 		FolderPath  string            `json:"folder_path" validate:"required,folder_path"`
+		Position    int               `json:"position,omitempty" validate:"min=0"`
+		RunSettings
 	}
 
 	FolderV2 struct {
@@ -38,6 +46,9 @@ type (
 		DateAdded   time.Time         `json:"date_added" validate:"required"`
 		DateUpdated time.Time         `json:"date_updated" validate:"required"`
 		Metadata    map[string]string `json:"metadata,omitempty" validate:"dive,keys,min=1,max=100,endkeys,min=0,max=500"`
+		Position    int               `json:"position,omitempty" validate:"min=0"`
+		// RunSettings of a folder are defaults for the items inside it.
+		RunSettings
 	}
 
 	DatabaseV2 struct {
@@ -59,11 +70,39 @@ type (
 		Folders []FolderV2 `json:"folders"`
 		Total   int        `json:"total"`
 	}
+
+	FolderNode struct {
+		Folder     FolderV2     `json:"folder"`
+		Subfolders []FolderNode `json:"subfolders"`
+		Items      []ItemV2     `json:"items"`
+	}
+
+	FolderTree struct {
+		Folders []FolderNode `json:"folders"`
+		Items   []ItemV2     `json:"items"`
+	}
 )
 
+var lastIDTimestamp atomic.Int64
+
+// uniqueTimestamp returns the current time in nanoseconds, bumped when needed
+// so that IDs generated in a tight loop never collide.
+func uniqueTimestamp() int64 {
+	for {
+		last := lastIDTimestamp.Load()
+		now := time.Now().UnixNano()
+		if now <= last {
+			now = last + 1
+		}
+		if lastIDTimestamp.CompareAndSwap(last, now) {
+			return now
+		}
+	}
+}
+
 func (i *ItemV2) GenerateID() {
 	if i.ID == "" {
-		i.ID = fmt.Sprintf("item_%d", time.Now().UnixNano())
+		i.ID = fmt.Sprintf("item_%d", uniqueTimestamp())
 	}
 }
 
@@ -79,7 +118,8 @@ func (i ItemV2) MatchesSearch(criteria SearchCriteria) bool {
 		query := strings.ToLower(criteria.Query)
 		if !strings.Contains(strings.ToLower(i.Title), query) &&
 			!strings.Contains(strings.ToLower(i.Desc), query) &&
-			!strings.Contains(strings.ToLower(i.Command), query) {
+			!strings.Contains(strings.ToLower(i.Script()), query) &&
+			!slices.ContainsFunc(i.Variants, func(v Variant) bool { return strings.Contains(strings.ToLower(v.Command), query) }) {
 			return false
 		}
 	}
@@ -118,7 +158,7 @@ func (i ItemV2) MatchesSearch(criteria SearchCriteria) bool {
 
 func (f *FolderV2) GenerateID() {
 	if f.ID == "" {
-		f.ID = fmt.Sprintf("folder_%d", time.Now().UnixNano())
+		f.ID = fmt.Sprintf("folder_%d", uniqueTimestamp())
 	}
 }
 
@@ -159,6 +199,61 @@ func NewDatabaseV2() DatabaseV2 {
 	}
 }
 
+func (db DatabaseV2) Clone() DatabaseV2 {
+	clone := DatabaseV2{
+		Version: db.Version,
+		Folders: make([]FolderV2, len(db.Folders)),
+		Items:   make([]ItemV2, len(db.Items)),
+	}
+
+	for i, folder := range db.Folders {
+		clone.Folders[i] = folder.Clone()
+	}
+
+	for i, item := range db.Items {
+		clone.Items[i] = item.Clone()
+	}
+
+	return clone
+}
+
+// Clone returns a copy of the item that shares no slices or maps with it.
+func (i ItemV2) Clone() ItemV2 {
+	if i.Tags != nil {
+		i.Tags = append([]string{}, i.Tags...)
+	}
+	if i.Steps != nil {
+		i.Steps = append([]Step{}, i.Steps...)
+	}
+	if i.Variants != nil {
+		i.Variants = append([]Variant{}, i.Variants...)
+	}
+	if i.Variables != nil {
+		i.Variables = append([]Variable{}, i.Variables...)
+	}
+	i.RunSettings = i.RunSettings.Clone()
+	i.Metadata = cloneMetadata(i.Metadata)
+	return i
+}
+
+// Clone returns a copy of the folder that shares no maps with it.
+func (f FolderV2) Clone() FolderV2 {
+	f.RunSettings = f.RunSettings.Clone()
+	f.Metadata = cloneMetadata(f.Metadata)
+	return f
+}
+
+func cloneMetadata(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		clone[k] = v
+	}
+	return clone
+}
+
 func (db *DatabaseV2) AddFolder(folder FolderV2) error {
 	folder.GenerateID()
 
@@ -216,6 +311,37 @@ func (db DatabaseV2) GetSubfolders(parentPath string) []FolderV2 {
 	return subfolders
 }
 
+// NextPosition returns the manual-order position after every folder and item
+// directly inside parentPath.
+func (db DatabaseV2) NextPosition(parentPath string) int {
+	next := 0
+	for _, folder := range db.GetSubfolders(parentPath) {
+		if folder.Position >= next {
+			next = folder.Position + 1
+		}
+	}
+	for _, item := range db.GetItemsByFolder(parentPath) {
+		if item.Position >= next {
+			next = item.Position + 1
+		}
+	}
+	return next
+}
+
+func (db DatabaseV2) GetDescendants(folderPath string) ([]FolderV2, []ItemV2) {
+	var folders []FolderV2
+	items := db.GetItemsByFolder(folderPath)
+
+	for _, subfolder := range db.GetSubfolders(folderPath) {
+		folders = append(folders, subfolder)
+		subfolderFolders, subfolderItems := db.GetDescendants(subfolder.Path)
+		folders = append(folders, subfolderFolders...)
+		items = append(items, subfolderItems...)
+	}
+
+	return folders, items
+}
+
 func (db DatabaseV2) Search(criteria SearchCriteria) SearchResult {
 	var matchingItems []ItemV2
 	var matchingFolders []FolderV2
@@ -369,6 +369,71 @@ func TestDatabaseV2_GetItemsByFolder(t *testing.T) {
 	}
 }
 
+func TestDatabaseV2_GetDescendants(t *testing.T) {
+	db := NewDatabaseV2()
+
+	folders := []FolderV2{
+		{Name: "infra", Path: "/infra", DateAdded: time.Now(), DateUpdated: time.Now()},
+		{Name: "aws", Path: "/infra/aws", ParentPath: "/infra", DateAdded: time.Now(), DateUpdated: time.Now()},
+		{Name: "iam", Path: "/infra/aws/iam", ParentPath: "/infra/aws", DateAdded: time.Now(), DateUpdated: time.Now()},
+		{Name: "other", Path: "/other", DateAdded: time.Now(), DateUpdated: time.Now()},
+	}
+	for _, folder := range folders {
+		if err := db.AddFolder(folder); err != nil {
+			t.Fatalf("Failed to add folder %s: %v", folder.Path, err)
+		}
+	}
+
+	items := []ItemV2{
+		{Title: "Root", FolderPath: "/infra", DateAdded: time.Now(), DateUpdated: time.Now()},
+		{Title: "Deep", FolderPath: "/infra/aws/iam", DateAdded: time.Now(), DateUpdated: time.Now()},
+		{Title: "Outside", FolderPath: "/other", DateAdded: time.Now(), DateUpdated: time.Now()},
+	}
+	for _, item := range items {
+		if err := db.AddItem(item); err != nil {
+			t.Fatalf("Failed to add item %s: %v", item.Title, err)
+		}
+	}
+
+	descendantFolders, descendantItems := db.GetDescendants("/infra")
+	if len(descendantFolders) != 2 {
+		t.Errorf("Expected 2 descendant folders, got %d", len(descendantFolders))
+	}
+	if len(descendantItems) != 2 {
+		t.Errorf("Expected 2 descendant items, got %d", len(descendantItems))
+	}
+
+	emptyFolders, emptyItems := db.GetDescendants("/infra/aws/iam")
+	if len(emptyFolders) != 0 || len(emptyItems) != 1 {
+		t.Errorf("Expected 0 folders and 1 item in leaf folder, got %d and %d", len(emptyFolders), len(emptyItems))
+	}
+}
+
+func TestDatabaseV2_Clone(t *testing.T) {
+	db := NewDatabaseV2()
+	if err := db.AddFolder(FolderV2{Name: "scripts", Path: "/scripts", Metadata: map[string]string{"k": "v"}}); err != nil {
+		t.Fatalf("Failed to add folder: %v", err)
+	}
+	if err := db.AddItem(ItemV2{Title: "Item", FolderPath: "/scripts", Tags: []string{"a"}}); err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+
+	clone := db.Clone()
+	clone.Folders[0].Path = "/changed"
+	clone.Folders[0].Metadata["k"] = "changed"
+	clone.Items[0].Tags[0] = "changed"
+
+	if db.Folders[0].Path != "/scripts" {
+		t.Error("Expected original folder path to be untouched")
+	}
+	if db.Folders[0].Metadata["k"] != "v" {
+		t.Error("Expected original folder metadata to be untouched")
+	}
+	if db.Items[0].Tags[0] != "a" {
+		t.Error("Expected original item tags to be untouched")
+	}
+}
+
 func TestDatabaseV2_Search(t *testing.T) {
 	db := NewDatabaseV2()
 	testTime := time.Now()
@@ -0,0 +1,8 @@
+package models
+
+// KeyBindings remaps keys by action ID. Keys are applied on top of the
+// bindings of Preset, which defaults to "default".
+type KeyBindings struct {
+	Preset string              `json:"preset,omitempty" toml:"preset,omitempty"`
+	Keys   map[string][]string `json:"keys,omitempty" toml:"keys,omitempty"`
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	LanguageShell  = "shell"
+	LanguagePython = "python"
+	LanguageSQL    = "sql"
+	LanguageYAML   = "yaml"
+	LanguageJq     = "jq"
+)
+
+// Language describes how snippets written in it are copied and run.
+type Language struct {
+	ID   string
+	Name string
+	// Wrapper is the command that takes a snippet as its last argument.
+	// Copying wraps the snippet in it, so that it can be pasted into a shell.
+	Wrapper []string
+	// Runnable snippets run on their own: shell snippets in the user's shell
+	// and the others through Wrapper.
+	Runnable bool
+}
+
+// Languages lists the supported languages in the order they are offered.
+var Languages = []Language{
+	{ID: LanguageShell, Name: "Shell", Runnable: true},
+	{ID: LanguagePython, Name: "Python", Wrapper: []string{"python3", "-c"}, Runnable: true},
+	{ID: LanguageSQL, Name: "SQL"},
+	{ID: LanguageYAML, Name: "YAML"},
+	{ID: LanguageJq, Name: "jq", Wrapper: []string{"jq"}},
+}
+
+// LanguageByID returns the language with the given ID, falling back to shell.
+func LanguageByID(id string) Language {
+	for _, language := range Languages {
+		if language.ID == id {
+			return language
+		}
+	}
+	return Languages[0]
+}
+
+// CopyText returns what copying snippet puts in the clipboard.
+func (l Language) CopyText(snippet string) string {
+	if len(l.Wrapper) == 0 {
+		return snippet
+	}
+	return strings.Join(l.Wrapper, " ") + " " + ShellQuote(snippet)
+}
+
+// RunArgs returns the command line that runs snippet, or false when snippets
+// of the language cannot run on their own. Shell snippets run in shell, or
+// in /bin/sh when it is empty.
+func (l Language) RunArgs(snippet, shell string) ([]string, bool) {
+	if !l.Runnable {
+		return nil, false
+	}
+	if len(l.Wrapper) > 0 {
+		return append(append([]string{}, l.Wrapper...), snippet), true
+	}
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	return []string{shell, "-c", snippet}, true
+}
+
+// ShellQuote quotes s as a single shell word.
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+var (
+	shebangPattern = regexp.MustCompile(`^#!\s*(?:/usr/bin/env\s+)?\S*?(python|bash|sh|zsh|fish)[\d.]*\b`)
+	sqlPattern     = regexp.MustCompile(`(?is)^\s*(select\s.+\sfrom\s|insert\s+into\s|update\s+\S+\s+set\s|delete\s+from\s|create\s+(table|index|view|database|schema)\s|alter\s+table\s|drop\s+(table|index|view|database|schema)\s|with\s+\w+\s+as\s*\()`)
+	pythonPattern  = regexp.MustCompile(`(?m)^\s*(import\s+[\w.]+\s*$|import\s+[\w.]+\s*(,|as\s)|from\s+[\w.]+\s+import\s|def\s+\w+\s*\(|class\s+\w+\s*[:(]|print\(|if\s+__name__\s*==)`)
+	yamlKey        = regexp.MustCompile(`^\s*(-\s+)?[\w.-]+:(\s|$)`)
+	yamlLine       = regexp.MustCompile(`^\s*-\s|^---\s*$|^\s*#`)
+	jqPattern      = regexp.MustCompile(`^\s*(\.(\w|\[|\s*\||\s*$)|(map|select|to_entries|from_entries|with_entries|keys|length|del|group_by|sort_by|reduce|paths)\s*(\(|\||$))`)
+)
+
+// DetectLanguage guesses the language of a snippet, falling back to shell.
+func DetectLanguage(snippet string) string {
+	if match := shebangPattern.FindStringSubmatch(snippet); match != nil {
+		if match[1] == "python" {
+			return LanguagePython
+		}
+		return LanguageShell
+	}
+
+	switch {
+	case sqlPattern.MatchString(snippet):
+		return LanguageSQL
+	case pythonPattern.MatchString(snippet):
+		return LanguagePython
+	case isYAML(snippet):
+		return LanguageYAML
+	case jqPattern.MatchString(snippet):
+		return LanguageJq
+	default:
+		return LanguageShell
+	}
+}
+
+// isYAML reports whether snippet has at least two lines, one of them a key,
+// and every line is a YAML key, list item, document marker or comment.
+func isYAML(snippet string) bool {
+	lines, keys := 0, 0
+	for _, line := range strings.Split(snippet, "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case yamlKey.MatchString(line):
+			keys++
+		case yamlLine.MatchString(line):
+		case lines > 0 && strings.HasPrefix(line, "  "):
+			// A continuation line of a block scalar.
+		default:
+			return false
+		}
+		lines++
+	}
+	return lines >= 2 && keys > 0
+}
+
+// GetLanguage returns the language the item was given, or the one detected
+// from its command.
+func (i ItemV2) GetLanguage() string {
+	if i.Language != "" {
+		return i.Language
+	}
+	if i.Interpreter != "" {
+		return InterpreterLanguage(i.Interpreter)
+	}
+	if i.IsMultiStep() {
+		commands := make([]string, len(i.Steps))
+		for index, step := range i.Steps {
+			commands[index] = step.Command
+		}
+		return DetectLanguage(strings.Join(commands, "\n"))
+	}
+	return DetectLanguage(i.Command)
+}
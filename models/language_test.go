@@ -0,0 +1,101 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		snippet  string
+		expected string
+	}{
+		{"shell command", "ls -la | grep go", LanguageShell},
+		{"shell script", "#!/usr/bin/env bash\nset -e", LanguageShell},
+		{"python shebang", "#!/usr/bin/env python3\nprint('hi')", LanguagePython},
+		{"python import", "import json, sys\nprint(json.load(sys.stdin))", LanguagePython},
+		{"python function", "def main():\n    pass", LanguagePython},
+		{"sql select", "SELECT id, name\nFROM users\nWHERE active = 1;", LanguageSQL},
+		{"sql lowercase", "delete from sessions where expires_at < now();", LanguageSQL},
+		{"sql cte", "WITH recent AS (SELECT 1) SELECT * FROM recent", LanguageSQL},
+		{"kubernetes yaml", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: dev", LanguageYAML},
+		{"yaml list", "- name: build\n  run: make\n- name: test\n  run: make test", LanguageYAML},
+		{"jq path", ".items[] | select(.status == \"ok\") | .name", LanguageJq},
+		{"jq function", "map(.id)", LanguageJq},
+		{"single key is not yaml", "key: value", LanguageShell},
+		{"commented shell is not yaml", "# build\n  make all", LanguageShell},
+		{"sourcing is shell", ". ~/.bashrc", LanguageShell},
+		{"relative script is shell", "./deploy.sh --prod", LanguageShell},
+		{"select in shell", "select-editor", LanguageShell},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.snippet); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestItemV2_GetLanguage(t *testing.T) {
+	item := ItemV2{Command: "SELECT * FROM users"}
+	if got := item.GetLanguage(); got != LanguageSQL {
+		t.Errorf("Expected detected language %q, got %q", LanguageSQL, got)
+	}
+
+	item.Language = LanguageShell
+	if got := item.GetLanguage(); got != LanguageShell {
+		t.Errorf("Expected explicit language %q, got %q", LanguageShell, got)
+	}
+}
+
+func TestLanguage_CopyText(t *testing.T) {
+	tests := []struct {
+		language string
+		snippet  string
+		expected string
+	}{
+		{LanguageShell, "echo 'hi'", "echo 'hi'"},
+		{LanguagePython, "print('hi')", `python3 -c 'print('\''hi'\'')'`},
+		{LanguageJq, ".name", "jq '.name'"},
+		{LanguageSQL, "SELECT 1", "SELECT 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			if got := LanguageByID(tt.language).CopyText(tt.snippet); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLanguage_RunArgs(t *testing.T) {
+	tests := []struct {
+		language string
+		shell    string
+		expected []string
+		runnable bool
+	}{
+		{LanguageShell, "/bin/zsh", []string{"/bin/zsh", "-c", "snippet"}, true},
+		{LanguageShell, "", []string{"/bin/sh", "-c", "snippet"}, true},
+		{LanguagePython, "/bin/zsh", []string{"python3", "-c", "snippet"}, true},
+		{LanguageSQL, "/bin/zsh", nil, false},
+		{LanguageJq, "/bin/zsh", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language+tt.shell, func(t *testing.T) {
+			args, runnable := LanguageByID(tt.language).RunArgs("snippet", tt.shell)
+			if runnable != tt.runnable || !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("Expected %v (%v), got %v (%v)", tt.expected, tt.runnable, args, runnable)
+			}
+		})
+	}
+
+	if LanguageByID("unknown").ID != LanguageShell {
+		t.Error("Expected unknown languages to fall back to shell")
+	}
+}
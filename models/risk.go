@@ -0,0 +1,138 @@
+package models
+
+import "slices"
+
+const (
+	RiskNone   = ""
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// RiskRule flags commands matching Pattern, a regular expression, as risky.
+// A rule in the config file replaces the built-in rule with the same ID.
+type RiskRule struct {
+	ID          string `toml:"id" validate:"required"`
+	Pattern     string `toml:"pattern" validate:"required,regexp"`
+	Level       string `toml:"level" validate:"oneof=medium high"`
+	Description string `toml:"description"`
+}
+
+// RiskSettings adds rules to the built-in ones and turns built-in rules off
+// by ID.
+type RiskSettings struct {
+	Rules    []RiskRule `toml:"rules" validate:"dive"`
+	Disabled []string   `toml:"disabled"`
+}
+
+// Reason explains what matching the rule means, falling back to its ID.
+func (r RiskRule) Reason() string {
+	if r.Description == "" {
+		return r.ID
+	}
+	return r.Description
+}
+
+// Risk is the result of analyzing a command: the highest level among the
+// rules it matches, and those rules.
+type Risk struct {
+	Level string
+	Rules []RiskRule
+}
+
+// IsHigh reports whether the command needs a confirmation before it is
+// copied or run.
+func (r Risk) IsHigh() bool {
+	return r.Level == RiskHigh
+}
+
+// RiskRank orders levels, so that the highest one wins.
+func RiskRank(level string) int {
+	switch level {
+	case RiskHigh:
+		return 2
+	case RiskMedium:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DefaultRiskRules are the rules every command is checked against.
+var DefaultRiskRules = []RiskRule{
+	{
+		ID:          "rm_recursive",
+		Pattern:     `\brm\s+(-[a-zA-Z]*[rR][a-zA-Z]*f|-[a-zA-Z]*f[a-zA-Z]*[rR]|-[rR]\s+-f|-f\s+-[rR]|--recursive\s+--force|--force\s+--recursive)\b`,
+		Level:       RiskHigh,
+		Description: "deletes files recursively without asking",
+	},
+	{
+		ID:          "kubectl_delete",
+		Pattern:     `\bkubectl\s+(\S+\s+)*delete(\s|$)`,
+		Level:       RiskHigh,
+		Description: "deletes Kubernetes resources",
+	},
+	{
+		ID:          "terraform_destroy",
+		Pattern:     `\b(terraform|tofu)\s+(\S+\s+)*(destroy\b|apply\s+(\S+\s+)*-destroy\b)`,
+		Level:       RiskHigh,
+		Description: "destroys infrastructure",
+	},
+	{
+		ID:          "git_force_push",
+		Pattern:     `\bgit\s+push\b[^|;&]*\s(--force\b|--force-with-lease\b|-[a-zA-Z]*f[a-zA-Z]*\b|\+\S+)`,
+		Level:       RiskHigh,
+		Description: "overwrites remote history",
+	},
+	{
+		ID:          "pipe_to_shell",
+		Pattern:     `\b(curl|wget)\b[^|]*\|\s*(sudo\s+)?(ba|z|da|k)?sh\b`,
+		Level:       RiskHigh,
+		Description: "runs a script downloaded from the network",
+	},
+	{
+		ID:          "disk_overwrite",
+		Pattern:     `\b(dd\s+[^|;&]*\bof=/dev/|mkfs(\.\w+)?\s|wipefs\s)`,
+		Level:       RiskHigh,
+		Description: "overwrites a disk or partition",
+	},
+	{
+		ID:          "sql_drop",
+		Pattern:     `(?i)\b(drop\s+(table|database|schema)|truncate\s+(table\s+)?\w)`,
+		Level:       RiskHigh,
+		Description: "drops or empties database tables",
+	},
+	{
+		ID:          "git_discard",
+		Pattern:     `\bgit\s+(reset\s+(\S+\s+)*--hard\b|clean\s+(\S+\s+)*-[a-zA-Z]*f|checkout\s+(\S+\s+)*--\s+\.)`,
+		Level:       RiskMedium,
+		Description: "discards local changes",
+	},
+	{
+		ID:          "docker_prune",
+		Pattern:     `\bdocker\s+(system|volume|image|container|network)\s+prune\b`,
+		Level:       RiskMedium,
+		Description: "removes unused Docker data",
+	},
+	{
+		ID:          "sudo",
+		Pattern:     `(^|[\s;&|(])sudo\s`,
+		Level:       RiskMedium,
+		Description: "runs with root privileges",
+	},
+}
+
+// Merge returns the risk of running both commands: the higher level and the
+// rules of both, each once.
+func (r Risk) Merge(other Risk) Risk {
+	if RiskRank(other.Level) > RiskRank(r.Level) {
+		r.Level = other.Level
+	}
+	rules := append([]RiskRule{}, r.Rules...)
+	for _, rule := range other.Rules {
+		if !slices.ContainsFunc(rules, func(existing RiskRule) bool { return existing.ID == rule.ID }) {
+			rules = append(rules, rule)
+		}
+	}
+	r.Rules = rules
+	return r
+}
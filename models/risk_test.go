@@ -0,0 +1,21 @@
+package models
+
+import "testing"
+
+func TestRisk_Merge(t *testing.T) {
+	sudo := RiskRule{ID: "sudo", Level: RiskMedium}
+	rm := RiskRule{ID: "rm_recursive", Level: RiskHigh}
+
+	merged := Risk{Level: RiskMedium, Rules: []RiskRule{sudo}}.Merge(Risk{Level: RiskHigh, Rules: []RiskRule{rm, sudo}})
+
+	if merged.Level != RiskHigh {
+		t.Errorf("Expected level %q, got %q", RiskHigh, merged.Level)
+	}
+	if len(merged.Rules) != 2 || merged.Rules[0].ID != "sudo" || merged.Rules[1].ID != "rm_recursive" {
+		t.Errorf("Expected rules sudo and rm_recursive once each, got %+v", merged.Rules)
+	}
+
+	if none := (Risk{}).Merge(Risk{}); none.Level != RiskNone || none.IsHigh() {
+		t.Errorf("Expected no risk, got %+v", none)
+	}
+}
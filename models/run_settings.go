@@ -0,0 +1,217 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	InterpreterBash   = "bash"
+	InterpreterZsh    = "zsh"
+	InterpreterSh     = "sh"
+	InterpreterFish   = "fish"
+	InterpreterPython = "python"
+)
+
+// RunSettings say where and how a workflow runs. Folders hold defaults for
+// the workflows inside them, which inherit every setting they leave empty.
+type RunSettings struct {
+	// WorkingDir may start with ~ and use environment variables and the
+	// placeholders of variables.
+	WorkingDir  string            `json:"working_dir,omitempty" validate:"omitempty,max=1000,working_dir"`
+	Env         map[string]string `json:"env,omitempty" validate:"dive,keys,env_name,endkeys,max=1000"`
+	Interpreter string            `json:"interpreter,omitempty" validate:"omitempty,oneof=bash zsh sh fish python"` // The language default when empty
+}
+
+// In the text form of run settings, every line holds one setting.
+const (
+	workingDirSetting  = "dir"
+	interpreterSetting = "interpreter"
+	envSetting         = "env"
+)
+
+func (r RunSettings) IsZero() bool {
+	return r.WorkingDir == "" && len(r.Env) == 0 && r.Interpreter == ""
+}
+
+// Clone returns a copy of the settings that shares no map with them.
+func (r RunSettings) Clone() RunSettings {
+	if r.Env != nil {
+		r.Env = maps.Clone(r.Env)
+	}
+	return r
+}
+
+// Inherit returns the settings with the empty ones taken from defaults.
+// Environment variables are merged, the ones of r winning.
+func (r RunSettings) Inherit(defaults RunSettings) RunSettings {
+	inherited := r.Clone()
+	if inherited.WorkingDir == "" {
+		inherited.WorkingDir = defaults.WorkingDir
+	}
+	if inherited.Interpreter == "" {
+		inherited.Interpreter = defaults.Interpreter
+	}
+	for name, value := range defaults.Env {
+		if _, ok := inherited.Env[name]; !ok {
+			if inherited.Env == nil {
+				inherited.Env = map[string]string{}
+			}
+			inherited.Env[name] = value
+		}
+	}
+	return inherited
+}
+
+// WithValues returns a copy of the settings with the placeholders of the
+// working directory and of the environment replaced by values.
+func (r RunSettings) WithValues(values map[string]string) RunSettings {
+	expanded := r.Clone()
+	expanded.WorkingDir = ExpandVariables(r.WorkingDir, values)
+	for name, value := range expanded.Env {
+		expanded.Env[name] = ExpandVariables(value, values)
+	}
+	return expanded
+}
+
+// InterpreterLanguage returns the language of the snippets interpreter runs.
+func InterpreterLanguage(interpreter string) string {
+	switch interpreter {
+	case "":
+		return ""
+	case InterpreterPython:
+		return LanguagePython
+	default:
+		return LanguageShell
+	}
+}
+
+// RunArgs returns the command line that runs snippet, written in language.
+// The interpreter runs it when it runs that language; otherwise it runs as
+// Language.RunArgs does.
+func (r RunSettings) RunArgs(language Language, snippet, shell string) ([]string, bool) {
+	if !language.Runnable || InterpreterLanguage(r.Interpreter) != language.ID {
+		return language.RunArgs(snippet, shell)
+	}
+	program := r.Interpreter
+	if program == InterpreterPython {
+		program = "python3"
+	}
+	return []string{program, "-c", snippet}, true
+}
+
+// Dir returns the working directory with ~ and environment variables
+// expanded. Env is looked up before the environment of the process.
+func (r RunSettings) Dir() string {
+	dir := os.Expand(r.WorkingDir, func(name string) string {
+		if value, ok := r.Env[name]; ok {
+			return value
+		}
+		return os.Getenv(name)
+	})
+	return expandHome(dir)
+}
+
+// expandHome replaces a leading ~ of path by the home directory.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + strings.TrimPrefix(path, "~")
+		}
+	}
+	return path
+}
+
+// Environ returns environ, a list of NAME=value pairs, with Env added.
+// Values may refer to the variables of environ, as in PATH=~/bin:$PATH, and
+// ~ is expanded at their start and after each colon, as a shell does.
+func (r RunSettings) Environ(environ []string) []string {
+	lookup := map[string]string{}
+	for _, pair := range environ {
+		if name, value, ok := strings.Cut(pair, "="); ok {
+			lookup[name] = value
+		}
+	}
+
+	result := append([]string{}, environ...)
+	for _, name := range slices.Sorted(maps.Keys(r.Env)) {
+		paths := strings.Split(r.Env[name], ":")
+		for i, path := range paths {
+			paths[i] = expandHome(path)
+		}
+		value := os.Expand(strings.Join(paths, ":"), func(name string) string { return lookup[name] })
+		result = append(result, name+"="+value)
+	}
+	return result
+}
+
+// FormatRunSettings writes settings in the text form read by
+// ParseRunSettings.
+func FormatRunSettings(settings RunSettings) string {
+	var lines []string
+	if settings.WorkingDir != "" {
+		lines = append(lines, workingDirSetting+": "+settings.WorkingDir)
+	}
+	if settings.Interpreter != "" {
+		lines = append(lines, interpreterSetting+": "+settings.Interpreter)
+	}
+	for _, name := range slices.Sorted(maps.Keys(settings.Env)) {
+		lines = append(lines, envSetting+": "+name+"="+settings.Env[name])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseRunSettings reads run settings, one per line:
+//
+//	dir: ~/src/{{project}}
+//	interpreter: bash
+//	env: GOFLAGS=-mod=mod
+//	env: PATH=~/go/bin:$PATH
+func ParseRunSettings(text string) (RunSettings, error) {
+	var settings RunSettings
+	for number, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		setting, value, ok := strings.Cut(line, ":")
+		setting = strings.TrimSpace(setting)
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return RunSettings{}, fmt.Errorf("line %d: expected a setting and its value, as in %q", number+1, workingDirSetting+": ~/src")
+		}
+
+		switch setting {
+		case workingDirSetting:
+			if settings.WorkingDir != "" {
+				return RunSettings{}, fmt.Errorf("line %d: %s is set twice", number+1, setting)
+			}
+			settings.WorkingDir = value
+		case interpreterSetting:
+			if settings.Interpreter != "" {
+				return RunSettings{}, fmt.Errorf("line %d: %s is set twice", number+1, setting)
+			}
+			settings.Interpreter = value
+		case envSetting:
+			name, envValue, ok := strings.Cut(value, "=")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return RunSettings{}, fmt.Errorf("line %d: expected NAME=value after %s", number+1, envSetting)
+			}
+			if _, ok := settings.Env[name]; ok {
+				return RunSettings{}, fmt.Errorf("line %d: %s is set twice", number+1, name)
+			}
+			if settings.Env == nil {
+				settings.Env = map[string]string{}
+			}
+			settings.Env[name] = strings.TrimSpace(envValue)
+		default:
+			return RunSettings{}, fmt.Errorf("line %d: unknown setting %q, expected %s, %s or %s", number+1, setting, workingDirSetting, interpreterSetting, envSetting)
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRunSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expected      RunSettings
+		errorContains string
+	}{
+		{
+			name: "every setting",
+			text: "dir: ~/src/{{project}}\n\ninterpreter: bash\nenv: GOFLAGS=-mod=mod\nenv: PATH = ~/go/bin:$PATH",
+			expected: RunSettings{
+				WorkingDir:  "~/src/{{project}}",
+				Interpreter: InterpreterBash,
+				Env:         map[string]string{"GOFLAGS": "-mod=mod", "PATH": "~/go/bin:$PATH"},
+			},
+		},
+		{
+			name:     "empty",
+			text:     "\n  \n",
+			expected: RunSettings{},
+		},
+		{
+			name:          "unknown setting",
+			text:          "cwd: /tmp",
+			errorContains: `line 1: unknown setting "cwd"`,
+		},
+		{
+			name:          "missing value",
+			text:          "dir: /tmp\ninterpreter:",
+			errorContains: "line 2: expected a setting and its value",
+		},
+		{
+			name:          "environment without value",
+			text:          "env: DEBUG",
+			errorContains: "line 1: expected NAME=value after env",
+		},
+		{
+			name:          "set twice",
+			text:          "env: A=1\nenv: A=2",
+			errorContains: "line 2: A is set twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := ParseRunSettings(tt.text)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(settings, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, settings)
+			}
+
+			parsed, err := ParseRunSettings(FormatRunSettings(settings))
+			if err != nil || !reflect.DeepEqual(parsed, settings) {
+				t.Errorf("Expected formatted settings to parse back, got %+v (%v)", parsed, err)
+			}
+		})
+	}
+}
+
+func TestRunSettings_Inherit(t *testing.T) {
+	defaults := RunSettings{
+		WorkingDir:  "/srv",
+		Interpreter: InterpreterZsh,
+		Env:         map[string]string{"STAGE": "dev", "REGION": "eu"},
+	}
+	own := RunSettings{Env: map[string]string{"STAGE": "prod"}}
+
+	inherited := own.Inherit(defaults)
+	expected := RunSettings{
+		WorkingDir:  "/srv",
+		Interpreter: InterpreterZsh,
+		Env:         map[string]string{"STAGE": "prod", "REGION": "eu"},
+	}
+	if !reflect.DeepEqual(inherited, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, inherited)
+	}
+	if len(own.Env) != 1 {
+		t.Errorf("Expected the settings not to change, got %+v", own)
+	}
+}
+
+func TestRunSettings_RunArgs(t *testing.T) {
+	shell := LanguageByID(LanguageShell)
+	python := LanguageByID(LanguagePython)
+	sql := LanguageByID(LanguageSQL)
+
+	tests := []struct {
+		name        string
+		interpreter string
+		language    Language
+		expected    []string
+		runnable    bool
+	}{
+		{"language default", "", shell, []string{"/bin/zsh", "-c", "ls"}, true},
+		{"shell interpreter", InterpreterFish, shell, []string{"fish", "-c", "ls"}, true},
+		{"python interpreter", InterpreterPython, python, []string{"python3", "-c", "ls"}, true},
+		{"interpreter of another language", InterpreterBash, python, []string{"python3", "-c", "ls"}, true},
+		{"language that does not run", InterpreterBash, sql, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, ok := RunSettings{Interpreter: tt.interpreter}.RunArgs(tt.language, "ls", "/bin/zsh")
+			if ok != tt.runnable || !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("Expected %v (%v), got %v (%v)", tt.expected, tt.runnable, args, ok)
+			}
+		})
+	}
+}
+
+func TestRunSettings_DirAndEnviron(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	t.Setenv("GO_WORKFLOWS_TEST_ROOT", "src")
+
+	settings := RunSettings{
+		WorkingDir: "~/$GO_WORKFLOWS_TEST_ROOT/${PROJECT}",
+		Env:        map[string]string{"PROJECT": "api", "PATH": "/opt/bin:~/go/bin:$PATH", "HISTFILE": "~"},
+	}
+	if dir := settings.Dir(); dir != home+"/src/api" {
+		t.Errorf("Expected %s/src/api, got %s", home, dir)
+	}
+
+	environ := settings.Environ([]string{"PATH=/usr/bin", "TERM=xterm"})
+	expected := []string{"PATH=/usr/bin", "TERM=xterm", "HISTFILE=" + home, "PATH=/opt/bin:" + home + "/go/bin:/usr/bin", "PROJECT=api"}
+	if !reflect.DeepEqual(environ, expected) {
+		t.Errorf("Expected %v, got %v", expected, environ)
+	}
+}
+
+func TestItemV2_RunSettingsVariables(t *testing.T) {
+	item := ItemV2{
+		Command:     "make deploy",
+		RunSettings: RunSettings{WorkingDir: "~/src/{{project}}", Env: map[string]string{"STAGE": "{{stage}}"}},
+	}
+
+	var names []string
+	for _, variable := range item.AllVariables() {
+		names = append(names, variable.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"project", "stage"}) {
+		t.Errorf("Expected the placeholders of the run settings, got %v", names)
+	}
+
+	expanded := item.WithValues(map[string]string{"project": "api", "stage": "prod"})
+	if expanded.WorkingDir != "~/src/api" || expanded.Env["STAGE"] != "prod" {
+		t.Errorf("Expected expanded run settings, got %+v", expanded.RunSettings)
+	}
+	if item.Env["STAGE"] != "{{stage}}" {
+		t.Errorf("Expected the item not to change, got %+v", item.RunSettings)
+	}
+	if language := (ItemV2{Command: "print(1)", RunSettings: RunSettings{Interpreter: InterpreterPython}}).GetLanguage(); language != LanguagePython {
+		t.Errorf("Expected the language of the interpreter, got %s", language)
+	}
+}
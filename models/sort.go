@@ -0,0 +1,97 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type SortMode string
+
+const (
+	SortManual        SortMode = "manual"
+	SortByName        SortMode = "name"
+	SortByDateAdded   SortMode = "date_added"
+	SortByDateUpdated SortMode = "date_updated"
+	SortByUsage       SortMode = "usage"
+)
+
+// SortModes lists every sort mode in the order they are cycled through.
+var SortModes = []SortMode{SortManual, SortByName, SortByDateAdded, SortByDateUpdated, SortByUsage}
+
+type (
+	// FolderView holds how the contents of a folder are listed.
+	FolderView struct {
+		SortMode     SortMode `json:"sort_mode"`
+		FoldersFirst bool     `json:"folders_first"`
+	}
+
+	// SortKey carries the attributes a folder or item is sorted by. Position
+	// is the entry's place in manual order and Index its place in storage,
+	// which breaks every remaining tie.
+	SortKey struct {
+		Name        string
+		DateAdded   time.Time
+		DateUpdated time.Time
+		Usage       float64
+		Position    int
+		Index       int
+		IsFolder    bool
+	}
+)
+
+func DefaultFolderView() FolderView {
+	return FolderView{SortMode: SortManual, FoldersFirst: true}
+}
+
+func (s SortMode) IsValid() bool {
+	for _, mode := range SortModes {
+		if mode == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the sort mode after s, wrapping around to the first one.
+func (s SortMode) Next() SortMode {
+	for i, mode := range SortModes {
+		if mode == s {
+			return SortModes[(i+1)%len(SortModes)]
+		}
+	}
+	return SortModes[0]
+}
+
+// Less reports whether a is listed before b. Dates and usage put the newest
+// and most used entries first; ties fall back to name and then storage order.
+func (v FolderView) Less(a, b SortKey) bool {
+	if v.FoldersFirst && a.IsFolder != b.IsFolder {
+		return a.IsFolder
+	}
+
+	switch v.SortMode {
+	case SortByDateAdded:
+		if !a.DateAdded.Equal(b.DateAdded) {
+			return a.DateAdded.After(b.DateAdded)
+		}
+	case SortByDateUpdated:
+		if !a.DateUpdated.Equal(b.DateUpdated) {
+			return a.DateUpdated.After(b.DateUpdated)
+		}
+	case SortByUsage:
+		if a.Usage != b.Usage {
+			return a.Usage > b.Usage
+		}
+	case SortByName:
+	default:
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.Index < b.Index
+	}
+
+	if nameA, nameB := strings.ToLower(a.Name), strings.ToLower(b.Name); nameA != nameB {
+		return nameA < nameB
+	}
+	return a.Index < b.Index
+}
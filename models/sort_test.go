@@ -0,0 +1,103 @@
+package models
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSortMode_Next(t *testing.T) {
+	mode := SortManual
+	for range SortModes {
+		mode = mode.Next()
+	}
+	if mode != SortManual {
+		t.Errorf("Expected cycling through every mode to wrap to %s, got %s", SortManual, mode)
+	}
+
+	if SortMode("bogus").Next() != SortModes[0] {
+		t.Error("Expected an unknown mode to restart the cycle")
+	}
+	if SortMode("bogus").IsValid() || !SortByUsage.IsValid() {
+		t.Error("Expected only known modes to be valid")
+	}
+}
+
+func TestFolderView_Less(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	keys := []SortKey{
+		{Name: "beta", DateAdded: base, DateUpdated: base.Add(3 * time.Hour), Usage: 5, Index: 0},
+		{Name: "Alpha", DateAdded: base.Add(time.Hour), DateUpdated: base.Add(time.Hour), Usage: 0, Index: 1},
+		{Name: "gamma", DateAdded: base.Add(2 * time.Hour), DateUpdated: base, Usage: 10, Index: 2},
+		{Name: "docs", DateAdded: base, DateUpdated: base, Index: 3, IsFolder: true},
+	}
+
+	tests := []struct {
+		name     string
+		view     FolderView
+		expected []string
+	}{
+		{
+			name:     "Manual without positions keeps storage order",
+			view:     FolderView{SortMode: SortManual},
+			expected: []string{"beta", "Alpha", "gamma", "docs"},
+		},
+		{
+			name:     "Manual with folders first",
+			view:     FolderView{SortMode: SortManual, FoldersFirst: true},
+			expected: []string{"docs", "beta", "Alpha", "gamma"},
+		},
+		{
+			name:     "Name is case insensitive",
+			view:     FolderView{SortMode: SortByName},
+			expected: []string{"Alpha", "beta", "docs", "gamma"},
+		},
+		{
+			name:     "Date added newest first",
+			view:     FolderView{SortMode: SortByDateAdded},
+			expected: []string{"gamma", "Alpha", "beta", "docs"},
+		},
+		{
+			name:     "Date updated newest first",
+			view:     FolderView{SortMode: SortByDateUpdated},
+			expected: []string{"beta", "Alpha", "docs", "gamma"},
+		},
+		{
+			name:     "Usage most used first, ties by name",
+			view:     FolderView{SortMode: SortByUsage, FoldersFirst: true},
+			expected: []string{"docs", "gamma", "beta", "Alpha"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := append([]SortKey(nil), keys...)
+			sort.SliceStable(sorted, func(i, j int) bool { return tt.view.Less(sorted[i], sorted[j]) })
+
+			for i, key := range sorted {
+				if key.Name != tt.expected[i] {
+					t.Fatalf("Expected order %v, got %v at index %d", tt.expected, key.Name, i)
+				}
+			}
+		})
+	}
+}
+
+func TestFolderView_Less_ManualPositions(t *testing.T) {
+	keys := []SortKey{
+		{Name: "first stored", Position: 2, Index: 0},
+		{Name: "second stored", Position: 0, Index: 1},
+		{Name: "folder", Position: 1, Index: 2, IsFolder: true},
+		{Name: "legacy", Position: 0, Index: 3},
+	}
+
+	view := FolderView{SortMode: SortManual}
+	sort.SliceStable(keys, func(i, j int) bool { return view.Less(keys[i], keys[j]) })
+
+	expected := []string{"second stored", "legacy", "folder", "first stored"}
+	for i, key := range keys {
+		if key.Name != expected[i] {
+			t.Fatalf("Expected order %v, got %v at index %d", expected, key.Name, i)
+		}
+	}
+}
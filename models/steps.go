@@ -0,0 +1,192 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Step is one command of a multi-step workflow. Steps run in order and a
+// failing step stops the run unless it continues on error.
+type Step struct {
+	Title           string `json:"title" validate:"required,min=1,max=255"`
+	Command         string `json:"command" validate:"required,min=1,max=5000"`
+	Description     string `json:"description,omitempty" validate:"max=1000"`
+	ContinueOnError bool   `json:"continue_on_error,omitempty"`
+}
+
+type StepStatus int
+
+const (
+	StepPending StepStatus = iota
+	StepSucceeded
+	// StepFailed marks the step that stopped the run.
+	StepFailed
+	// StepIgnored marks a step that failed but let the run continue.
+	StepIgnored
+)
+
+// In the text form of steps, every step starts with a header line holding its
+// title. "# " comment lines right after the header describe the step, and the
+// lines up to the next header are its command; a blank line ends the
+// description, so a command may start with a comment. Command lines that
+// would read as a header are indented by one more space. The text stays a
+// valid shell script.
+const (
+	stepHeaderPrefix  = "## "
+	stepCommentPrefix = "#"
+	continueMarker    = "[continue]"
+)
+
+// isDescriptionLine reports whether line describes a step when it follows
+// its header.
+func isDescriptionLine(line string) bool {
+	return line == stepCommentPrefix || strings.HasPrefix(line, stepCommentPrefix+" ")
+}
+
+// isEscapedHeader reports whether line, once its leading spaces are removed,
+// would start a step.
+func isEscapedHeader(line string) bool {
+	return strings.HasPrefix(strings.TrimLeft(line, " "), stepHeaderPrefix)
+}
+
+// IsMultiStep reports whether the item runs steps instead of a single
+// command.
+func (i ItemV2) IsMultiStep() bool {
+	return len(i.Steps) > 0
+}
+
+// Script returns the command of the item, or the text form of its steps.
+func (i ItemV2) Script() string {
+	if i.IsMultiStep() {
+		return FormatSteps(i.Steps)
+	}
+	return i.Command
+}
+
+// FormatSteps writes steps in the text form read by ParseSteps.
+func FormatSteps(steps []Step) string {
+	blocks := make([]string, len(steps))
+	for i, step := range steps {
+		header := stepHeaderPrefix + step.Title
+		if step.ContinueOnError {
+			header += " " + continueMarker
+		}
+		lines := []string{header}
+		if step.Description != "" {
+			for _, line := range strings.Split(step.Description, "\n") {
+				lines = append(lines, strings.TrimSpace(stepCommentPrefix+" "+line))
+			}
+		}
+		command := strings.Split(step.Command, "\n")
+		if isDescriptionLine(command[0]) {
+			lines = append(lines, "")
+		}
+		for _, line := range command {
+			if isEscapedHeader(line) {
+				line = " " + line
+			}
+			lines = append(lines, line)
+		}
+		blocks[i] = strings.Join(lines, "\n")
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// ParseSteps reads steps from their text form:
+//
+//	## Build
+//	# Compiles the binary
+//	make build
+//
+//	## Test [continue]
+//	go test ./...
+//
+// A title ending in [continue] lets the run go on when the step fails. A
+// command starting with a comment is set apart from the header by a blank
+// line, and one leading space is removed from command lines such as " ## x",
+// which FormatSteps writes for "## x".
+func ParseSteps(text string) ([]Step, error) {
+	var steps []Step
+	var command []string
+	inDescription := false
+
+	finish := func() error {
+		if len(steps) == 0 {
+			return nil
+		}
+		step := &steps[len(steps)-1]
+		for len(command) > 0 && strings.TrimSpace(command[0]) == "" {
+			command = command[1:]
+		}
+		for len(command) > 0 && strings.TrimSpace(command[len(command)-1]) == "" {
+			command = command[:len(command)-1]
+		}
+		step.Command = strings.Join(command, "\n")
+		if step.Command == "" {
+			return fmt.Errorf("step %d (%s) has no command", len(steps), step.Title)
+		}
+		return nil
+	}
+
+	for number, line := range strings.Split(text, "\n") {
+		if title, ok := strings.CutPrefix(line, stepHeaderPrefix); ok {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			title = strings.TrimSpace(title)
+			step := Step{}
+			if trimmed, ok := strings.CutSuffix(title, continueMarker); ok {
+				title = strings.TrimSpace(trimmed)
+				step.ContinueOnError = true
+			}
+			if title == "" {
+				return nil, fmt.Errorf("line %d: step %d has no title", number+1, len(steps)+1)
+			}
+			step.Title = title
+			steps = append(steps, step)
+			command = nil
+			inDescription = true
+			continue
+		}
+
+		if len(steps) == 0 {
+			if strings.TrimSpace(line) != "" {
+				return nil, fmt.Errorf("line %d: expected a step, starting with %q and its title", number+1, strings.TrimSpace(stepHeaderPrefix))
+			}
+			continue
+		}
+
+		if inDescription && isDescriptionLine(line) {
+			step := &steps[len(steps)-1]
+			text := strings.TrimSpace(strings.TrimPrefix(line, stepCommentPrefix))
+			if step.Description != "" {
+				step.Description += "\n"
+			}
+			step.Description += text
+			continue
+		}
+		inDescription = false
+		if isEscapedHeader(line) {
+			line = strings.TrimPrefix(line, " ")
+		}
+		command = append(command, line)
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no steps found, start each step with %q and its title", strings.TrimSpace(stepHeaderPrefix))
+	}
+	return steps, nil
+}
+
+// ResumeStep returns the step that stopped a run with the given statuses.
+func ResumeStep(statuses []StepStatus) (int, bool) {
+	for i, status := range statuses {
+		if status == StepFailed {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSteps(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expected      []Step
+		errorContains string
+	}{
+		{
+			name: "steps with descriptions and continue on error",
+			text: "## Build\n# Compiles the binary\n# for this platform\nmake build\n\n## Test [continue]\ngo test ./...\n\n## Ship\nscp bin host:\nssh host restart\n",
+			expected: []Step{
+				{Title: "Build", Description: "Compiles the binary\nfor this platform", Command: "make build"},
+				{Title: "Test", Command: "go test ./...", ContinueOnError: true},
+				{Title: "Ship", Command: "scp bin host:\nssh host restart"},
+			},
+		},
+		{
+			name:     "comments after the command belong to it",
+			text:     "## Clean\nrm -rf dist\n# keeps the cache\nrm -rf build",
+			expected: []Step{{Title: "Clean", Command: "rm -rf dist\n# keeps the cache\nrm -rf build"}},
+		},
+		{
+			name: "commands starting with a comment",
+			text: "## Lint\n#!/bin/bash\nshellcheck *.sh\n\n## Format\n# Rewrites the files\n\n# shellcheck disable=SC2046\ngofmt -w $(git ls-files '*.go')",
+			expected: []Step{
+				{Title: "Lint", Command: "#!/bin/bash\nshellcheck *.sh"},
+				{Title: "Format", Description: "Rewrites the files", Command: "# shellcheck disable=SC2046\ngofmt -w $(git ls-files '*.go')"},
+			},
+		},
+		{
+			name:     "indented headers belong to the command",
+			text:     "## Notes\ncat <<EOF\n ## Changes\n  ## Fixes\nEOF",
+			expected: []Step{{Title: "Notes", Command: "cat <<EOF\n## Changes\n ## Fixes\nEOF"}},
+		},
+		{
+			name:          "text before the first step",
+			text:          "make build\n## Build\nmake",
+			errorContains: "line 1: expected a step",
+		},
+		{
+			name:          "step without command",
+			text:          "## Build\n# Only a description\n\n## Test\ngo test",
+			errorContains: "step 1 (Build) has no command",
+		},
+		{
+			name:          "step without title",
+			text:          "## [continue]\nmake",
+			errorContains: "line 1: step 1 has no title",
+		},
+		{
+			name:          "no steps",
+			text:          "\n\n",
+			errorContains: "no steps found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := ParseSteps(tt.text)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(steps, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, steps)
+			}
+		})
+	}
+}
+
+func TestFormatSteps_RoundTrip(t *testing.T) {
+	steps := []Step{
+		{Title: "Build", Description: "Compiles the binary\n\nfor this platform", Command: "make build"},
+		{Title: "Test", Command: "go test ./...\ngo vet ./...", ContinueOnError: true},
+	}
+
+	text := FormatSteps(steps)
+	expected := "## Build\n# Compiles the binary\n#\n# for this platform\nmake build\n\n## Test [continue]\ngo test ./...\ngo vet ./..."
+	if text != expected {
+		t.Errorf("Expected text %q, got %q", expected, text)
+	}
+
+	parsed, err := ParseSteps(text)
+	if err != nil {
+		t.Fatalf("Failed to parse formatted steps: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, steps) {
+		t.Errorf("Expected %+v after a round trip, got %+v", steps, parsed)
+	}
+}
+
+func TestFormatSteps_RoundTripComments(t *testing.T) {
+	steps := []Step{
+		{Title: "Lint", Command: "# shellcheck disable=SC2086\nshellcheck $FILES"},
+		{Title: "Script", Description: "Runs under bash", Command: "#!/bin/bash\nset -e\n#\n./build.sh"},
+		{Title: "Notes", Command: "cat <<EOF\n## Changes\n ## Fixes\nEOF"},
+	}
+
+	text := FormatSteps(steps)
+	expected := "## Lint\n\n# shellcheck disable=SC2086\nshellcheck $FILES\n\n" +
+		"## Script\n# Runs under bash\n#!/bin/bash\nset -e\n#\n./build.sh\n\n" +
+		"## Notes\ncat <<EOF\n ## Changes\n  ## Fixes\nEOF"
+	if text != expected {
+		t.Errorf("Expected text %q, got %q", expected, text)
+	}
+
+	parsed, err := ParseSteps(text)
+	if err != nil {
+		t.Fatalf("Failed to parse formatted steps: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, steps) {
+		t.Errorf("Expected %+v after a round trip, got %+v", steps, parsed)
+	}
+}
+
+func TestItemV2_Script(t *testing.T) {
+	single := ItemV2{Command: "echo hi"}
+	if single.IsMultiStep() || single.Script() != "echo hi" {
+		t.Errorf("Expected a single command item to use its command, got %q", single.Script())
+	}
+
+	multi := ItemV2{Steps: []Step{{Title: "Greet", Command: "echo hi"}}}
+	if !multi.IsMultiStep() || multi.Script() != "## Greet\necho hi" {
+		t.Errorf("Expected a multi-step item to use its steps, got %q", multi.Script())
+	}
+}
+
+func TestResumeStep(t *testing.T) {
+	if _, ok := ResumeStep([]StepStatus{StepSucceeded, StepIgnored, StepSucceeded}); ok {
+		t.Error("Expected a finished run not to be resumable")
+	}
+	step, ok := ResumeStep([]StepStatus{StepSucceeded, StepIgnored, StepFailed, StepPending})
+	if !ok || step != 2 {
+		t.Errorf("Expected to resume from step 2, got %d (%v)", step, ok)
+	}
+}
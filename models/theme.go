@@ -0,0 +1,50 @@
+package models
+
+// Theme is a color scheme. Colors are ANSI 256 color numbers ("205") or hex
+// codes ("#ff5f87"). A user theme starts from the theme named in Base and
+// only needs to set the colors it changes.
+type Theme struct {
+	Base string `toml:"base,omitempty"`
+
+	// Accent marks the focused element, Muted the elements out of focus,
+	// Subtle secondary text such as dates and Warning risky commands.
+	Accent  string `toml:"accent,omitempty" validate:"omitempty,terminal_color"`
+	Muted   string `toml:"muted,omitempty" validate:"omitempty,terminal_color"`
+	Subtle  string `toml:"subtle,omitempty" validate:"omitempty,terminal_color"`
+	Warning string `toml:"warning,omitempty" validate:"omitempty,terminal_color"`
+
+	NotificationForeground string `toml:"notification_foreground,omitempty" validate:"omitempty,terminal_color"`
+	NotificationBackground string `toml:"notification_background,omitempty" validate:"omitempty,terminal_color"`
+
+	// Colors of the syntax highlighting of commands.
+	SyntaxCommand  string `toml:"syntax_command,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxKeyword  string `toml:"syntax_keyword,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxFlag     string `toml:"syntax_flag,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxString   string `toml:"syntax_string,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxVariable string `toml:"syntax_variable,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxNumber   string `toml:"syntax_number,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxOperator string `toml:"syntax_operator,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxSpecial  string `toml:"syntax_special,omitempty" validate:"omitempty,terminal_color"`
+	SyntaxComment  string `toml:"syntax_comment,omitempty" validate:"omitempty,terminal_color"`
+}
+
+// Inherit returns t with every color it leaves empty taken from base.
+func (t Theme) Inherit(base Theme) Theme {
+	overrideString(&base.Accent, t.Accent)
+	overrideString(&base.Muted, t.Muted)
+	overrideString(&base.Subtle, t.Subtle)
+	overrideString(&base.Warning, t.Warning)
+	overrideString(&base.NotificationForeground, t.NotificationForeground)
+	overrideString(&base.NotificationBackground, t.NotificationBackground)
+	overrideString(&base.SyntaxCommand, t.SyntaxCommand)
+	overrideString(&base.SyntaxKeyword, t.SyntaxKeyword)
+	overrideString(&base.SyntaxFlag, t.SyntaxFlag)
+	overrideString(&base.SyntaxString, t.SyntaxString)
+	overrideString(&base.SyntaxVariable, t.SyntaxVariable)
+	overrideString(&base.SyntaxNumber, t.SyntaxNumber)
+	overrideString(&base.SyntaxOperator, t.SyntaxOperator)
+	overrideString(&base.SyntaxSpecial, t.SyntaxSpecial)
+	overrideString(&base.SyntaxComment, t.SyntaxComment)
+	base.Base = t.Base
+	return base
+}
@@ -0,0 +1,15 @@
+package models
+
+import "testing"
+
+func TestTheme_Inherit(t *testing.T) {
+	base := Theme{Accent: "205", Muted: "240", SyntaxFlag: "32"}
+	theme := Theme{Base: "dark", Accent: "#ff0000"}
+
+	inherited := theme.Inherit(base)
+
+	expected := Theme{Base: "dark", Accent: "#ff0000", Muted: "240", SyntaxFlag: "32"}
+	if inherited != expected {
+		t.Errorf("Expected %+v, got %+v", expected, inherited)
+	}
+}
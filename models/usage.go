@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type (
+	// ItemUsage records how often and how recently a workflow was used. It is
+	// kept outside the database so that using a workflow never rewrites the
+	// data file.
+	ItemUsage struct {
+		Count    int       `json:"count,omitempty"`
+		LastUsed time.Time `json:"last_used,omitempty"`
+		Favorite bool      `json:"favorite,omitempty"`
+	}
+
+	UsageData struct {
+		Version string               `json:"version"`
+		Items   map[string]ItemUsage `json:"items"`
+	}
+)
+
+func NewUsageData() UsageData {
+	return UsageData{
+		Version: "1.0",
+		Items:   map[string]ItemUsage{},
+	}
+}
+
+// Frecency scores the usage by weighting the use count with how recently the
+// item was last used, so that daily commands outrank old habits.
+func (u ItemUsage) Frecency(now time.Time) float64 {
+	if u.Count == 0 {
+		return 0
+	}
+
+	age := now.Sub(u.LastUsed)
+	var weight float64
+	switch {
+	case age < 4*24*time.Hour:
+		weight = 100
+	case age < 14*24*time.Hour:
+		weight = 70
+	case age < 31*24*time.Hour:
+		weight = 50
+	case age < 90*24*time.Hour:
+		weight = 30
+	default:
+		weight = 10
+	}
+
+	return float64(u.Count) * weight
+}
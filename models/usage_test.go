@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestItemUsage_Frecency(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		usage    ItemUsage
+		expected float64
+	}{
+		{
+			name:     "Never used",
+			usage:    ItemUsage{Favorite: true},
+			expected: 0,
+		},
+		{
+			name:     "Used today",
+			usage:    ItemUsage{Count: 3, LastUsed: now.Add(-time.Hour)},
+			expected: 300,
+		},
+		{
+			name:     "Used last week",
+			usage:    ItemUsage{Count: 3, LastUsed: now.Add(-7 * 24 * time.Hour)},
+			expected: 210,
+		},
+		{
+			name:     "Used last month",
+			usage:    ItemUsage{Count: 3, LastUsed: now.Add(-20 * 24 * time.Hour)},
+			expected: 150,
+		},
+		{
+			name:     "Used two months ago",
+			usage:    ItemUsage{Count: 3, LastUsed: now.Add(-60 * 24 * time.Hour)},
+			expected: 90,
+		},
+		{
+			name:     "Used last year",
+			usage:    ItemUsage{Count: 3, LastUsed: now.Add(-365 * 24 * time.Hour)},
+			expected: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.usage.Frecency(now); got != tt.expected {
+				t.Errorf("Expected frecency %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewUsageData(t *testing.T) {
+	data := NewUsageData()
+
+	if data.Version != "1.0" {
+		t.Errorf("Expected version 1.0, got %s", data.Version)
+	}
+	if data.Items == nil {
+		t.Error("Expected items map to be initialized")
+	}
+}
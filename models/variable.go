@@ -0,0 +1,209 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	VariableString = "string"
+	VariableEnum   = "enum"
+	VariableInt    = "int"
+	VariablePath   = "path"
+	VariableBool   = "bool"
+)
+
+// Variable is a value asked for before a workflow is copied or run. Its
+// placeholder, {{name}}, is replaced by the value in the command. Suggest is
+// a shell command whose output lines are suggested as values; it may use the
+// placeholders of other variables.
+type Variable struct {
+	Name        string   `json:"name" validate:"required,variable_name"`
+	Type        string   `json:"type,omitempty" validate:"omitempty,oneof=string enum int path bool"` // String when empty
+	Default     string   `json:"default,omitempty"`
+	Description string   `json:"description,omitempty" validate:"max=255"`
+	Required    bool     `json:"required,omitempty"`
+	Choices     []string `json:"choices,omitempty" validate:"required_if=Type enum,dive,required"`
+	Suggest     string   `json:"suggest,omitempty" validate:"excluded_if=Type enum,excluded_if=Type bool,max=1000"`
+}
+
+// VariableValuesData holds the last value given to each variable, by item
+// ID. Like usage, it is kept outside the database.
+type VariableValuesData struct {
+	Version string                       `json:"version"`
+	Items   map[string]map[string]string `json:"items"`
+}
+
+func NewVariableValuesData() VariableValuesData {
+	return VariableValuesData{
+		Version: "1.0",
+		Items:   map[string]map[string]string{},
+	}
+}
+
+var (
+	placeholderPattern  = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	variableTypePattern = regexp.MustCompile(`^(\w+)(?:\((.*)\))?$`)
+)
+
+// Kind returns the type of the variable, which is a string unless given.
+func (v Variable) Kind() string {
+	if v.Type == "" {
+		return VariableString
+	}
+	return v.Type
+}
+
+// Placeholders returns the names of the variables used in text, each once,
+// in the order they first appear.
+func Placeholders(text string) []string {
+	var names []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// ExpandVariables replaces the placeholders of text that have a value.
+func ExpandVariables(text string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// AllVariables returns the declared variables of the item, followed by a
+// required string variable for every placeholder of its command and its run
+// settings that was not declared.
+func (i ItemV2) AllVariables() []Variable {
+	text := []string{i.Script(), i.WorkingDir}
+	for _, name := range slices.Sorted(maps.Keys(i.Env)) {
+		text = append(text, i.Env[name])
+	}
+
+	variables := append([]Variable{}, i.Variables...)
+	for _, name := range Placeholders(strings.Join(text, "\n")) {
+		declared := slices.ContainsFunc(variables, func(variable Variable) bool { return variable.Name == name })
+		if !declared {
+			variables = append(variables, Variable{Name: name, Required: true})
+		}
+	}
+	return variables
+}
+
+// WithValues returns a copy of the item with the placeholders of its command,
+// or of its steps, and of its run settings replaced by values.
+func (i ItemV2) WithValues(values map[string]string) ItemV2 {
+	expanded := i.Clone()
+	expanded.RunSettings = i.RunSettings.WithValues(values)
+	expanded.Command = ExpandVariables(i.Command, values)
+	for index := range expanded.Steps {
+		expanded.Steps[index].Command = ExpandVariables(i.Steps[index].Command, values)
+	}
+	return expanded
+}
+
+// ParseVariables reads variable declarations, one per line:
+//
+//	env!: enum(dev, staging, prod) = staging # Target environment
+//	replicas: int = 3
+//	values: path # Helm values file
+//	namespace: $(kubectl get ns -o name) # Suggested from the cluster
+//
+// A name ending in ! is required. The type defaults to string, text after #
+// describes the variable and the output of a command in $( ) is suggested as
+// its value.
+func ParseVariables(text string) ([]Variable, error) {
+	var variables []Variable
+	for number, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var variable Variable
+		line, suggest, err := cutSuggestCommand(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+		variable.Suggest = suggest
+		if declaration, description, ok := strings.Cut(line, "#"); ok {
+			line = strings.TrimSpace(declaration)
+			variable.Description = strings.TrimSpace(description)
+		}
+		if declaration, value, ok := strings.Cut(line, "="); ok {
+			line = strings.TrimSpace(declaration)
+			variable.Default = strings.TrimSpace(value)
+		}
+		name, kind, _ := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if trimmed, ok := strings.CutSuffix(name, "!"); ok {
+			name = strings.TrimSpace(trimmed)
+			variable.Required = true
+		}
+		if name == "" {
+			return nil, fmt.Errorf("line %d: variable has no name", number+1)
+		}
+		variable.Name = name
+
+		kind = strings.TrimSpace(kind)
+		if kind != "" {
+			match := variableTypePattern.FindStringSubmatch(kind)
+			if match == nil {
+				return nil, fmt.Errorf("line %d: invalid type %q", number+1, kind)
+			}
+			variable.Type = match[1]
+			if match[1] == VariableEnum {
+				for _, choice := range strings.Split(match[2], ",") {
+					if choice = strings.TrimSpace(choice); choice != "" {
+						variable.Choices = append(variable.Choices, choice)
+					}
+				}
+			} else if match[2] != "" {
+				return nil, fmt.Errorf("line %d: only enum variables take choices", number+1)
+			}
+		}
+
+		if slices.ContainsFunc(variables, func(existing Variable) bool { return existing.Name == name }) {
+			return nil, fmt.Errorf("line %d: variable %s is declared twice", number+1, name)
+		}
+		variables = append(variables, variable)
+	}
+	return variables, nil
+}
+
+// cutSuggestCommand removes the first $( ) from line, returning the command
+// inside it. Parentheses in the command must be balanced.
+func cutSuggestCommand(line string) (string, string, error) {
+	start := strings.Index(line, "$(")
+	if start < 0 {
+		return line, "", nil
+	}
+
+	depth := 0
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth > 0 {
+			continue
+		}
+		command := strings.TrimSpace(line[start+2 : i])
+		if command == "" {
+			return "", "", fmt.Errorf("empty suggestion command")
+		}
+		return strings.TrimSpace(line[:start] + line[i+1:]), command, nil
+	}
+	return "", "", fmt.Errorf("unclosed suggestion command")
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseVariables(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expected      []Variable
+		errorContains string
+	}{
+		{
+			name: "every type",
+			text: "env!: enum(dev, staging, prod) = staging # Target environment\n\nreplicas: int = 3\nvalues: path # Helm values file\ndry_run: bool=true\nmessage",
+			expected: []Variable{
+				{Name: "env", Type: VariableEnum, Default: "staging", Description: "Target environment", Required: true, Choices: []string{"dev", "staging", "prod"}},
+				{Name: "replicas", Type: VariableInt, Default: "3"},
+				{Name: "values", Type: VariablePath, Description: "Helm values file"},
+				{Name: "dry_run", Type: VariableBool, Default: "true"},
+				{Name: "message"},
+			},
+		},
+		{
+			name: "suggestion commands",
+			text: "branch!: $(git branch --format=%(refname:short)) = main # Branch to deploy\nport: int $(ss -tln | awk '{print $4}')",
+			expected: []Variable{
+				{Name: "branch", Default: "main", Description: "Branch to deploy", Required: true, Suggest: "git branch --format=%(refname:short)"},
+				{Name: "port", Type: VariableInt, Suggest: "ss -tln | awk '{print $4}'"},
+			},
+		},
+		{
+			name:          "unclosed suggestion command",
+			text:          "branch: $(git branch",
+			errorContains: "line 1: unclosed suggestion command",
+		},
+		{
+			name:          "empty suggestion command",
+			text:          "branch: $( )",
+			errorContains: "line 1: empty suggestion command",
+		},
+		{
+			name:          "missing name",
+			text:          "replicas: int\n: string",
+			errorContains: "line 2: variable has no name",
+		},
+		{
+			name:          "choices on another type",
+			text:          "replicas: int(1, 2)",
+			errorContains: "line 1: only enum variables take choices",
+		},
+		{
+			name:          "invalid type",
+			text:          "env: enum(dev",
+			errorContains: `line 1: invalid type "enum(dev"`,
+		},
+		{
+			name:          "declared twice",
+			text:          "env\nenv: string",
+			errorContains: "line 2: variable env is declared twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variables, err := ParseVariables(tt.text)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(variables, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, variables)
+			}
+		})
+	}
+}
+
+func TestExpandVariables(t *testing.T) {
+	text := "helm upgrade {{ release }} -f {{values}} --set image={{release}}:{{tag}} {{unknown}}"
+	if names := Placeholders(text); !reflect.DeepEqual(names, []string{"release", "values", "tag", "unknown"}) {
+		t.Errorf("Unexpected placeholders %v", names)
+	}
+
+	expanded := ExpandVariables(text, map[string]string{"release": "api", "values": "prod.yaml", "tag": ""})
+	expected := "helm upgrade api -f prod.yaml --set image=api: {{unknown}}"
+	if expanded != expected {
+		t.Errorf("Expected %q, got %q", expected, expanded)
+	}
+}
+
+func TestItemV2_AllVariables(t *testing.T) {
+	item := ItemV2{
+		Steps: []Step{
+			{Title: "Build", Command: "docker build -t {{image}}:{{tag}} ."},
+			{Title: "Push", Command: "docker push {{image}}:{{tag}}"},
+		},
+		Variables: []Variable{{Name: "tag", Default: "latest"}},
+	}
+
+	expected := []Variable{{Name: "tag", Default: "latest"}, {Name: "image", Required: true}}
+	if variables := item.AllVariables(); !reflect.DeepEqual(variables, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, variables)
+	}
+
+	expanded := item.WithValues(map[string]string{"image": "api", "tag": "v1"})
+	if expanded.Steps[1].Command != "docker push api:v1" {
+		t.Errorf("Expected expanded step command, got %q", expanded.Steps[1].Command)
+	}
+	if item.Steps[1].Command != "docker push {{image}}:{{tag}}" {
+		t.Error("Expected the original item to be left unchanged")
+	}
+}
@@ -0,0 +1,231 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Variant replaces the command of a workflow on the systems and in the
+// shells it matches. An empty OS or Shell matches any.
+type Variant struct {
+	OS      string `json:"os,omitempty" validate:"required_without=Shell,omitempty,oneof=linux darwin windows freebsd openbsd netbsd"`
+	Shell   string `json:"shell,omitempty" validate:"omitempty,oneof=bash zsh sh fish"`
+	Command string `json:"command" validate:"required,min=1,max=5000"`
+}
+
+// Platform is where a workflow runs: the operating system, as in
+// runtime.GOOS, and the name of the shell.
+type Platform struct {
+	OS    string
+	Shell string
+}
+
+// CurrentPlatform returns the system the application runs on with shell, a
+// path or a command line such as "/usr/bin/zsh -l".
+func CurrentPlatform(shell string) Platform {
+	name := ""
+	if fields := strings.Fields(shell); len(fields) > 0 {
+		name = filepath.Base(fields[0])
+	}
+	return Platform{OS: runtime.GOOS, Shell: name}
+}
+
+// WithInterpreter returns the platform with the shell replaced by
+// interpreter, when it is a shell.
+func (p Platform) WithInterpreter(interpreter string) Platform {
+	if InterpreterLanguage(interpreter) == LanguageShell {
+		p.Shell = interpreter
+	}
+	return p
+}
+
+// Matches reports whether the variant applies on p.
+func (v Variant) Matches(p Platform) bool {
+	return (v.OS == "" || v.OS == p.OS) && (v.Shell == "" || v.Shell == p.Shell)
+}
+
+// Label names the system and shell the variant is for, as in "macOS, zsh".
+func (v Variant) Label() string {
+	var parts []string
+	if v.OS != "" {
+		name, ok := osNames[v.OS]
+		if !ok {
+			name = v.OS
+		}
+		parts = append(parts, name)
+	}
+	if v.Shell != "" {
+		parts = append(parts, v.Shell)
+	}
+	return strings.Join(parts, ", ")
+}
+
+var osNames = map[string]string{
+	"linux":   "Linux",
+	"darwin":  "macOS",
+	"windows": "Windows",
+	"freebsd": "FreeBSD",
+	"openbsd": "OpenBSD",
+	"netbsd":  "NetBSD",
+}
+
+// ActiveVariant returns the index of the variant of the item that applies on
+// p, or -1 when the command applies. A variant naming both the system and
+// the shell wins over one naming only one of them; among equals the first
+// wins.
+func (i ItemV2) ActiveVariant(p Platform) int {
+	active, best := -1, 0
+	for index, variant := range i.Variants {
+		if !variant.Matches(p) {
+			continue
+		}
+		score := 0
+		if variant.OS != "" {
+			score++
+		}
+		if variant.Shell != "" {
+			score++
+		}
+		if score > best {
+			active, best = index, score
+		}
+	}
+	return active
+}
+
+// ForPlatform returns a copy of the item whose command is the one that
+// applies on p, without variants.
+func (i ItemV2) ForPlatform(p Platform) ItemV2 {
+	resolved := i.Clone()
+	if active := i.ActiveVariant(p); active >= 0 {
+		resolved.Command = i.Variants[active].Command
+	}
+	resolved.Variants = nil
+	return resolved
+}
+
+// VariantCommand returns the command of the variant at index, or the
+// command of the item when index is -1.
+func (i ItemV2) VariantCommand(index int) string {
+	if index < 0 || index >= len(i.Variants) {
+		return i.Command
+	}
+	return i.Variants[index].Command
+}
+
+// In the text form of a command with variants, the command comes first and
+// each variant starts with a header line naming where it applies.
+const variantHeaderPrefix = "#@variant"
+
+// FormatVariants writes command and its variants in the text form read by
+// ParseVariants.
+func FormatVariants(command string, variants []Variant) string {
+	blocks := []string{command}
+	for _, variant := range variants {
+		header := variantHeaderPrefix
+		if variant.OS != "" {
+			header += " os=" + variant.OS
+		}
+		if variant.Shell != "" {
+			header += " shell=" + variant.Shell
+		}
+		blocks = append(blocks, header+"\n"+variant.Command)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// ParseVariants reads a command followed by its variants:
+//
+//	sed -i 's/debug/info/' app.conf
+//
+//	#@variant os=darwin
+//	sed -i '' 's/debug/info/' app.conf
+//
+//	#@variant shell=fish
+//	sed -i 's/debug/info/' app.conf; and echo done
+//
+// The OS is a value of runtime.GOOS, or macos for darwin, and the shell is
+// one of bash, zsh, sh or fish. Text without variant headers is a command
+// alone.
+func ParseVariants(text string) (string, []Variant, error) {
+	var command string
+	var variants []Variant
+	var lines []string
+
+	finish := func() error {
+		body := trimBlankLines(lines)
+		if len(variants) == 0 {
+			command = body
+			return nil
+		}
+		variant := &variants[len(variants)-1]
+		if body == "" {
+			return fmt.Errorf("variant %d (%s) has no command", len(variants), variant.Label())
+		}
+		variant.Command = body
+		return nil
+	}
+
+	for number, line := range strings.Split(text, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), variantHeaderPrefix)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			lines = append(lines, line)
+			continue
+		}
+
+		if err := finish(); err != nil {
+			return "", nil, err
+		}
+		variant, err := parseVariantHeader(rest)
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+		variants = append(variants, variant)
+		lines = nil
+	}
+
+	if err := finish(); err != nil {
+		return "", nil, err
+	}
+	if command == "" && len(variants) > 0 {
+		return "", nil, fmt.Errorf("expected the command used elsewhere before the first variant")
+	}
+	return command, variants, nil
+}
+
+func parseVariantHeader(text string) (Variant, error) {
+	var variant Variant
+	for _, field := range strings.Fields(text) {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || value == "" {
+			return Variant{}, fmt.Errorf("expected os=NAME or shell=NAME, got %q", field)
+		}
+		switch name {
+		case "os":
+			if value == "macos" {
+				value = "darwin"
+			}
+			variant.OS = value
+		case "shell":
+			variant.Shell = value
+		default:
+			return Variant{}, fmt.Errorf("unknown variant setting %q, expected os or shell", name)
+		}
+	}
+	if variant.OS == "" && variant.Shell == "" {
+		return Variant{}, fmt.Errorf("a variant needs an os or a shell, as in %q", variantHeaderPrefix+" os=darwin")
+	}
+	return variant, nil
+}
+
+func trimBlankLines(lines []string) string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
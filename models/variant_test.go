@@ -0,0 +1,124 @@
+package models
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseVariants(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		command       string
+		variants      []Variant
+		errorContains string
+	}{
+		{
+			name:    "command alone",
+			text:    "ls -la\n# #@variants are not headers\n",
+			command: "ls -la\n# #@variants are not headers",
+		},
+		{
+			name:    "variants",
+			text:    "sed -i 's/a/b/' f\n\n#@variant os=macos\nsed -i '' 's/a/b/' f\n\n  #@variant os=linux shell=fish\nsed -i 's/a/b/' f; and echo ok\n",
+			command: "sed -i 's/a/b/' f",
+			variants: []Variant{
+				{OS: "darwin", Command: "sed -i '' 's/a/b/' f"},
+				{OS: "linux", Shell: "fish", Command: "sed -i 's/a/b/' f; and echo ok"},
+			},
+		},
+		{
+			name:          "variant without command",
+			text:          "ls\n#@variant os=darwin\n\n#@variant os=linux\nls",
+			errorContains: "variant 1 (macOS) has no command",
+		},
+		{
+			name:          "variant without platform",
+			text:          "ls\n#@variant\nls",
+			errorContains: "line 2: a variant needs an os or a shell",
+		},
+		{
+			name:          "unknown setting",
+			text:          "ls\n#@variant arch=arm64\nls",
+			errorContains: `line 2: unknown variant setting "arch"`,
+		},
+		{
+			name:          "no default command",
+			text:          "#@variant os=darwin\nopen .",
+			errorContains: "expected the command used elsewhere before the first variant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, variants, err := ParseVariants(tt.text)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if command != tt.command || !reflect.DeepEqual(variants, tt.variants) {
+				t.Errorf("Expected %q %+v, got %q %+v", tt.command, tt.variants, command, variants)
+			}
+
+			parsedCommand, parsedVariants, err := ParseVariants(FormatVariants(command, variants))
+			if err != nil || parsedCommand != command || !reflect.DeepEqual(parsedVariants, variants) {
+				t.Errorf("Expected formatted variants to parse back, got %q %+v (%v)", parsedCommand, parsedVariants, err)
+			}
+		})
+	}
+}
+
+func TestItemV2_ForPlatform(t *testing.T) {
+	item := ItemV2{
+		Command: "xdg-open .",
+		Variants: []Variant{
+			{OS: "darwin", Command: "open ."},
+			{Shell: "fish", Command: "xdg-open .; and true"},
+			{OS: "darwin", Shell: "fish", Command: "open .; and true"},
+		},
+	}
+
+	tests := []struct {
+		platform Platform
+		active   int
+		command  string
+	}{
+		{Platform{OS: "linux", Shell: "bash"}, -1, "xdg-open ."},
+		{Platform{OS: "darwin", Shell: "zsh"}, 0, "open ."},
+		{Platform{OS: "linux", Shell: "fish"}, 1, "xdg-open .; and true"},
+		{Platform{OS: "darwin", Shell: "fish"}, 2, "open .; and true"},
+	}
+
+	for _, tt := range tests {
+		if active := item.ActiveVariant(tt.platform); active != tt.active {
+			t.Errorf("%+v: expected variant %d, got %d", tt.platform, tt.active, active)
+		}
+		resolved := item.ForPlatform(tt.platform)
+		if resolved.Command != tt.command || resolved.Variants != nil {
+			t.Errorf("%+v: expected %q alone, got %q with %d variants", tt.platform, tt.command, resolved.Command, len(resolved.Variants))
+		}
+	}
+	if len(item.Variants) != 3 || item.Command != "xdg-open ." {
+		t.Errorf("Expected the item not to change, got %+v", item)
+	}
+}
+
+func TestCurrentPlatform(t *testing.T) {
+	platform := CurrentPlatform("/usr/local/bin/fish -l")
+	if platform.OS != runtime.GOOS || platform.Shell != "fish" {
+		t.Errorf("Expected %s with fish, got %+v", runtime.GOOS, platform)
+	}
+	if shell := platform.WithInterpreter(InterpreterBash).Shell; shell != "bash" {
+		t.Errorf("Expected the interpreter to replace the shell, got %s", shell)
+	}
+	if shell := platform.WithInterpreter(InterpreterPython).Shell; shell != "fish" {
+		t.Errorf("Expected python to keep the shell, got %s", shell)
+	}
+}
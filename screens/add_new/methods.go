@@ -1,14 +1,24 @@
 package addnew
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
 )
 
 func (m *Model) SetSize(width, height int) {
 	m.Title.Width = width
 	m.Description.Width = width
 	m.TextArea.SetWidth(width)
-	m.TextArea.SetHeight(height)
+	m.TextArea.SetHeight(max(height-2*(variablesHeight+2), variablesHeight))
+	m.Variables.SetWidth(width)
+	m.RunSettings.SetWidth(width)
 }
 
 func (m *Model) SetValues(title, description, command string) {
@@ -21,6 +31,10 @@ func (m *Model) ResetForm() {
 	m.Title.SetValue("")
 	m.Description.SetValue("")
 	m.TextArea.SetValue("")
+	m.Variables.SetValue("")
+	m.RunSettings.SetValue("")
+	m.language = 0
+	m.setMultiStep(false)
 	m.focusInput(title)
 }
 
@@ -30,32 +44,121 @@ func (m *Model) focusInput(i inputs) (Model, tea.Cmd) {
 		m.Title.Focus()
 		m.Description.Blur()
 		m.TextArea.Blur()
+		m.Variables.Blur()
+		m.RunSettings.Blur()
 		m.selectedInput = title
 	case description:
 		m.Title.Blur()
 		m.Description.Focus()
 		m.TextArea.Blur()
+		m.Variables.Blur()
+		m.RunSettings.Blur()
 		m.selectedInput = description
+	case kindPicker:
+		m.Title.Blur()
+		m.Description.Blur()
+		m.TextArea.Blur()
+		m.Variables.Blur()
+		m.RunSettings.Blur()
+		m.selectedInput = kindPicker
 	case textArea:
 		m.Title.Blur()
 		m.Description.Blur()
 		m.TextArea.Focus()
+		m.Variables.Blur()
+		m.RunSettings.Blur()
 		m.selectedInput = textArea
+	case variablesInput:
+		m.Title.Blur()
+		m.Description.Blur()
+		m.TextArea.Blur()
+		m.Variables.Focus()
+		m.RunSettings.Blur()
+		m.selectedInput = variablesInput
+	case runSettingsInput:
+		m.Title.Blur()
+		m.Description.Blur()
+		m.TextArea.Blur()
+		m.Variables.Blur()
+		m.RunSettings.Focus()
+		m.selectedInput = runSettingsInput
+	case languagePicker:
+		m.Title.Blur()
+		m.Description.Blur()
+		m.TextArea.Blur()
+		m.Variables.Blur()
+		m.RunSettings.Blur()
+		m.selectedInput = languagePicker
 	case submit:
 		m.Title.Blur()
 		m.Description.Blur()
 		m.TextArea.Blur()
+		m.Variables.Blur()
+		m.RunSettings.Blur()
 		m.selectedInput = submit
 	case close:
 		m.Title.Blur()
 		m.Description.Blur()
 		m.TextArea.Blur()
+		m.Variables.Blur()
+		m.RunSettings.Blur()
 		m.selectedInput = close
 	}
 
 	return *m, nil
 }
 
+// cycleLanguage selects the language delta positions away in the picker.
+func (m *Model) cycleLanguage(delta int) {
+	count := len(languageOptions())
+	m.language = (m.language + delta + count) % count
+}
+
+// setMultiStep switches the text area between a single command and steps.
+func (m *Model) setMultiStep(multiStep bool) {
+	m.multiStep = multiStep
+	if multiStep {
+		m.TextArea.Placeholder = m.placeholders.steps
+	} else {
+		m.TextArea.Placeholder = m.placeholders.command
+	}
+}
+
+func (m Model) selectedLanguage() string {
+	return languageOptions()[m.language]
+}
+
+// parseVariables reads the declared variables and validates them, so that
+// the workflow is not created with variables that can't be saved.
+func parseVariables(text string) ([]models.Variable, error) {
+	variables, err := models.ParseVariables(text)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := di.GetService[*services.ValidationService](di.ValidationServiceKey)
+	for _, variable := range variables {
+		if err := validation.Validate(variable); err != nil {
+			return nil, fmt.Errorf("%s: %s", variable.Name, strings.Join(validation.GetValidationErrors(err), ", "))
+		}
+	}
+	return variables, nil
+}
+
+// parseRunSettings reads the run settings and validates them.
+func parseRunSettings(text string) (models.RunSettings, error) {
+	settings, err := models.ParseRunSettings(text)
+	if err != nil {
+		return models.RunSettings{}, err
+	}
+
+	validation := di.GetService[*services.ValidationService](di.ValidationServiceKey)
+	if err := validation.Validate(settings); err != nil {
+		return models.RunSettings{}, errors.New(strings.Join(validation.GetValidationErrors(err), ", "))
+	}
+	return settings, nil
+}
+
 func (m Model) isFormValid() bool {
 	return m.Title.Value() != "" && m.Description.Value() != "" && m.TextArea.Value() != ""
 }
@@ -9,27 +9,17 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared/di"
 	"github.com/evertonstz/go-workflows/shared/di/services"
 )
 
-var (
-	focusedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	blurredStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-
-	focusedTextAreaStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("205"))
-	blurredTextAreaStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240"))
-
-	focusedButton = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	blurredButton = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-
-	mainStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240"))
-)
-
 type (
 	inputs uint
 
 	Styles struct {
+		main               lipgloss.Style
 		focusedInput       lipgloss.Style
 		blurredInput       lipgloss.Style
 		focusedTextArea    lipgloss.Style
@@ -41,16 +31,40 @@ type (
 	}
 
 	Notifications struct {
-		fillAllFields string
+		fillAllFields      string
+		invalidSteps       func(err error) string
+		invalidVariants    func(err error) string
+		invalidVariables   func(err error) string
+		invalidRunSettings func(err error) string
+	}
+
+	Labels struct {
+		language     string
+		autoLanguage string
+		kind         string
+		command      string
+		steps        string
+	}
+
+	Placeholders struct {
+		command string
+		steps   string
 	}
 
 	Model struct {
-		Title         textinput.Model
-		Description   textinput.Model
-		TextArea      textarea.Model
+		Title       textinput.Model
+		Description textinput.Model
+		TextArea    textarea.Model
+		Variables   textarea.Model
+		RunSettings textarea.Model
+		// language indexes languageOptions.
+		language      int
+		multiStep     bool
 		selectedInput inputs
 		styles        Styles
 		notifications Notifications
+		labels        Labels
+		placeholders  Placeholders
 		Keys          helpkeys.AddNewKeyMap
 	}
 )
@@ -59,10 +73,28 @@ const (
 	close inputs = iota
 	title
 	description
+	kindPicker
 	textArea
+	variablesInput
+	runSettingsInput
+	languagePicker
 	submit
 )
 
+// variablesHeight is the number of lines of the variables and run settings
+// inputs.
+const variablesHeight = 3
+
+// languageOptions are the languages offered by the picker. The empty one
+// detects the language from the command.
+func languageOptions() []string {
+	options := []string{""}
+	for _, language := range models.Languages {
+		options = append(options, language.ID)
+	}
+	return options
+}
+
 func New() Model {
 	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
 
@@ -75,26 +107,63 @@ func New() Model {
 	textareaModel.Placeholder = i18n.Translate("command_placeholder")
 	textareaModel.Prompt = ""
 	textareaModel.ShowLineNumbers = false
-
-	focusedSaveButton := focusedButton.Render(fmt.Sprintf("[ %s ]", i18n.Translate("save_button_label")))
-	blurredSaveButton := blurredButton.Render(fmt.Sprintf("[ %s ]", i18n.Translate("save_button_label")))
-	focusedCloseButton := focusedButton.Render(fmt.Sprintf("[ %s ]", i18n.Translate("cancel_button_label")))
-	blurredCloseButton := blurredButton.Render(fmt.Sprintf("[ %s ]", i18n.Translate("cancel_button_label")))
+	variablesModel := textarea.New()
+	variablesModel.Placeholder = i18n.Translate("variables_placeholder")
+	variablesModel.Prompt = ""
+	variablesModel.ShowLineNumbers = false
+	variablesModel.SetHeight(variablesHeight)
+	runSettingsModel := textarea.New()
+	runSettingsModel.Placeholder = i18n.Translate("run_settings_placeholder")
+	runSettingsModel.Prompt = ""
+	runSettingsModel.ShowLineNumbers = false
+	runSettingsModel.SetHeight(variablesHeight)
+
+	styles := theme.Current()
+	focusedSaveButton := styles.Focused.Render(fmt.Sprintf("[ %s ]", i18n.Translate("save_button_label")))
+	blurredSaveButton := styles.Blurred.Render(fmt.Sprintf("[ %s ]", i18n.Translate("save_button_label")))
+	focusedCloseButton := styles.Focused.Render(fmt.Sprintf("[ %s ]", i18n.Translate("cancel_button_label")))
+	blurredCloseButton := styles.Blurred.Render(fmt.Sprintf("[ %s ]", i18n.Translate("cancel_button_label")))
 
 	return Model{
 		Title:         titleModel,
 		Description:   descModel,
 		TextArea:      textareaModel,
+		Variables:     variablesModel,
+		RunSettings:   runSettingsModel,
 		selectedInput: title,
 		Keys:          helpkeys.NewAddNewKeys(i18n),
 		notifications: Notifications{
 			fillAllFields: i18n.Translate("error_fill_all_fields"),
+			invalidVariants: func(err error) string {
+				return i18n.TranslateWithData("error_invalid_variants", map[string]interface{}{"Error": err.Error()})
+			},
+			invalidSteps: func(err error) string {
+				return i18n.TranslateWithData("error_invalid_steps", map[string]interface{}{"Error": err.Error()})
+			},
+			invalidVariables: func(err error) string {
+				return i18n.TranslateWithData("error_invalid_variables", map[string]interface{}{"Error": err.Error()})
+			},
+			invalidRunSettings: func(err error) string {
+				return i18n.TranslateWithData("error_invalid_run_settings", map[string]interface{}{"Error": err.Error()})
+			},
+		},
+		labels: Labels{
+			language:     i18n.Translate("language_picker_label"),
+			autoLanguage: i18n.Translate("language_auto"),
+			kind:         i18n.Translate("kind_picker_label"),
+			command:      i18n.Translate("kind_command"),
+			steps:        i18n.Translate("kind_steps"),
+		},
+		placeholders: Placeholders{
+			command: i18n.Translate("command_placeholder"),
+			steps:   i18n.Translate("steps_placeholder"),
 		},
 		styles: Styles{
-			focusedInput:       focusedStyle,
-			blurredInput:       blurredStyle,
-			focusedTextArea:    focusedTextAreaStyle,
-			blurredTextArea:    blurredTextAreaStyle,
+			main:               styles.BlurredBorder,
+			focusedInput:       styles.Focused,
+			blurredInput:       styles.Blurred,
+			focusedTextArea:    styles.FocusedBorder,
+			blurredTextArea:    styles.BlurredBorder,
 			focusedButton:      focusedSaveButton,
 			blurredButton:      blurredSaveButton,
 			blurredCloseButton: blurredCloseButton,
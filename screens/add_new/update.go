@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/evertonstz/go-workflows/components/notification"
+	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared"
 )
 
@@ -20,8 +21,16 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			case title:
 				return m.focusInput(description)
 			case description:
+				return m.focusInput(kindPicker)
+			case kindPicker:
 				return m.focusInput(textArea)
 			case textArea:
+				return m.focusInput(variablesInput)
+			case variablesInput:
+				return m.focusInput(runSettingsInput)
+			case runSettingsInput:
+				return m.focusInput(languagePicker)
+			case languagePicker:
 				return m.focusInput(submit)
 			case submit, close:
 				return m, nil
@@ -32,17 +41,39 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				return m, nil
 			case description:
 				return m.focusInput(title)
-			case textArea:
+			case kindPicker:
 				return m.focusInput(description)
-			case submit, close:
+			case textArea:
+				return m.focusInput(kindPicker)
+			case variablesInput:
 				return m.focusInput(textArea)
+			case runSettingsInput:
+				return m.focusInput(variablesInput)
+			case languagePicker:
+				return m.focusInput(runSettingsInput)
+			case submit, close:
+				return m.focusInput(languagePicker)
 			}
 		case key.Matches(msg, m.Keys.Right):
-			if m.selectedInput == submit {
+			switch m.selectedInput {
+			case kindPicker:
+				m.setMultiStep(!m.multiStep)
+				return m, nil
+			case languagePicker:
+				m.cycleLanguage(1)
+				return m, nil
+			case submit:
 				return m.focusInput(close)
 			}
 		case key.Matches(msg, m.Keys.Left):
-			if m.selectedInput == close {
+			switch m.selectedInput {
+			case kindPicker:
+				m.setMultiStep(!m.multiStep)
+				return m, nil
+			case languagePicker:
+				m.cycleLanguage(-1)
+				return m, nil
+			case close:
 				return m.focusInput(submit)
 			}
 		case key.Matches(msg, m.Keys.Close):
@@ -52,13 +83,40 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			switch m.selectedInput {
 			case submit:
 				if m.isFormValid() {
-					var title, description, command string
+					var title, description, command, language string
 					title = m.Title.Value()
 					description = m.Description.Value()
 					command = m.TextArea.Value()
+					language = m.selectedLanguage()
+
+					variables, err := parseVariables(m.Variables.Value())
+					if err != nil {
+						return m, notification.ShowNotificationCmd(m.notifications.invalidVariables(err))
+					}
+					run, err := parseRunSettings(m.RunSettings.Value())
+					if err != nil {
+						return m, notification.ShowNotificationCmd(m.notifications.invalidRunSettings(err))
+					}
+
+					if m.multiStep {
+						steps, err := models.ParseSteps(command)
+						if err != nil {
+							return m, notification.ShowNotificationCmd(m.notifications.invalidSteps(err))
+						}
+						m.ResetForm()
+						return m, shared.AddNewMultiStepItemCmd(title, description, language, steps, variables, run)
+					}
+
+					defaultCommand, variants, err := models.ParseVariants(command)
+					if err != nil {
+						return m, notification.ShowNotificationCmd(m.notifications.invalidVariants(err))
+					}
+					if len(variants) > 0 {
+						command = defaultCommand
+					}
 
 					m.ResetForm()
-					return m, shared.AddNewItemCmd(title, description, command)
+					return m, shared.AddNewItemCmd(title, description, command, language, variables, variants, run)
 				}
 				return m, notification.ShowNotificationCmd(m.notifications.fillAllFields)
 			case close:
@@ -68,13 +126,21 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 	}
 	textModel, textCmd := m.TextArea.Update(msg)
+	variablesModel, variablesCmd := m.Variables.Update(msg)
+	runSettingsModel, runSettingsCmd := m.RunSettings.Update(msg)
 	return Model{
 		Title:         titleModel,
 		Description:   descModel,
 		TextArea:      textModel,
+		Variables:     variablesModel,
+		RunSettings:   runSettingsModel,
+		language:      m.language,
+		multiStep:     m.multiStep,
 		selectedInput: m.selectedInput,
 		styles:        m.styles,
 		notifications: m.notifications,
+		labels:        m.labels,
+		placeholders:  m.placeholders,
 		Keys:          m.Keys,
-	}, tea.Batch(titleCmd, descCmd, textCmd)
+	}, tea.Batch(titleCmd, descCmd, textCmd, variablesCmd, runSettingsCmd)
 }
@@ -1,45 +1,105 @@
 package addnew
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/evertonstz/go-workflows/models"
 )
 
 func (m Model) View() string {
 	switch m.selectedInput {
 	case title:
-		return mainStyle.Render(lipgloss.JoinVertical(lipgloss.Top,
+		return m.styles.main.Render(lipgloss.JoinVertical(lipgloss.Top,
 			m.styles.focusedInput.Render(m.Title.View()),
 			m.styles.blurredInput.Render(m.Description.View()),
+			m.kindView(),
 			m.styles.blurredTextArea.Render(m.TextArea.View()),
+			m.styles.blurredTextArea.Render(m.Variables.View()),
+			m.styles.blurredTextArea.Render(m.RunSettings.View()),
+			m.languageView(),
 			lipgloss.NewStyle().Align(lipgloss.Right).Width(m.Title.Width).Render(
 				lipgloss.JoinHorizontal(lipgloss.Top, m.styles.blurredButton, m.styles.blurredCloseButton))))
 	case description:
-		return mainStyle.Render(lipgloss.JoinVertical(lipgloss.Top,
+		return m.styles.main.Render(lipgloss.JoinVertical(lipgloss.Top,
 			m.styles.blurredInput.Render(m.Title.View()),
 			m.styles.focusedInput.Render(m.Description.View()),
+			m.kindView(),
+			m.styles.blurredTextArea.Render(m.TextArea.View()),
+			m.styles.blurredTextArea.Render(m.Variables.View()),
+			m.styles.blurredTextArea.Render(m.RunSettings.View()),
+			m.languageView(),
+			lipgloss.NewStyle().Align(lipgloss.Right).Width(m.Title.Width).Render(
+				lipgloss.JoinHorizontal(lipgloss.Top, m.styles.blurredButton, m.styles.blurredCloseButton))))
+	case kindPicker, languagePicker:
+		return m.styles.main.Render(lipgloss.JoinVertical(lipgloss.Top,
+			m.styles.blurredInput.Render(m.Title.View()),
+			m.styles.blurredInput.Render(m.Description.View()),
+			m.kindView(),
 			m.styles.blurredTextArea.Render(m.TextArea.View()),
+			m.styles.blurredTextArea.Render(m.Variables.View()),
+			m.styles.blurredTextArea.Render(m.RunSettings.View()),
+			m.languageView(),
 			lipgloss.NewStyle().Align(lipgloss.Right).Width(m.Title.Width).Render(
 				lipgloss.JoinHorizontal(lipgloss.Top, m.styles.blurredButton, m.styles.blurredCloseButton))))
 	case submit:
-		return mainStyle.Render(lipgloss.JoinVertical(lipgloss.Top,
+		return m.styles.main.Render(lipgloss.JoinVertical(lipgloss.Top,
 			m.styles.blurredInput.Render(m.Title.View()),
 			m.styles.blurredInput.Render(m.Description.View()),
+			m.kindView(),
 			m.styles.blurredTextArea.Render(m.TextArea.View()),
+			m.styles.blurredTextArea.Render(m.Variables.View()),
+			m.styles.blurredTextArea.Render(m.RunSettings.View()),
+			m.languageView(),
 			lipgloss.NewStyle().Align(lipgloss.Right).Width(m.Title.Width).Render(
 				lipgloss.JoinHorizontal(lipgloss.Top, m.styles.focusedButton, m.styles.blurredCloseButton))))
 	case close:
-		return mainStyle.Render(lipgloss.JoinVertical(lipgloss.Top,
+		return m.styles.main.Render(lipgloss.JoinVertical(lipgloss.Top,
 			m.styles.blurredInput.Render(m.Title.View()),
 			m.styles.blurredInput.Render(m.Description.View()),
+			m.kindView(),
 			m.styles.blurredTextArea.Render(m.TextArea.View()),
+			m.styles.blurredTextArea.Render(m.Variables.View()),
+			m.styles.blurredTextArea.Render(m.RunSettings.View()),
+			m.languageView(),
 			lipgloss.NewStyle().Align(lipgloss.Right).Width(m.Title.Width).Render(
 				lipgloss.JoinHorizontal(lipgloss.Top, m.styles.blurredButton, m.styles.focusedCloseButton))))
 	default:
-		return mainStyle.Render(lipgloss.JoinVertical(lipgloss.Top,
+		return m.styles.main.Render(lipgloss.JoinVertical(lipgloss.Top,
 			m.styles.blurredInput.Render(m.Title.View()),
 			m.styles.blurredInput.Render(m.Description.View()),
+			m.kindView(),
 			m.styles.blurredTextArea.Render(m.TextArea.View()),
+			m.styles.blurredTextArea.Render(m.Variables.View()),
+			m.styles.blurredTextArea.Render(m.RunSettings.View()),
+			m.languageView(),
 			lipgloss.NewStyle().Align(lipgloss.Right).Width(m.Title.Width).Render(
 				lipgloss.JoinHorizontal(lipgloss.Top, m.styles.blurredButton, m.styles.blurredCloseButton))))
 	}
 }
+
+// languageView shows the language picker, with arrows when it is focused.
+func (m Model) languageView() string {
+	name := m.labels.autoLanguage
+	if language := m.selectedLanguage(); language != "" {
+		name = models.LanguageByID(language).Name
+	}
+	if m.selectedInput == languagePicker {
+		return m.styles.focusedInput.Render(fmt.Sprintf("%s ‹ %s ›", m.labels.language, name))
+	}
+	return m.styles.blurredInput.Render(fmt.Sprintf("%s   %s", m.labels.language, name))
+}
+
+// kindView shows whether the workflow runs a command or steps, with arrows
+// when it is focused.
+func (m Model) kindView() string {
+	name := m.labels.command
+	if m.multiStep {
+		name = m.labels.steps
+	}
+	if m.selectedInput == kindPicker {
+		return m.styles.focusedInput.Render(fmt.Sprintf("%s ‹ %s ›", m.labels.kind, name))
+	}
+	return m.styles.blurredInput.Render(fmt.Sprintf("%s   %s", m.labels.kind, name))
+}
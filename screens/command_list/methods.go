@@ -1,18 +1,90 @@
 package commandlist
 
 import (
+	"fmt"
 	"math"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
 	tea "github.com/charmbracelet/bubbletea"
 
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
 	"github.com/evertonstz/go-workflows/components/list"
+	"github.com/evertonstz/go-workflows/components/notification"
+	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+	"github.com/evertonstz/go-workflows/shared/runner"
 )
 
+const maxDeletePreviewEntries = 8
+
 func (m Model) GetAllItems() []list.ListItemInterface {
 	return m.navigableList.AllItems()
 }
 
+func (m Model) CurrentItem() list.ListItemInterface {
+	return m.navigableList.CurrentItem()
+}
+
+// IsCapturingInput reports whether the right panel is showing an interactive
+// component, or the list filter is being typed, which should receive every
+// key press.
+func (m Model) IsCapturingInput() bool {
+	return m.currentRightPanel != textArea || m.folderTree.Focused() || m.navigableList.IsFiltering()
+}
+
+func (m Model) HelpKeys() help.KeyMap {
+	if m.folderTree.Focused() {
+		return m.folderTree.Keys
+	}
+
+	switch m.currentRightPanel {
+	case folderPicker:
+		return m.folderPicker.Keys
+	case tagFormPanel:
+		return m.tagForm.Keys
+	case variableFormPanel:
+		return m.variableForm.Keys
+	case goToPanel:
+		return m.goToPrompt.Keys
+	case commandPalettePanel:
+		return m.commandPalette.Keys
+	}
+	return m.Keys
+}
+
+func (m Model) HasSelection() bool {
+	return m.navigableList.HasSelection()
+}
+
+// isFolderTreeVisible reports whether the folder tree is shown. Small
+// widths always fall back to the two-pane layout.
+func (m Model) isFolderTreeVisible() bool {
+	return m.showFolderTree && !m.isSmallWidth
+}
+
+func (m *Model) toggleFolderTree() {
+	m.showFolderTree = !m.showFolderTree
+	if !m.showFolderTree {
+		m.folderTree.Blur()
+	}
+	m.SetSize(m.width, m.height, m.isSmallWidth)
+}
+
+// reload refreshes the folder tree and the current folder after the database
+// changed.
+func (m *Model) reload() tea.Cmd {
+	if m.databaseManager != nil {
+		m.folderTree.SetTree(m.databaseManager.GetFolderTree())
+	}
+	return m.navigableList.ReloadCurrentFolder()
+}
+
 func (m Model) GetCurrentPath() string {
 	return m.navigableList.CurrentPath()
 }
@@ -22,6 +94,18 @@ func (m Model) IsAtRoot() bool {
 }
 
 func (m *Model) setSizeForBigWidth(width, height int) {
+	if m.showFolderTree {
+		m.panelsStyle.treePanelStyle = m.panelsStyle.treePanelStyle.
+			Width(int(math.Floor(float64(width) * treePanelWidthPercentage))).
+			Height(height)
+
+		treeWidthFrameSize, treeHeightFrameSize := m.panelsStyle.treePanelStyle.GetFrameSize()
+		m.folderTree.SetSize(
+			m.panelsStyle.treePanelStyle.GetWidth()-treeWidthFrameSize,
+			m.panelsStyle.treePanelStyle.GetHeight()-treeHeightFrameSize)
+		width -= m.panelsStyle.treePanelStyle.GetWidth()
+	}
+
 	m.panelsStyle.leftPanelStyle = m.panelsStyle.leftPanelStyle.
 		Width(int(math.Floor(float64(width) * leftPanelWidthPercentage))).
 		Height(height)
@@ -39,6 +123,11 @@ func (m *Model) setSizeForBigWidth(width, height int) {
 
 	m.navigableList.SetSize(leftPanelWidth, m.panelsStyle.leftPanelStyle.GetHeight()-leftHeightFrameSize)
 	m.textArea.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.folderPicker.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.tagForm.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.variableForm.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.goToPrompt.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.commandPalette.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
 }
 
 func (m *Model) setSizeForSmallWidth(width, height int) {
@@ -59,11 +148,19 @@ func (m *Model) setSizeForSmallWidth(width, height int) {
 
 	m.navigableList.SetSize(leftPanelWidth, m.panelsStyle.leftPanelStyle.GetHeight()-leftHeightFrameSize)
 	m.textArea.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.folderPicker.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.tagForm.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.variableForm.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.goToPrompt.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
+	m.commandPalette.SetSize(rightPanelWidth, m.panelsStyle.rightPanelStyle.GetHeight()-rightHeightFrameSize)
 }
 
 func (m *Model) SetSize(width, height int, smallWidth bool) {
+	m.width = width
+	m.height = height
 	m.isSmallWidth = smallWidth
 	if m.isSmallWidth {
+		m.folderTree.Blur()
 		m.setSizeForSmallWidth(width, height)
 	} else {
 		m.setSizeForBigWidth(width, height)
@@ -77,9 +174,314 @@ func (m *Model) showDeleteModal() {
 	m.currentRightPanel = modal
 }
 
+func (m *Model) showDeleteFolderModal(folder models.FolderV2) {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+
+	message := i18n.Translate("confirm_delete_folder_message")
+	if m.databaseManager != nil {
+		folders, items := m.databaseManager.GetDatabase().GetDescendants(folder.Path)
+		if len(folders) > 0 || len(items) > 0 {
+			message = i18n.TranslateWithData("confirm_delete_folder_contents_message", map[string]interface{}{
+				"Name":    folder.Name,
+				"Folders": len(folders),
+				"Items":   len(items),
+			}) + "\n" + deletePreview(folders, items)
+		}
+	}
+
+	m.confirmationModal = m.confirmationModalBuilder(
+		message,
+		tea.Batch(shared.DeleteFolderCmd(folder.Path), shared.CloseConfirmationModalCmd()),
+		shared.CloseConfirmationModalCmd())
+	m.currentRightPanel = modal
+}
+
+func deletePreview(folders []models.FolderV2, items []models.ItemV2) string {
+	var entries []string
+	for _, folder := range folders {
+		entries = append(entries, "📁 "+folder.Path)
+	}
+	for _, item := range items {
+		entries = append(entries, "📄 "+item.GetFullPath())
+	}
+
+	if len(entries) > maxDeletePreviewEntries {
+		remaining := len(entries) - maxDeletePreviewEntries
+		entries = append(entries[:maxDeletePreviewEntries], fmt.Sprintf("… +%d", remaining))
+	}
+
+	return strings.Join(entries, "\n")
+}
+
+func (m *Model) showMoveItemPicker(item models.ItemV2) tea.Cmd {
+	if m.databaseManager == nil {
+		return nil
+	}
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	title := i18n.TranslateWithData("folder_picker_title", map[string]interface{}{"Title": item.Title})
+
+	m.pending = pendingEntries{itemIDs: []string{item.ID}}
+	m.currentRightPanel = folderPicker
+	return m.folderPicker.Open(m.databaseManager.GetDatabase(), title, item.FolderPath)
+}
+
+func (m *Model) showMoveSelectionPicker() tea.Cmd {
+	if m.databaseManager == nil {
+		return nil
+	}
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	title := i18n.TranslateWithData("folder_picker_title_selection", map[string]interface{}{
+		"Count": m.navigableList.SelectionCount(),
+	})
+
+	itemIDs, folderPaths := m.navigableList.SelectedEntries()
+	m.pending = pendingEntries{itemIDs: itemIDs, folderPaths: folderPaths}
+	m.currentRightPanel = folderPicker
+	return m.folderPicker.Open(m.databaseManager.GetDatabase(), title, m.navigableList.TargetPath())
+}
+
+// pickDestination turns the folder picked for the pending entries into a
+// move, asking for confirmation first when several entries are involved.
+func (m *Model) pickDestination(path, newFolderName string) tea.Cmd {
+	pending := m.pending
+	m.pending = pendingEntries{}
+	m.currentRightPanel = textArea
+
+	count := len(pending.itemIDs) + len(pending.folderPaths)
+	if count == 0 {
+		return nil
+	}
+
+	moveCmd := shared.MoveEntriesCmd(pending.itemIDs, pending.folderPaths, path, newFolderName)
+	if count == 1 {
+		return moveCmd
+	}
+
+	destination := path
+	if newFolderName != "" {
+		destination = strings.TrimSuffix(path, "/") + "/" + newFolderName
+	}
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	m.confirmationModal = m.confirmationModalBuilder(
+		i18n.TranslateWithData("confirm_move_entries_message", map[string]interface{}{
+			"Count": count,
+			"Path":  destination,
+		}),
+		tea.Batch(moveCmd, shared.CloseConfirmationModalCmd()),
+		shared.CloseConfirmationModalCmd())
+	m.currentRightPanel = modal
+	return nil
+}
+
+// moveEntries moves the entries of msg into its destination, or into a new
+// folder named msg.NewFolderName inside it when it is set.
+func (m *Model) moveEntries(msg shared.DidMoveEntriesMsg) error {
+	destination := msg.Destination
+	if msg.NewFolderName != "" {
+		folder, err := m.databaseManager.PasteEntriesIntoNewFolder(msg.ItemIDs, msg.FolderPaths, destination, msg.NewFolderName, true)
+		if err != nil {
+			return err
+		}
+		return m.moveFolderViews(msg.FolderPaths, folder.Path)
+	}
+
+	if err := m.databaseManager.PasteEntries(msg.ItemIDs, msg.FolderPaths, destination, true); err != nil {
+		return err
+	}
+	return m.moveFolderViews(msg.FolderPaths, destination)
+}
+
+// renameFolderViews keeps the remembered sort modes of a folder and its
+// descendants after the folder got a new path.
+func (m *Model) renameFolderViews(oldPath, newPath string) error {
+	if m.views == nil || oldPath == newPath {
+		return nil
+	}
+	return m.views.Rename(oldPath, newPath)
+}
+
+func (m *Model) moveFolderViews(folderPaths []string, destination string) error {
+	for _, path := range folderPaths {
+		newPath := strings.TrimSuffix(destination, "/") + path[strings.LastIndex(path, "/"):]
+		if err := m.renameFolderViews(path, newPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// switchToManualOrder makes a reordered folder list in manual order, so the
+// move the user just made is visible.
+func (m *Model) switchToManualOrder(path string) tea.Cmd {
+	if m.views == nil {
+		return nil
+	}
+
+	view := m.views.Get(path)
+	if view.SortMode == models.SortManual {
+		return nil
+	}
+
+	view.SortMode = models.SortManual
+	if err := m.views.Set(path, view); err != nil {
+		return shared.ErrorCmd(err)
+	}
+	return notification.ShowNotificationCmd(describeView(view))
+}
+
+func describeView(view models.FolderView) string {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	data := map[string]interface{}{"Mode": i18n.Translate("sort_mode_" + string(view.SortMode))}
+	if view.FoldersFirst {
+		return i18n.TranslateWithData("notification_sorted_by", data)
+	}
+	return i18n.TranslateWithData("notification_sorted_by_mixed", data)
+}
+
+func (m *Model) showDeleteSelectionModal() {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	itemIDs, folderPaths := m.navigableList.SelectedEntries()
+
+	message := i18n.TranslateWithData("confirm_delete_entries_message", map[string]interface{}{
+		"Count": len(itemIDs) + len(folderPaths),
+	})
+	if m.databaseManager != nil {
+		database := m.databaseManager.GetDatabase()
+		var folders []models.FolderV2
+		var items []models.ItemV2
+		for _, path := range folderPaths {
+			if folder, found := database.GetFolderByPath(path); found {
+				descendantFolders, descendantItems := database.GetDescendants(path)
+				folders = append(append(folders, *folder), descendantFolders...)
+				items = append(items, descendantItems...)
+			}
+		}
+		for _, id := range itemIDs {
+			if item, found := database.GetItemByID(id); found {
+				items = append(items, *item)
+			}
+		}
+		message += "\n" + deletePreview(folders, items)
+	}
+
+	m.confirmationModal = m.confirmationModalBuilder(
+		message,
+		tea.Batch(shared.DeleteEntriesCmd(itemIDs, folderPaths), shared.CloseConfirmationModalCmd()),
+		shared.CloseConfirmationModalCmd())
+	m.currentRightPanel = modal
+}
+
+// showTagForm opens the tag form for the selected workflows, or for the
+// current workflow when nothing is selected.
+func (m *Model) showTagForm() tea.Cmd {
+	var itemIDs []string
+	if m.navigableList.HasSelection() {
+		for _, item := range m.navigableList.SelectedItems() {
+			itemIDs = append(itemIDs, item.ID)
+		}
+	} else if currentItem := m.navigableList.CurrentItem(); currentItem != nil && !currentItem.IsFolder() {
+		itemIDs = []string{currentItem.(list.WorkflowItem).GetItem().ID}
+	}
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	m.pending = pendingEntries{itemIDs: itemIDs}
+	m.currentRightPanel = tagFormPanel
+	return m.tagForm.Open(i18n.TranslateWithData("tag_form_title", map[string]interface{}{"Count": len(itemIDs)}))
+}
+
+// actionContext describes the entry under the cursor for the command palette.
+func (m Model) actionContext() helpkeys.ActionContext {
+	ctx := helpkeys.ActionContext{
+		HasSelection:    m.navigableList.HasSelection(),
+		InVirtualFolder: m.navigableList.IsVirtualFolder(),
+		AtRoot:          m.navigableList.IsAtRoot(),
+		SidebarVisible:  m.isFolderTreeVisible(),
+	}
+
+	switch currentItem := m.navigableList.CurrentItem().(type) {
+	case list.FolderItem:
+		ctx.OnFolder = !currentItem.IsVirtual()
+	case list.WorkflowItem:
+		ctx.OnWorkflow = true
+		ctx.OnMultiStep = currentItem.GetItem().IsMultiStep()
+		ctx.HasVariants = len(currentItem.GetItem().Variants) > 0
+	}
+	return ctx
+}
+
+func (m *Model) showCommandPalette() tea.Cmd {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	m.currentRightPanel = commandPalettePanel
+	return m.commandPalette.Open(i18n.Translate("command_palette_title"), m.Keys.PaletteActions(m.actionContext()))
+}
+
+// showGoToPrompt opens the prompt that jumps to any folder, suggesting the
+// breadcrumbs of the current folder until a path is typed.
+func (m *Model) showGoToPrompt() tea.Cmd {
+	if m.databaseManager == nil {
+		return nil
+	}
+
+	paths := []string{"/"}
+	for _, folder := range m.databaseManager.GetDatabase().Folders {
+		paths = append(paths, folder.Path)
+	}
+	sort.Strings(paths)
+
+	var breadcrumbs []string
+	for _, crumb := range list.Breadcrumbs(m.navigableList.CurrentPath(), "/") {
+		breadcrumbs = append(breadcrumbs, crumb.Path)
+	}
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	m.currentRightPanel = goToPanel
+	return m.goToPrompt.Open(i18n.Translate("go_to_title"), paths, breadcrumbs)
+}
+
+func (m *Model) confirmTagUpdate(tag string, remove bool) {
+	itemIDs := m.pending.itemIDs
+	m.pending = pendingEntries{}
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	data := map[string]interface{}{"Tag": tag, "Count": len(itemIDs)}
+
+	var message string
+	var updateCmd tea.Cmd
+	if remove {
+		message = i18n.TranslateWithData("confirm_remove_tag_message", data)
+		updateCmd = shared.UpdateTagsCmd(itemIDs, nil, []string{tag})
+	} else {
+		message = i18n.TranslateWithData("confirm_add_tag_message", data)
+		updateCmd = shared.UpdateTagsCmd(itemIDs, []string{tag}, nil)
+	}
+
+	m.confirmationModal = m.confirmationModalBuilder(
+		message,
+		tea.Batch(updateCmd, shared.CloseConfirmationModalCmd()),
+		shared.CloseConfirmationModalCmd())
+	m.currentRightPanel = modal
+}
+
 func (m *Model) InitializeDatabase() {
 	if m.databaseManager != nil {
+		m.navigableList.SetUsage(m.usage)
+		m.navigableList.SetViewState(m.views)
+		m.navigableList.SetRisk(m.risk)
+		m.navigableList.SetPlatform(models.CurrentPlatform(m.shell))
 		m.navigableList.SetDatabase(m.databaseManager)
+		if m.defaultFolder != "/" {
+			if _, err := m.databaseManager.GetFolder(m.defaultFolder); err == nil {
+				m.navigableList.NavigateToFolder(m.defaultFolder)
+			}
+		}
+		m.folderTree.SetTree(m.databaseManager.GetFolderTree())
+		m.folderTree.SetCurrentPath(m.navigableList.CurrentPath())
 		m.loadInitialContent()
 	}
 }
@@ -93,25 +495,201 @@ func (m *Model) loadInitialContent() {
 	if currentItem.IsFolder() {
 		folder := currentItem.(list.FolderItem).GetFolder()
 		m.textArea.SetCurrentFolder(folder)
+		m.textArea.TextArea.SetValue(m.folderPreview(folder))
+	} else {
+		workflowItem := currentItem.(list.WorkflowItem).GetItem()
+		described, _ := m.databaseManager.DescribeIncludes(workflowItem)
+		m.textArea.TextArea.SetValue(described.ForPlatform(m.platformOf(workflowItem)).Script())
+	}
+}
+
+// folderPreview lists the contents of folder for the right panel. Virtual
+// folders list the workflows they resolve to.
+func (m Model) folderPreview(folder models.FolderV2) string {
+	if m.databaseManager == nil {
+		return ""
+	}
+
+	var subfolders []models.FolderV2
+	var items []models.ItemV2
+	if list.IsVirtualPath(folder.Path) {
+		items = m.navigableList.VirtualItems(folder.Path)
+	} else {
+		var err error
+		subfolders, items, err = m.databaseManager.GetFolderContents(folder.Path)
+		if err != nil {
+			return "Error loading folder contents: " + err.Error()
+		}
+	}
+
+	content := "📁 " + folder.Name + "\n" + folder.Description + "\n\n"
+	content += "Contents:\n"
+	for _, subfolder := range subfolders {
+		content += "📁 " + subfolder.Name + " - " + subfolder.Description + "\n"
+	}
+	for _, item := range items {
+		content += "📄 " + item.Title + " - " + item.Desc + "\n"
+	}
+	return content
+}
+
+// runWorkflow suspends the program to run the workflow with the given ID in
+// the terminal, once the values of its variables are given, offering values
+// when set. Resume runs a multi-step workflow from the step its last run
+// stopped at.
+func (m Model) runWorkflow(id string, resume bool, values map[string]string) tea.Cmd {
+	if m.databaseManager == nil {
+		return nil
+	}
+	item, err := m.databaseManager.GetItem(id)
+	if err != nil {
+		return shared.ErrorCmd(err)
+	}
+
+	if resume {
+		if _, ok := models.ResumeStep(m.stepRuns[id]); !ok {
+			i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+			return notification.ShowNotificationCmd(i18n.Translate("notification_nothing_to_resume"))
+		}
+	}
 
-		if m.databaseManager != nil {
-			subfolders, items, err := m.databaseManager.GetFolderContents(folder.Path)
-			if err != nil {
-				m.textArea.TextArea.SetValue("Error loading folder contents: " + err.Error())
-			} else {
-				content := "📁 " + folder.Name + "\n" + folder.Description + "\n\n"
-				content += "Contents:\n"
-				for _, subfolder := range subfolders {
-					content += "📁 " + subfolder.Name + " - " + subfolder.Description + "\n"
-				}
-				for _, item := range items {
-					content += "📄 " + item.Title + " - " + item.Desc + "\n"
-				}
-				m.textArea.TextArea.SetValue(content)
+	// The language depends on the item alone, not on the interpreter its
+	// folders give it.
+	workflow := item.Clone()
+	workflow.Language = item.GetLanguage()
+	workflow.RunSettings = m.databaseManager.RunSettingsOf(*item)
+	workflow, err = m.databaseManager.ExpandIncludes(workflow, m.platformOf(workflow))
+	if err != nil {
+		i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+		return notification.ShowNotificationCmd(i18n.TranslateWithData("error_include", map[string]interface{}{"Error": err.Error()}))
+	}
+	return shared.WithValuesCmd(workflow, values, func(values map[string]string) tea.Cmd {
+		return m.execWorkflow(workflow.WithValues(values), values, resume)
+	})
+}
+
+// platformOf returns where item runs: this system, in the shell its
+// interpreter gives it or the configured one.
+func (m Model) platformOf(item models.ItemV2) models.Platform {
+	return models.CurrentPlatform(m.shell).WithInterpreter(m.databaseManager.RunSettingsOf(item).Interpreter)
+}
+
+// execWorkflow runs item, whose variables have been replaced by values, with
+// the run settings it inherited, and records the run for the history.
+func (m Model) execWorkflow(item models.ItemV2, values map[string]string, resume bool) tea.Cmd {
+	id := item.ID
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	language := models.LanguageByID(item.GetLanguage())
+	unsupported := notification.ShowNotificationCmd(i18n.TranslateWithData("notification_run_unsupported", map[string]interface{}{
+		"Language": language.Name,
+	}))
+
+	dir := item.RunSettings.Dir()
+	if dir != "" {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return notification.ShowNotificationCmd(i18n.TranslateWithData("notification_working_dir_missing", map[string]interface{}{
+				"Dir": dir,
+			}))
+		}
+	}
+	var env []string
+	if len(item.Env) > 0 {
+		env = item.RunSettings.Environ(os.Environ())
+	}
+
+	var run tea.Cmd
+	if item.IsMultiStep() {
+		steps := make([]runner.Step, len(item.Steps))
+		for i, step := range item.Steps {
+			args, ok := item.RunSettings.RunArgs(language, step.Command, m.shell)
+			if !ok {
+				return unsupported
 			}
+			steps[i] = runner.Step{Title: step.Title, Args: args, Dir: dir, Env: env, ContinueOnError: step.ContinueOnError}
 		}
+
+		var previous []models.StepStatus
+		if resume {
+			previous = m.stepRuns[id]
+		}
+		sequence := runner.NewSequence(steps, previous)
+		recorder := runner.NewRecorder(sequence, m.historyOutputLines)
+		run = tea.Exec(recorder, func(err error) tea.Msg {
+			return shared.RanWorkflowMsg{ItemID: id, Err: err, Steps: sequence.Statuses, Record: historyRecord(item, values, dir, recorder, err)}
+		})
 	} else {
-		workflowItem := currentItem.(list.WorkflowItem).GetItem()
-		m.textArea.TextArea.SetValue(workflowItem.Command)
+		args, ok := item.RunSettings.RunArgs(language, item.Command, m.shell)
+		if !ok {
+			return unsupported
+		}
+		command := exec.Command(args[0], args[1:]...)
+		command.Dir = dir
+		command.Env = env
+		recorder := runner.NewRecorder(runner.Process{Cmd: command}, m.historyOutputLines)
+		run = tea.Exec(recorder, func(err error) tea.Msg {
+			return shared.RanWorkflowMsg{ItemID: id, Err: err, Record: historyRecord(item, values, dir, recorder, err)}
+		})
+	}
+
+	if m.risk != nil {
+		if risk := m.risk.Analyze(item.Script()); risk.IsHigh() {
+			return shared.ConfirmRiskCmd(risk, run)
+		}
+	}
+	return run
+}
+
+// historyRecord describes the run of item that recorder timed. Item has its
+// variables replaced by values and runs in dir, the current directory when
+// empty.
+func historyRecord(item models.ItemV2, values map[string]string, dir string, recorder *runner.Recorder, err error) models.HistoryRecord {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	return models.HistoryRecord{
+		ItemID:     item.ID,
+		Title:      item.Title,
+		Command:    item.Script(),
+		Variables:  values,
+		Cwd:        dir,
+		StartedAt:  recorder.StartedAt,
+		DurationMS: recorder.Duration.Milliseconds(),
+		ExitCode:   runner.ExitCode(err),
+		Output:     recorder.Output(),
+	}
+}
+
+// showVariableForm asks for the values of the variables of item, offering
+// offered or else the last ones given, before then copies or runs it.
+func (m *Model) showVariableForm(item models.ItemV2, offered map[string]string, then func(values map[string]string) tea.Cmd) tea.Cmd {
+	values := map[string]string{}
+	if m.variableValues != nil {
+		values = m.variableValues.Initial(item)
+	}
+	for name := range values {
+		if value, ok := offered[name]; ok {
+			values[name] = value
+		}
 	}
+
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	m.pending = pendingEntries{itemIDs: []string{item.ID}, then: then}
+	m.currentRightPanel = variableFormPanel
+	return m.variableForm.Open(i18n.TranslateWithData("variable_form_title", map[string]interface{}{"Title": item.Title}), item.AllVariables(), values)
+}
+
+// showRiskModal asks for a confirmation before running confirm, which copies
+// or runs high risk commands.
+func (m *Model) showRiskModal(risk models.Risk, confirm tea.Cmd) {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+	message := i18n.Translate("confirm_risky_command_message")
+	for _, rule := range risk.Rules {
+		message += "\n• " + rule.Reason()
+	}
+
+	m.confirmationModal = m.confirmationModalBuilder(
+		message,
+		tea.Batch(confirm, shared.CloseConfirmationModalCmd()),
+		shared.CloseConfirmationModalCmd())
+	m.currentRightPanel = modal
 }
@@ -1,18 +1,33 @@
 package commandlist
 
 import (
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	commandpalette "github.com/evertonstz/go-workflows/components/command_palette"
 	confirmationmodal "github.com/evertonstz/go-workflows/components/confirmation_modal"
+	folderpicker "github.com/evertonstz/go-workflows/components/folder_picker"
+	foldertree "github.com/evertonstz/go-workflows/components/folder_tree"
+	gotoprompt "github.com/evertonstz/go-workflows/components/goto_prompt"
 	helpkeys "github.com/evertonstz/go-workflows/components/keys"
 	"github.com/evertonstz/go-workflows/components/list"
+	tagform "github.com/evertonstz/go-workflows/components/tag_form"
 	textarea "github.com/evertonstz/go-workflows/components/text_area"
+	variableform "github.com/evertonstz/go-workflows/components/variable_form"
+	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared/di"
 	"github.com/evertonstz/go-workflows/shared/di/services"
+	"github.com/evertonstz/go-workflows/shared/runner"
 )
 
 var (
+	treePanelWidthPercentage = 0.2
+	treePanelStyle           = lipgloss.NewStyle().
+					AlignHorizontal(lipgloss.Left).
+					PaddingTop(1).
+					PaddingRight(1)
 	leftPanelWidthPercentage = 0.5
 	leftPanelStyle           = lipgloss.NewStyle().
 					AlignHorizontal(lipgloss.Left)
@@ -25,21 +40,53 @@ var (
 
 type (
 	panelsStyle struct {
+		treePanelStyle  lipgloss.Style
 		leftPanelStyle  lipgloss.Style
 		rightPanelStyle lipgloss.Style
 	}
 
 	confirmationModalBuilder func(confirmCmd, cancelCmd tea.Cmd) confirmationmodal.Model
 
+	messageConfirmationModalBuilder func(message string, confirmCmd, cancelCmd tea.Cmd) confirmationmodal.Model
+
+	// pendingEntries are the workflows and folders waiting for the folder
+	// picker, the tag form or the variable form to finish.
+	pendingEntries struct {
+		itemIDs     []string
+		folderPaths []string
+		// then copies or runs the workflow with the values of its variables.
+		then func(values map[string]string) tea.Cmd
+	}
+
 	Model struct {
 		navigableList                  list.NavigableModel
+		folderTree                     foldertree.Model
+		showFolderTree                 bool
 		confirmationModal              confirmationmodal.Model
 		deleteConfirmationModalBuilder confirmationModalBuilder
+		confirmationModalBuilder       messageConfirmationModalBuilder
 		textArea                       textarea.Model
+		folderPicker                   folderpicker.Model
+		tagForm                        tagform.Model
+		variableForm                   variableform.Model
+		goToPrompt                     gotoprompt.Model
+		commandPalette                 commandpalette.Model
+		pending                        pendingEntries
 		panelsStyle                    panelsStyle
 		currentRightPanel              currentRightPanel
 		isSmallWidth                   bool
+		width                          int
+		defaultFolder                  string
+		shell                          string
+		historyOutputLines             int
+		height                         int
 		databaseManager                *services.DatabaseManagerV2
+		usage                          *services.UsageService
+		views                          *services.ViewStateService
+		risk                           *services.RiskService
+		variableValues                 *services.VariableValuesService
+		history                        *services.HistoryService
+		stepRuns                       map[string][]models.StepStatus
 		Keys                           helpkeys.ListKeyMap
 	}
 	currentRightPanel uint
@@ -48,6 +95,11 @@ type (
 const (
 	textArea currentRightPanel = iota
 	modal
+	folderPicker
+	tagFormPanel
+	goToPanel
+	commandPalettePanel
+	variableFormPanel
 )
 
 func (m Model) Init() tea.Cmd {
@@ -70,6 +122,33 @@ func New() Model {
 		)
 		return modal
 	}
+	confirmationModalBuilder := func(message string, confirmCmd, cancelCmd tea.Cmd) confirmationmodal.Model {
+		return confirmationmodal.NewConfirmationModal(
+			message,
+			i18n.Translate("yes"),
+			i18n.Translate("no"),
+			confirmCmd,
+			cancelCmd,
+		)
+	}
+
+	folderPickerModel := folderpicker.New(helpkeys.NewFolderPickerKeys(i18n), i18n.Translate("folder_picker_filter_placeholder"))
+	folderPickerModel.NoMatchesText = i18n.Translate("folder_picker_no_matches")
+	folderPickerModel.NewFolderText = func(parentPath string) string {
+		return i18n.TranslateWithData("folder_picker_new_folder_prompt", map[string]interface{}{"Path": parentPath})
+	}
+
+	tagFormModel := tagform.New(
+		helpkeys.NewTagFormKeys(i18n),
+		i18n.Translate("tag_form_placeholder"),
+		i18n.Translate("tag_form_add"),
+		i18n.Translate("tag_form_remove"))
+
+	goToPromptModel := gotoprompt.New(helpkeys.NewGoToPromptKeys(i18n), i18n.Translate("go_to_placeholder"))
+	goToPromptModel.NoMatchesText = i18n.Translate("folder_picker_no_matches")
+
+	commandPaletteModel := commandpalette.New(helpkeys.NewCommandPaletteKeys(i18n), i18n.Translate("command_palette_placeholder"))
+	commandPaletteModel.NoMatchesText = i18n.Translate("command_palette_no_matches")
 
 	persistence := di.GetService[*services.PersistenceService](di.PersistenceServiceKey)
 	validation := di.GetService[*services.ValidationService](di.ValidationServiceKey)
@@ -78,18 +157,56 @@ func New() Model {
 		databaseManager = nil
 	}
 
+	config := di.GetService[*services.ConfigService](di.ConfigServiceKey).Config()
+	shell := config.Shell
+	if shell == "" {
+		shell = os.Getenv("SHELL")
+	}
+
+	risk := di.GetService[*services.RiskService](di.RiskServiceKey)
+	suggestions := runner.NewSuggestions(shell, runner.SuggestionTimeout, runner.SuggestionCacheTTL)
+	variableFormModel := variableform.New(helpkeys.NewVariableFormKeys(i18n), validation, suggestions, risk)
+	variableFormModel.RequiredLabel = i18n.Translate("variable_form_required")
+	variableFormModel.NoneLabel = i18n.Translate("variable_form_none")
+	variableFormModel.LoadingLabel = i18n.Translate("variable_form_loading")
+	variableFormModel.FailedLabel = i18n.Translate("variable_form_suggestions_failed")
+	variableFormModel.NoMatchesLabel = i18n.Translate("variable_form_no_matches")
+	variableFormModel.RiskyLabel = i18n.TranslateWithData("variable_form_suggestions_risky", map[string]interface{}{"Key": variableFormModel.Keys.RefreshSuggestions.Help().Key})
+
+	usage := di.GetService[*services.UsageService](di.UsageServiceKey)
+	views := di.GetService[*services.ViewStateService](di.ViewStateServiceKey)
+	variableValues := di.GetService[*services.VariableValuesService](di.VariableValuesServiceKey)
+	history := di.GetService[*services.HistoryService](di.HistoryServiceKey)
+
 	return Model{
 		navigableList:                  navigableListModel,
+		folderTree:                     foldertree.New(helpkeys.NewFolderTreeKeys(i18n), i18n.Translate("folder_tree_root")),
 		confirmationModal:              initialModal,
 		deleteConfirmationModalBuilder: deleteConfirmationModalBuilder,
+		confirmationModalBuilder:       confirmationModalBuilder,
 		textArea:                       textAreaModel,
+		folderPicker:                   folderPickerModel,
+		tagForm:                        tagFormModel,
+		variableForm:                   variableFormModel,
+		goToPrompt:                     goToPromptModel,
+		commandPalette:                 commandPaletteModel,
 		Keys:                           helpkeys.NewListKeys(i18n),
 		panelsStyle: panelsStyle{
+			treePanelStyle:  treePanelStyle,
 			leftPanelStyle:  leftPanelStyle,
 			rightPanelStyle: rightPanelStyle,
 		},
-		currentRightPanel: textArea,
-		isSmallWidth:      false,
-		databaseManager:   databaseManager,
+		currentRightPanel:  textArea,
+		isSmallWidth:       false,
+		databaseManager:    databaseManager,
+		usage:              usage,
+		views:              views,
+		risk:               risk,
+		variableValues:     variableValues,
+		history:            history,
+		defaultFolder:      config.DefaultFolder,
+		shell:              shell,
+		historyOutputLines: config.History.OutputLines,
+		stepRuns:           map[string][]models.StepStatus{},
 	}
 }
@@ -1,14 +1,21 @@
 package commandlist
 
 import (
+	"errors"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	confirmationmodal "github.com/evertonstz/go-workflows/components/confirmation_modal"
 	helpkeys "github.com/evertonstz/go-workflows/components/keys"
 	"github.com/evertonstz/go-workflows/components/list"
+	"github.com/evertonstz/go-workflows/components/notification"
 	textarea "github.com/evertonstz/go-workflows/components/text_area"
+	"github.com/evertonstz/go-workflows/models"
 	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+	"github.com/evertonstz/go-workflows/shared/runner"
 )
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -27,65 +34,346 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if err != nil {
 					return m, shared.ErrorCmd(err)
 				}
-				m.navigableList.ReloadCurrentFolder()
+				return m, m.reload()
 			}
 		}
 		return m, nil
 	case shared.DidAddNewItemMsg:
 		if m.databaseManager != nil {
-			currentPath := m.navigableList.CurrentPath()
-			_, err := m.databaseManager.CreateItem(
-				msg.Title,
-				msg.Description,
-				msg.CommandText,
-				currentPath,
-				[]string{},          // empty tags
-				map[string]string{}, // empty metadata
-			)
+			_, err := m.databaseManager.AddItem(models.ItemV2{
+				Title:       msg.Title,
+				Desc:        msg.Description,
+				Command:     msg.CommandText,
+				Steps:       msg.Steps,
+				Language:    msg.Language,
+				Variables:   msg.Variables,
+				Variants:    msg.Variants,
+				RunSettings: msg.RunSettings,
+			}, m.navigableList.TargetPath())
 			if err != nil {
 				return m, shared.ErrorCmd(err)
 			}
 
-			m.navigableList.ReloadCurrentFolder()
+			return m, m.reload()
 		}
 		return m, nil
-	case shared.DidNavigateToFolderMsg:
+	case shared.DidAddNewFolderMsg:
+		if m.databaseManager != nil {
+			folder, err := m.databaseManager.CreateFolder(msg.Name, msg.Description, m.navigableList.TargetPath())
+			if err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+			if !msg.RunSettings.IsZero() {
+				if err := m.databaseManager.SetFolderRunSettings(folder.Path, msg.RunSettings); err != nil {
+					return m, shared.ErrorCmd(err)
+				}
+			}
+
+			return m, m.reload()
+		}
 		return m, nil
-	case shared.DidSetCurrentItemMsg:
+	case shared.DidUpdateFolderMsg:
+		if m.databaseManager != nil {
+			folder, err := m.databaseManager.UpdateFolder(msg.Path, msg.Name, msg.Description)
+			if err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+			if err := m.renameFolderViews(msg.Path, folder.Path); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+			if err := m.databaseManager.SetFolderRunSettings(folder.Path, msg.RunSettings); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.DidDeleteFolderMsg:
+		if m.databaseManager != nil {
+			if err := m.databaseManager.DeleteFolder(msg.Path, true); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.DidPickFolderMsg:
+		return m, m.pickDestination(msg.Path, msg.NewFolderName)
+	case shared.DidMoveEntriesMsg:
+		if m.databaseManager != nil {
+			if err := m.moveEntries(msg); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			m.navigableList.ClearSelection()
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.DidDeleteEntriesMsg:
+		if m.databaseManager != nil {
+			if err := m.databaseManager.DeleteEntries(msg.ItemIDs, msg.FolderPaths); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			m.navigableList.ClearSelection()
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.DidSubmitTagFormMsg:
+		m.confirmTagUpdate(msg.Tag, msg.Remove)
+		return m, nil
+	case shared.DidCloseTagFormMsg:
+		m.pending = pendingEntries{}
 		m.currentRightPanel = textArea
-	case shared.DidSetCurrentFolderMsg:
-		m.textArea.SetCurrentFolder(msg.Folder)
+		return m, nil
+	case shared.DidRequestVariablesMsg:
+		return m, m.showVariableForm(msg.Item, msg.Values, msg.Then)
+	case shared.DidSubmitVariableFormMsg:
+		pending := m.pending
+		m.pending = pendingEntries{}
+		m.currentRightPanel = textArea
+		if m.variableValues != nil && len(pending.itemIDs) > 0 {
+			if err := m.variableValues.Remember(pending.itemIDs[0], msg.Values); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+		}
+		if pending.then == nil {
+			return m, nil
+		}
+		return m, pending.then(msg.Values)
+	case shared.DidCloseVariableFormMsg:
+		m.pending = pendingEntries{}
+		m.currentRightPanel = textArea
+		return m, nil
+	case shared.DidLoadSuggestionsMsg:
+		m.variableForm, cmd = m.variableForm.Update(msg)
+		return m, cmd
+	case shared.DidUpdateTagsMsg:
+		if m.databaseManager != nil {
+			if err := m.databaseManager.UpdateTags(msg.ItemIDs, msg.AddTags, msg.RemoveTags); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
 
+			m.navigableList.ClearSelection()
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.DidDuplicateMsg:
 		if m.databaseManager != nil {
-			subfolders, items, err := m.databaseManager.GetFolderContents(msg.Folder.Path)
-			if err != nil {
-				m.textArea.TextArea.SetValue("Error loading folder contents: " + err.Error())
+			var err error
+			if msg.ItemID != "" {
+				_, err = m.databaseManager.DuplicateItem(msg.ItemID, m.navigableList.TargetPath())
 			} else {
-				content := "📁 " + msg.Folder.Name + "\n" + msg.Folder.Description + "\n\n"
-				content += "Contents:\n"
-				for _, folder := range subfolders {
-					content += "📁 " + folder.Name + " - " + folder.Description + "\n"
-				}
-				for _, item := range items {
-					content += "📄 " + item.Title + " - " + item.Desc + "\n"
+				_, err = m.databaseManager.CopyFolder(msg.FolderPath, m.navigableList.TargetPath())
+			}
+			if err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.DidPasteMsg:
+		if m.databaseManager != nil {
+			if err := m.databaseManager.PasteEntries(msg.ItemIDs, msg.FolderPaths, msg.Destination, msg.Cut); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			if msg.Cut {
+				m.navigableList.ClearClipboard()
+				if err := m.moveFolderViews(msg.FolderPaths, msg.Destination); err != nil {
+					return m, shared.ErrorCmd(err)
 				}
+			}
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.CopiedToClipboardMsg:
+		if m.usage != nil && len(msg.ItemIDs) > 0 {
+			if err := m.usage.RecordUse(msg.ItemIDs...); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			return m, m.reload()
+		}
+		return m, nil
+	case shared.DidRequestRiskConfirmationMsg:
+		m.showRiskModal(msg.Risk, msg.Confirm)
+		return m, nil
+	case shared.DidRequestRunWorkflowMsg:
+		return m, m.runWorkflow(msg.ItemID, msg.Resume, msg.Values)
+	case shared.RanWorkflowMsg:
+		i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+		if m.history != nil && msg.Record.ItemID != "" {
+			if err := m.history.Append(msg.Record); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+		}
+		if msg.Steps != nil {
+			m.stepRuns[msg.ItemID] = msg.Steps
+			if item := m.navigableList.CurrentItem(); item != nil && !item.IsFolder() && item.(list.WorkflowItem).GetItem().ID == msg.ItemID {
+				m.textArea.SetStepStatuses(msg.Steps)
+			}
+		}
+		var stepErr *runner.StepError
+		if errors.As(msg.Err, &stepErr) {
+			return m, notification.ShowNotificationCmd(i18n.TranslateWithData("notification_step_failed", map[string]interface{}{
+				"Step":  stepErr.Index + 1,
+				"Title": stepErr.Title,
+				"Key":   m.Keys.ResumeWorkflow.Help().Key,
+			}))
+		}
+		if msg.Err != nil {
+			return m, notification.ShowNotificationCmd(i18n.TranslateWithData("notification_run_failed", map[string]interface{}{
+				"Error": msg.Err.Error(),
+			}))
+		}
+		text := i18n.Translate("notification_run_succeeded")
+		if m.usage != nil {
+			if err := m.usage.RecordUse(msg.ItemID); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+			return m, tea.Batch(m.reload(), notification.ShowNotificationCmd(text))
+		}
+		return m, notification.ShowNotificationCmd(text)
+	case shared.DidToggleFavoriteMsg:
+		if m.usage != nil {
+			favorite, err := m.usage.ToggleFavorite(msg.ItemID)
+			if err != nil {
+				return m, shared.ErrorCmd(err)
+			}
 
-				m.textArea.TextArea.SetValue(content)
+			i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+			text := i18n.Translate("notification_favorite_removed")
+			if favorite {
+				text = i18n.Translate("notification_favorite_added")
 			}
+			return m, tea.Batch(m.reload(), notification.ShowNotificationCmd(text))
 		}
+		return m, nil
+	case shared.DidSetFolderViewMsg:
+		if m.views != nil {
+			if err := m.views.Set(msg.Path, msg.View); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			return m, tea.Batch(m.reload(), notification.ShowNotificationCmd(describeView(msg.View)))
+		}
+		return m, nil
+	case shared.DidReorderMsg:
+		if m.databaseManager != nil {
+			if err := m.databaseManager.Reorder(msg.Path, msg.IDs); err != nil {
+				return m, shared.ErrorCmd(err)
+			}
+
+			return m, tea.Batch(m.switchToManualOrder(msg.Path), m.reload())
+		}
+		return m, nil
+	case shared.DidCloseFolderPickerMsg:
+		m.pending = pendingEntries{}
+		m.currentRightPanel = textArea
+		return m, nil
+	case shared.DidNavigateToFolderMsg:
+		m.folderTree.SetCurrentPath(msg.Path)
+		return m, nil
+	case shared.DidOpenFolderMsg:
+		m.folderTree.Blur()
+		if m.currentRightPanel == goToPanel {
+			m.currentRightPanel = textArea
+		}
+		return m, m.navigableList.NavigateToFolder(msg.Path)
+	case shared.DidCloseGoToPromptMsg, shared.DidCloseCommandPaletteMsg:
+		m.currentRightPanel = textArea
+		return m, nil
+	case shared.DidRunActionMsg:
+		m.currentRightPanel = textArea
+		return m, func() tea.Msg { return msg.Key }
+	case shared.DidCloseFolderTreeMsg:
+		m.folderTree.Blur()
+		return m, nil
+	case shared.DidSetCurrentItemMsg:
+		m.currentRightPanel = textArea
+		m.textArea.SetStepStatuses(m.stepRuns[msg.ItemID])
+	case shared.DidSetCurrentFolderMsg:
+		m.textArea.SetCurrentFolder(msg.Folder)
+		m.textArea.TextArea.SetValue(m.folderPreview(msg.Folder))
 		m.currentRightPanel = textArea
 		return m, nil
 	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, helpkeys.LisKeys.Esc):
-			if m.currentRightPanel == modal {
+		if m.folderTree.Focused() {
+			m.folderTree, cmd = m.folderTree.Update(msg)
+			return m, cmd
+		}
+
+		switch m.currentRightPanel {
+		case folderPicker:
+			m.folderPicker, cmd = m.folderPicker.Update(msg)
+			return m, cmd
+		case tagFormPanel:
+			m.tagForm, cmd = m.tagForm.Update(msg)
+			return m, cmd
+		case variableFormPanel:
+			m.variableForm, cmd = m.variableForm.Update(msg)
+			return m, cmd
+		case goToPanel:
+			m.goToPrompt, cmd = m.goToPrompt.Update(msg)
+			return m, cmd
+		case commandPalettePanel:
+			m.commandPalette, cmd = m.commandPalette.Update(msg)
+			return m, cmd
+		case modal:
+			if key.Matches(msg, helpkeys.LisKeys.Esc) {
 				m.currentRightPanel = textArea
 				return m, nil
 			}
-		case key.Matches(msg, helpkeys.LisKeys.Delete):
+			confirmationModalModel, cmd := m.confirmationModal.Update(msg)
+			m.confirmationModal = confirmationModalModel.(confirmationmodal.Model)
+			return m, cmd
+		}
+		if m.navigableList.IsFiltering() {
+			break
+		}
+
+		switch {
+		case key.Matches(msg, helpkeys.LisKeys.ToggleSidebar):
+			m.toggleFolderTree()
+			return m, nil
+		case key.Matches(msg, helpkeys.LisKeys.FocusSidebar):
+			if m.isFolderTreeVisible() {
+				m.folderTree.Focus()
+			}
+			return m, nil
+		case key.Matches(msg, helpkeys.LisKeys.CommandPalette):
+			return m, m.showCommandPalette()
+		case key.Matches(msg, helpkeys.LisKeys.GoToFolder):
+			return m, m.showGoToPrompt()
+		case key.Matches(msg, helpkeys.LisKeys.TagWorkflows):
+			return m, m.showTagForm()
+		case key.Matches(msg, helpkeys.LisKeys.CycleVariant):
+			m.textArea.CycleVariant()
+			return m, nil
+		case key.Matches(msg, helpkeys.LisKeys.MoveWorkflow):
+			if m.navigableList.HasSelection() {
+				return m, m.showMoveSelectionPicker()
+			}
 			currentItem := m.navigableList.CurrentItem()
 			if currentItem != nil && !currentItem.IsFolder() {
-				m.showDeleteModal()
+				return m, m.showMoveItemPicker(currentItem.(list.WorkflowItem).GetItem())
+			}
+		case key.Matches(msg, helpkeys.LisKeys.Delete):
+			if m.navigableList.HasSelection() {
+				m.showDeleteSelectionModal()
+				return m, nil
+			}
+			currentItem := m.navigableList.CurrentItem()
+			if currentItem != nil {
+				if folderItem, ok := currentItem.(list.FolderItem); ok {
+					if !folderItem.IsVirtual() {
+						m.showDeleteFolderModal(folderItem.GetFolder())
+					}
+				} else {
+					m.showDeleteModal()
+				}
 			}
 		}
 	}
@@ -10,13 +10,29 @@ func (m Model) bigWidthView() string {
 		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.textArea.View())
 	case modal:
 		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.confirmationModal.View())
+	case folderPicker:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.folderPicker.View())
+	case tagFormPanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.tagForm.View())
+	case variableFormPanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.variableForm.View())
+	case goToPanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.goToPrompt.View())
+	case commandPalettePanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.commandPalette.View())
 	default:
 		rightPanel = ""
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top,
+	panels := []string{
 		m.panelsStyle.leftPanelStyle.Render(m.navigableList.View()),
-		m.panelsStyle.rightPanelStyle.Render(rightPanel))
+		m.panelsStyle.rightPanelStyle.Render(rightPanel),
+	}
+	if m.isFolderTreeVisible() {
+		panels = append([]string{m.panelsStyle.treePanelStyle.Render(m.folderTree.View())}, panels...)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, panels...)
 }
 
 func (m Model) smallWidthView() string {
@@ -27,6 +43,16 @@ func (m Model) smallWidthView() string {
 		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.textArea.View())
 	case modal:
 		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.confirmationModal.View())
+	case folderPicker:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.folderPicker.View())
+	case tagFormPanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.tagForm.View())
+	case variableFormPanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.variableForm.View())
+	case goToPanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.goToPrompt.View())
+	case commandPalettePanel:
+		rightPanel = m.panelsStyle.rightPanelStyle.Render(m.commandPalette.View())
 	default:
 		rightPanel = ""
 	}
@@ -0,0 +1,89 @@
+package folderform
+
+import (
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+func (m *Model) SetSize(width, _ int) {
+	m.Name.Width = width
+	m.Description.Width = width
+	m.RunSettings.SetWidth(width)
+}
+
+// SetFolder switches the form to edit mode for the given folder.
+func (m *Model) SetFolder(folder models.FolderV2) {
+	m.editingPath = folder.Path
+	m.Name.SetValue(folder.Name)
+	m.Description.SetValue(folder.Description)
+	m.RunSettings.SetValue(models.FormatRunSettings(folder.RunSettings))
+	m.focusInput(name)
+}
+
+func (m Model) IsEditing() bool {
+	return m.editingPath != ""
+}
+
+func (m *Model) ResetForm() {
+	m.editingPath = ""
+	m.Name.SetValue("")
+	m.Description.SetValue("")
+	m.RunSettings.SetValue("")
+	m.focusInput(name)
+}
+
+func (m *Model) focusInput(i inputs) (Model, tea.Cmd) {
+	switch i {
+	case name:
+		m.Name.Focus()
+		m.Description.Blur()
+		m.RunSettings.Blur()
+		m.selectedInput = name
+	case description:
+		m.Name.Blur()
+		m.Description.Focus()
+		m.RunSettings.Blur()
+		m.selectedInput = description
+	case runSettingsInput:
+		m.Name.Blur()
+		m.Description.Blur()
+		m.RunSettings.Focus()
+		m.selectedInput = runSettingsInput
+	case submit:
+		m.Name.Blur()
+		m.Description.Blur()
+		m.RunSettings.Blur()
+		m.selectedInput = submit
+	case close:
+		m.Name.Blur()
+		m.Description.Blur()
+		m.RunSettings.Blur()
+		m.selectedInput = close
+	}
+
+	return *m, nil
+}
+
+// parseRunSettings reads the defaults of the folder and validates them.
+func parseRunSettings(text string) (models.RunSettings, error) {
+	settings, err := models.ParseRunSettings(text)
+	if err != nil {
+		return models.RunSettings{}, err
+	}
+
+	validation := di.GetService[*services.ValidationService](di.ValidationServiceKey)
+	if err := validation.Validate(settings); err != nil {
+		return models.RunSettings{}, errors.New(strings.Join(validation.GetValidationErrors(err), ", "))
+	}
+	return settings, nil
+}
+
+func (m Model) isFormValid() bool {
+	return m.Name.Value() != ""
+}
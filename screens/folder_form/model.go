@@ -0,0 +1,108 @@
+package folderform
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type (
+	inputs uint
+
+	Styles struct {
+		main               lipgloss.Style
+		focusedInput       lipgloss.Style
+		blurredInput       lipgloss.Style
+		focusedTextArea    lipgloss.Style
+		blurredTextArea    lipgloss.Style
+		focusedButton      string
+		blurredButton      string
+		blurredCloseButton string
+		focusedCloseButton string
+	}
+
+	Notifications struct {
+		fillFolderName     string
+		invalidRunSettings func(err error) string
+	}
+
+	Model struct {
+		Name          textinput.Model
+		Description   textinput.Model
+		RunSettings   textarea.Model
+		editingPath   string
+		selectedInput inputs
+		styles        Styles
+		notifications Notifications
+		Keys          helpkeys.AddNewKeyMap
+	}
+)
+
+const (
+	close inputs = iota
+	name
+	description
+	runSettingsInput
+	submit
+)
+
+// runSettingsHeight is the number of lines of the run settings input.
+const runSettingsHeight = 3
+
+func New() Model {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+
+	nameModel := textinput.New()
+	nameModel.Placeholder = i18n.Translate("folder_name_placeholder")
+	nameModel.Focus()
+	descModel := textinput.New()
+	descModel.Placeholder = i18n.Translate("folder_description_placeholder")
+	runSettingsModel := textarea.New()
+	runSettingsModel.Placeholder = i18n.Translate("folder_run_settings_placeholder")
+	runSettingsModel.Prompt = ""
+	runSettingsModel.ShowLineNumbers = false
+	runSettingsModel.SetHeight(runSettingsHeight)
+
+	styles := theme.Current()
+	focusedSaveButton := styles.Focused.Render(fmt.Sprintf("[ %s ]", i18n.Translate("save_button_label")))
+	blurredSaveButton := styles.Blurred.Render(fmt.Sprintf("[ %s ]", i18n.Translate("save_button_label")))
+	focusedCloseButton := styles.Focused.Render(fmt.Sprintf("[ %s ]", i18n.Translate("cancel_button_label")))
+	blurredCloseButton := styles.Blurred.Render(fmt.Sprintf("[ %s ]", i18n.Translate("cancel_button_label")))
+
+	return Model{
+		Name:          nameModel,
+		Description:   descModel,
+		RunSettings:   runSettingsModel,
+		selectedInput: name,
+		Keys:          helpkeys.NewAddNewKeys(i18n),
+		notifications: Notifications{
+			fillFolderName: i18n.Translate("error_fill_folder_name"),
+			invalidRunSettings: func(err error) string {
+				return i18n.TranslateWithData("error_invalid_run_settings", map[string]interface{}{"Error": err.Error()})
+			},
+		},
+		styles: Styles{
+			main:               styles.BlurredBorder,
+			focusedInput:       styles.Focused,
+			blurredInput:       styles.Blurred,
+			focusedTextArea:    styles.FocusedBorder,
+			blurredTextArea:    styles.BlurredBorder,
+			focusedButton:      focusedSaveButton,
+			blurredButton:      blurredSaveButton,
+			blurredCloseButton: blurredCloseButton,
+			focusedCloseButton: focusedCloseButton,
+		},
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
@@ -0,0 +1,87 @@
+package folderform
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/components/notification"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	nameModel, nameCmd := m.Name.Update(msg)
+	descModel, descCmd := m.Description.Update(msg)
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.Keys.Down):
+			switch m.selectedInput {
+			case name:
+				return m.focusInput(description)
+			case description:
+				return m.focusInput(runSettingsInput)
+			case runSettingsInput:
+				return m.focusInput(submit)
+			case submit, close:
+				return m, nil
+			}
+		case key.Matches(msg, m.Keys.Up):
+			switch m.selectedInput {
+			case name:
+				return m, nil
+			case description:
+				return m.focusInput(name)
+			case runSettingsInput:
+				return m.focusInput(description)
+			case submit, close:
+				return m.focusInput(runSettingsInput)
+			}
+		case key.Matches(msg, m.Keys.Right):
+			if m.selectedInput == submit {
+				return m.focusInput(close)
+			}
+		case key.Matches(msg, m.Keys.Left):
+			if m.selectedInput == close {
+				return m.focusInput(submit)
+			}
+		case key.Matches(msg, m.Keys.Close):
+			m.ResetForm()
+			return m, shared.CloseFolderFormScreenCmd()
+		case key.Matches(msg, m.Keys.Submit):
+			switch m.selectedInput {
+			case name, description:
+				return m.focusInput(m.selectedInput + 1)
+			case submit:
+				if !m.isFormValid() {
+					return m, notification.ShowNotificationCmd(m.notifications.fillFolderName)
+				}
+
+				run, err := parseRunSettings(m.RunSettings.Value())
+				if err != nil {
+					return m, notification.ShowNotificationCmd(m.notifications.invalidRunSettings(err))
+				}
+
+				folderName := strings.TrimSpace(m.Name.Value())
+				folderDescription := strings.TrimSpace(m.Description.Value())
+				editingPath := m.editingPath
+
+				m.ResetForm()
+				if editingPath != "" {
+					return m, shared.UpdateFolderCmd(editingPath, folderName, folderDescription, run)
+				}
+				return m, shared.AddNewFolderCmd(folderName, folderDescription, run)
+			case close:
+				m.ResetForm()
+				return m, shared.CloseFolderFormScreenCmd()
+			}
+		}
+	}
+
+	runSettingsModel, runSettingsCmd := m.RunSettings.Update(msg)
+	m.Name = nameModel
+	m.Description = descModel
+	m.RunSettings = runSettingsModel
+	return m, tea.Batch(nameCmd, descCmd, runSettingsCmd)
+}
@@ -0,0 +1,31 @@
+package folderform
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) View() string {
+	nameStyle, descriptionStyle := m.styles.blurredInput, m.styles.blurredInput
+	runSettingsStyle := m.styles.blurredTextArea
+	saveButton, closeButton := m.styles.blurredButton, m.styles.blurredCloseButton
+
+	switch m.selectedInput {
+	case name:
+		nameStyle = m.styles.focusedInput
+	case description:
+		descriptionStyle = m.styles.focusedInput
+	case runSettingsInput:
+		runSettingsStyle = m.styles.focusedTextArea
+	case submit:
+		saveButton = m.styles.focusedButton
+	case close:
+		closeButton = m.styles.focusedCloseButton
+	}
+
+	return m.styles.main.Render(lipgloss.JoinVertical(lipgloss.Top,
+		nameStyle.Render(m.Name.View()),
+		descriptionStyle.Render(m.Description.View()),
+		runSettingsStyle.Render(m.RunSettings.View()),
+		lipgloss.NewStyle().Align(lipgloss.Right).Width(m.Name.Width).Render(
+			lipgloss.JoinHorizontal(lipgloss.Top, saveButton, closeButton))))
+}
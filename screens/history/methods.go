@@ -0,0 +1,56 @@
+package history
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+// Open loads the most recent runs and clears the filter.
+func (m *Model) Open() tea.Cmd {
+	m.records = nil
+	m.filter.SetValue("")
+	m.cursor = 0
+
+	if m.history != nil {
+		records, err := m.history.List("", recordLimit)
+		if err != nil {
+			m.applyFilter()
+			return shared.ErrorCmd(err)
+		}
+		m.records = records
+	}
+	m.applyFilter()
+	return m.filter.Focus()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.filter.Width = width - 6
+}
+
+// Selected returns the highlighted run.
+func (m Model) Selected() (models.HistoryRecord, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return models.HistoryRecord{}, false
+	}
+	return m.filtered[m.cursor], true
+}
+
+func (m *Model) applyFilter() {
+	m.filtered = nil
+	for _, record := range m.records {
+		if record.Matches(m.filter.Value()) {
+			m.filtered = append(m.filtered, record)
+		}
+	}
+
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
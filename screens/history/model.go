@@ -0,0 +1,82 @@
+package history
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/theme"
+	"github.com/evertonstz/go-workflows/models"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+)
+
+type (
+	Styles struct {
+		main     lipgloss.Style
+		title    lipgloss.Style
+		selected lipgloss.Style
+		subtle   lipgloss.Style
+		failed   lipgloss.Style
+	}
+
+	Labels struct {
+		title     string
+		empty     string
+		noMatches string
+		output    string
+		exitCode  func(code int) string
+	}
+
+	Model struct {
+		filter   textinput.Model
+		records  []models.HistoryRecord
+		filtered []models.HistoryRecord
+		cursor   int
+		width    int
+		height   int
+		history  *services.HistoryService
+		styles   Styles
+		labels   Labels
+		Keys     helpkeys.HistoryKeyMap
+	}
+)
+
+// recordLimit is the number of most recent runs the screen lists.
+const recordLimit = 500
+
+func New() Model {
+	i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+
+	filter := textinput.New()
+	filter.Placeholder = i18n.Translate("history_filter_placeholder")
+	filter.Prompt = "/ "
+
+	styles := theme.Current()
+	return Model{
+		filter:  filter,
+		history: di.GetService[*services.HistoryService](di.HistoryServiceKey),
+		Keys:    helpkeys.NewHistoryKeys(i18n),
+		labels: Labels{
+			title:     i18n.Translate("history_title"),
+			empty:     i18n.Translate("history_empty"),
+			noMatches: i18n.Translate("history_no_matches"),
+			output:    i18n.Translate("history_output"),
+			exitCode: func(code int) string {
+				return i18n.TranslateWithData("history_exit_code", map[string]interface{}{"Code": code})
+			},
+		},
+		styles: Styles{
+			main:     styles.BlurredBorder.Padding(0, 1),
+			title:    lipgloss.NewStyle().Bold(true).PaddingBottom(1),
+			selected: styles.Focused,
+			subtle:   styles.Blurred,
+			failed:   styles.Warning,
+		},
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
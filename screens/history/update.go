@@ -0,0 +1,42 @@
+package history
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/shared"
+)
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.Keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Down):
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case key.Matches(keyMsg, m.Keys.Close):
+		return m, shared.CloseHistoryScreenCmd()
+	case key.Matches(keyMsg, m.Keys.Rerun):
+		if record, ok := m.Selected(); ok {
+			return m, shared.RerunWorkflowCmd(record.ItemID, record.Variables)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(keyMsg)
+	m.applyFilter()
+	return m, cmd
+}
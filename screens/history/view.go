@@ -0,0 +1,128 @@
+package history
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// detailLines is the number of lines of command and output shown for the
+// highlighted run.
+const detailLines = 6
+
+func (m Model) View() string {
+	width := m.width - 4
+	if width < 20 {
+		width = 20
+	}
+	line := lipgloss.NewStyle().MaxWidth(width)
+
+	sections := []string{
+		m.styles.title.Render(m.labels.title),
+		m.filter.View(),
+		"",
+	}
+
+	switch {
+	case len(m.records) == 0:
+		sections = append(sections, m.styles.subtle.Render(m.labels.empty))
+	case len(m.filtered) == 0:
+		sections = append(sections, m.styles.subtle.Render(m.labels.noMatches))
+	default:
+		sections = append(sections, m.rows(line)...)
+		sections = append(sections, m.styles.subtle.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.filtered))))
+		if record, ok := m.Selected(); ok {
+			sections = append(sections, "")
+			sections = append(sections, m.details(record, line)...)
+		}
+	}
+
+	return m.styles.main.Width(width + 2).Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+// rows lists the runs around the highlighted one, as many as fit next to its
+// details.
+func (m Model) rows(line lipgloss.Style) []string {
+	visibleRows := m.height - 2*detailLines - 10
+	if visibleRows < 3 {
+		visibleRows = 3
+	}
+
+	start := 0
+	if m.cursor >= visibleRows {
+		start = m.cursor - visibleRows + 1
+	}
+	end := min(start+visibleRows, len(m.filtered))
+
+	var rows []string
+	for i := start; i < end; i++ {
+		record := m.filtered[i]
+		status := "✓"
+		if !record.Succeeded() {
+			status = m.styles.failed.Render("✗")
+		}
+		row := fmt.Sprintf("%s %s  %s  %s", status,
+			record.StartedAt.Local().Format("2006-01-02 15:04"),
+			record.Title,
+			m.styles.subtle.Render(formatDuration(record.Duration())))
+
+		if i == m.cursor {
+			rows = append(rows, line.Render(m.styles.selected.Render("› ")+row))
+		} else {
+			rows = append(rows, line.Render("  "+row))
+		}
+	}
+	return rows
+}
+
+// details shows what the highlighted run ran, where, with which values and
+// how it ended.
+func (m Model) details(record models.HistoryRecord, line lipgloss.Style) []string {
+	var lines []string
+	for _, command := range headLines(record.Command, detailLines) {
+		lines = append(lines, line.Render("$ "+command))
+	}
+	lines = append(lines, line.Render(m.styles.subtle.Render(record.Cwd)))
+
+	for _, name := range slices.Sorted(maps.Keys(record.Variables)) {
+		lines = append(lines, line.Render(m.styles.subtle.Render(name+"="+record.Variables[name])))
+	}
+	if !record.Succeeded() {
+		lines = append(lines, m.styles.failed.Render(m.labels.exitCode(record.ExitCode)))
+	}
+
+	if record.Output != "" {
+		lines = append(lines, "", m.styles.subtle.Render(m.labels.output))
+		for _, output := range tailLines(record.Output, detailLines) {
+			lines = append(lines, line.Render(output))
+		}
+	}
+	return lines
+}
+
+// headLines returns the first count lines of text, marking the ones left out.
+func headLines(text string, count int) []string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) <= count {
+		return lines
+	}
+	return append(lines[:count-1:count-1], "…")
+}
+
+func tailLines(text string, count int) []string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	return lines[max(len(lines)-count, 0):]
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -1,26 +1,69 @@
 package shared
 
 import (
+	"errors"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/evertonstz/go-workflows/models"
-
-	"github.com/atotto/clipboard"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
+	"github.com/evertonstz/go-workflows/shared/runner"
 )
 
-func CopyToClipboardCmd(t string) tea.Cmd {
-	return func() tea.Msg {
-		err := clipboard.WriteAll(t)
+// CopyToClipboardCmd copies t and reports the workflows it came from, so
+// that their usage is only recorded when the copy succeeds, and the provider
+// that copied it. When the copy writes to the terminal, it runs while the
+// program releases the terminal.
+func CopyToClipboardCmd(t string, itemIDs ...string) tea.Cmd {
+	copied := func(provider services.ClipboardProvider, err error) tea.Msg {
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
-		return CopiedToClipboardMsg{}
+		msg := CopiedToClipboardMsg{ItemIDs: itemIDs, Provider: provider.Name()}
+		if file, ok := provider.(services.FileClipboard); ok {
+			msg.Path = file.Path
+		}
+		return msg
+	}
+
+	return func() tea.Msg {
+		service := di.GetService[*services.ClipboardService](di.ClipboardServiceKey)
+		provider, err := service.Copy(t, nil)
+		if errors.Is(err, services.ErrNeedsTerminal) {
+			terminalCopy := services.NewTerminalCopy(service, t)
+			return tea.Exec(terminalCopy, func(err error) tea.Msg {
+				return copied(terminalCopy.Provider, err)
+			})()
+		}
+		return copied(provider, err)
 	}
 }
 
-func SetCurrentItemCmd(i models.Item) tea.Cmd {
+// SetCurrentItemCmd selects i. Risk is what analyzing its command found,
+// inherited are the run settings its folders give it, active is the index of
+// the variant that applies here, or -1, and includeErr tells why its includes
+// cannot be expanded.
+func SetCurrentItemCmd(i models.ItemV2, risk models.Risk, inherited models.RunSettings, active int, includeErr error) tea.Cmd {
 	return func() tea.Msg {
-		return DidSetCurrentItemMsg{Item: i}
+		return DidSetCurrentItemMsg{
+			Item: models.Item{
+				Title:       i.Title,
+				Desc:        i.Desc,
+				Command:     i.Command,
+				DateAdded:   i.DateAdded,
+				DateUpdated: i.DateUpdated,
+			},
+			ItemID:               i.ID,
+			Language:             i.Language,
+			Steps:                i.Steps,
+			Variants:             i.Variants,
+			ActiveVariant:        active,
+			IncludeError:         includeErr,
+			Risk:                 risk,
+			RunSettings:          i.RunSettings,
+			InheritedRunSettings: inherited,
+		}
 	}
 }
 
@@ -30,12 +73,96 @@ func DeleteCurrentItemCmd(i int) tea.Cmd {
 	}
 }
 
-func AddNewItemCmd(title, description, command string) tea.Cmd {
+func AddNewItemCmd(title, description, command, language string, variables []models.Variable, variants []models.Variant, run models.RunSettings) tea.Cmd {
 	return func() tea.Msg {
 		return DidAddNewItemMsg{
 			Title:       title,
 			Description: description,
 			CommandText: command,
+			Language:    language,
+			Variables:   variables,
+			Variants:    variants,
+			RunSettings: run,
+		}
+	}
+}
+
+// AddNewMultiStepItemCmd adds a workflow that runs steps instead of a
+// single command.
+func AddNewMultiStepItemCmd(title, description, language string, steps []models.Step, variables []models.Variable, run models.RunSettings) tea.Cmd {
+	return func() tea.Msg {
+		return DidAddNewItemMsg{
+			Title:       title,
+			Description: description,
+			Language:    language,
+			Steps:       steps,
+			Variables:   variables,
+			RunSettings: run,
+		}
+	}
+}
+
+func AddNewFolderCmd(name, description string, run models.RunSettings) tea.Cmd {
+	return func() tea.Msg {
+		return DidAddNewFolderMsg{
+			Name:        name,
+			Description: description,
+			RunSettings: run,
+		}
+	}
+}
+
+func UpdateFolderCmd(path, name, description string, run models.RunSettings) tea.Cmd {
+	return func() tea.Msg {
+		return DidUpdateFolderMsg{
+			Path:        path,
+			Name:        name,
+			Description: description,
+			RunSettings: run,
+		}
+	}
+}
+
+func DeleteFolderCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		return DidDeleteFolderMsg{Path: path}
+	}
+}
+
+func CloseFolderFormScreenCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseFolderFormScreenMsg{}
+	}
+}
+
+func PickFolderCmd(path, newFolderName string) tea.Cmd {
+	return func() tea.Msg {
+		return DidPickFolderMsg{
+			Path:          path,
+			NewFolderName: newFolderName,
+		}
+	}
+}
+
+func CloseFolderPickerCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseFolderPickerMsg{}
+	}
+}
+
+func DuplicateCmd(itemID, folderPath string) tea.Cmd {
+	return func() tea.Msg {
+		return DidDuplicateMsg{ItemID: itemID, FolderPath: folderPath}
+	}
+}
+
+func PasteCmd(itemIDs, folderPaths []string, cut bool, destination string) tea.Cmd {
+	return func() tea.Msg {
+		return DidPasteMsg{
+			ItemIDs:     itemIDs,
+			FolderPaths: folderPaths,
+			Cut:         cut,
+			Destination: destination,
 		}
 	}
 }
@@ -75,3 +202,158 @@ func ErrorCmd(err error) tea.Cmd {
 		return ErrorMsg{Err: err}
 	}
 }
+
+func DeleteEntriesCmd(itemIDs, folderPaths []string) tea.Cmd {
+	return func() tea.Msg {
+		return DidDeleteEntriesMsg{ItemIDs: itemIDs, FolderPaths: folderPaths}
+	}
+}
+
+func MoveEntriesCmd(itemIDs, folderPaths []string, destination, newFolderName string) tea.Cmd {
+	return func() tea.Msg {
+		return DidMoveEntriesMsg{
+			ItemIDs:       itemIDs,
+			FolderPaths:   folderPaths,
+			Destination:   destination,
+			NewFolderName: newFolderName,
+		}
+	}
+}
+
+func SubmitTagFormCmd(tag string, remove bool) tea.Cmd {
+	return func() tea.Msg {
+		return DidSubmitTagFormMsg{Tag: tag, Remove: remove}
+	}
+}
+
+func CloseTagFormCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseTagFormMsg{}
+	}
+}
+
+// WithVariablesCmd runs then with the values of the variables of item,
+// asking for them first when it has any.
+func WithVariablesCmd(item models.ItemV2, then func(values map[string]string) tea.Cmd) tea.Cmd {
+	return WithValuesCmd(item, nil, then)
+}
+
+// WithValuesCmd is WithVariablesCmd offering values, such as those of a past
+// run, instead of the ones given last.
+func WithValuesCmd(item models.ItemV2, values map[string]string, then func(values map[string]string) tea.Cmd) tea.Cmd {
+	if len(item.AllVariables()) == 0 {
+		return then(nil)
+	}
+	return func() tea.Msg {
+		return DidRequestVariablesMsg{Item: item, Values: values, Then: then}
+	}
+}
+
+func SubmitVariableFormCmd(values map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		return DidSubmitVariableFormMsg{Values: values}
+	}
+}
+
+func CloseVariableFormCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseVariableFormMsg{}
+	}
+}
+
+// LoadSuggestionsCmd runs command in the background, so that a slow command
+// doesn't hold up the interface.
+func LoadSuggestionsCmd(suggestions *runner.Suggestions, command string) tea.Cmd {
+	return func() tea.Msg {
+		values, err := suggestions.Load(command)
+		return DidLoadSuggestionsMsg{Command: command, Suggestions: values, Err: err}
+	}
+}
+
+func UpdateTagsCmd(itemIDs, addTags, removeTags []string) tea.Cmd {
+	return func() tea.Msg {
+		return DidUpdateTagsMsg{ItemIDs: itemIDs, AddTags: addTags, RemoveTags: removeTags}
+	}
+}
+
+func ToggleFavoriteCmd(itemID string) tea.Cmd {
+	return func() tea.Msg {
+		return DidToggleFavoriteMsg{ItemID: itemID}
+	}
+}
+
+// ConfirmRiskCmd asks the user to confirm cmd, which copies or runs commands
+// with the given risk, before it runs.
+func ConfirmRiskCmd(risk models.Risk, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return DidRequestRiskConfirmationMsg{Risk: risk, Confirm: cmd}
+	}
+}
+
+// RunWorkflowCmd runs the workflow with the given ID. Resume runs a
+// multi-step workflow from the step its last run stopped at.
+func RunWorkflowCmd(itemID string, resume bool) tea.Cmd {
+	return func() tea.Msg {
+		return DidRequestRunWorkflowMsg{ItemID: itemID, Resume: resume}
+	}
+}
+
+// RerunWorkflowCmd runs the workflow with the given ID again, offering the
+// values its variables had in a past run.
+func RerunWorkflowCmd(itemID string, values map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		return DidRequestRunWorkflowMsg{ItemID: itemID, Values: values}
+	}
+}
+
+func CloseHistoryScreenCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseHistoryScreenMsg{}
+	}
+}
+
+func SetFolderViewCmd(path string, view models.FolderView) tea.Cmd {
+	return func() tea.Msg {
+		return DidSetFolderViewMsg{Path: path, View: view}
+	}
+}
+
+func ReorderCmd(path string, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		return DidReorderMsg{Path: path, IDs: ids}
+	}
+}
+
+// OpenFolderCmd asks the list to browse path, as opposed to
+// NavigatedToFolderCmd, which reports that it already did.
+func OpenFolderCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		return DidOpenFolderMsg{Path: path}
+	}
+}
+
+func CloseFolderTreeCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseFolderTreeMsg{}
+	}
+}
+
+func CloseGoToPromptCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseGoToPromptMsg{}
+	}
+}
+
+func CloseCommandPaletteCmd() tea.Cmd {
+	return func() tea.Msg {
+		return DidCloseCommandPaletteMsg{}
+	}
+}
+
+// RunActionCmd asks the list to close the command palette and then handle
+// key as if it had been typed.
+func RunActionCmd(key tea.KeyMsg) tea.Cmd {
+	return func() tea.Msg {
+		return DidRunActionMsg{Key: key}
+	}
+}
@@ -20,6 +20,13 @@ const (
 	I18nServiceKey ServiceKey = iota
 	PersistenceServiceKey
 	ValidationServiceKey
+	UsageServiceKey
+	ViewStateServiceKey
+	ConfigServiceKey
+	RiskServiceKey
+	VariableValuesServiceKey
+	HistoryServiceKey
+	ClipboardServiceKey
 	// Add other service keys here as needed
 )
 
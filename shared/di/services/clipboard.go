@@ -0,0 +1,261 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/atotto/clipboard"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// ErrNeedsTerminal is returned by Copy when a provider writes to the
+// terminal and none was given.
+var ErrNeedsTerminal = errors.New("the clipboard needs the terminal")
+
+// ClipboardProvider copies text to a place it can be pasted from.
+type ClipboardProvider interface {
+	// Name is how the provider is chosen in the config and named in
+	// notifications.
+	Name() string
+	Available() bool
+	// Copy copies text. Terminal is the output of the program, for the
+	// providers that talk to the terminal, or nil.
+	Copy(text string, terminal io.Writer) error
+}
+
+// ClipboardService copies text with the provider chosen in the config or,
+// when it is "auto", with the first available provider that works for the
+// session: over SSH tmux and the terminal come first, as the clipboards of
+// the remote machine cannot be pasted from.
+type ClipboardService struct {
+	providers []ClipboardProvider
+}
+
+// clipboardEnv is what detecting the providers depends on, replaced in
+// tests.
+type clipboardEnv struct {
+	getenv   func(string) string
+	lookPath func(string) (string, error)
+}
+
+func NewClipboardService(appName, setting string) (*ClipboardService, error) {
+	filePath, err := xdg.StateFile(fmt.Sprintf("%s/clipboard.txt", appName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine clipboard file path: %w", err)
+	}
+
+	env := clipboardEnv{
+		getenv:   os.Getenv,
+		lookPath: exec.LookPath,
+	}
+	return newClipboardService(env, setting, filePath)
+}
+
+func newClipboardService(env clipboardEnv, setting, filePath string) (*ClipboardService, error) {
+	providers := env.providers(filePath)
+	if setting == "" || setting == models.ClipboardAuto {
+		return &ClipboardService{providers: env.detect(providers)}, nil
+	}
+	for _, provider := range providers {
+		if provider.Name() == setting {
+			return &ClipboardService{providers: []ClipboardProvider{provider}}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown clipboard provider %q", setting)
+}
+
+// Providers returns the providers Copy tries, in order.
+func (c *ClipboardService) Providers() []ClipboardProvider {
+	return c.providers
+}
+
+// Copy copies text with the first provider that succeeds and returns it.
+// Without a terminal it stops with ErrNeedsTerminal at the first provider
+// that needs one; copy with a TerminalCopy then.
+func (c *ClipboardService) Copy(text string, terminal io.Writer) (ClipboardProvider, error) {
+	var errs []error
+	for _, provider := range c.providers {
+		err := provider.Copy(text, terminal)
+		if errors.Is(err, ErrNeedsTerminal) {
+			return nil, err
+		}
+		if err == nil {
+			return provider, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no clipboard provider available")
+	}
+	return nil, fmt.Errorf("failed to copy: %w", errors.Join(errs...))
+}
+
+// TerminalCopy copies text with a service while the program has released the
+// terminal, so that escape sequences go to the output of the program instead
+// of landing in the middle of a frame. It matches tea.ExecCommand.
+type TerminalCopy struct {
+	service  *ClipboardService
+	text     string
+	terminal io.Writer
+
+	// Provider is the provider that copied the text, once Run succeeds.
+	Provider ClipboardProvider
+}
+
+func NewTerminalCopy(service *ClipboardService, text string) *TerminalCopy {
+	return &TerminalCopy{service: service, text: text}
+}
+
+func (t *TerminalCopy) Run() error {
+	if t.terminal == nil {
+		return ErrNeedsTerminal
+	}
+	provider, err := t.service.Copy(t.text, t.terminal)
+	t.Provider = provider
+	return err
+}
+
+func (t *TerminalCopy) SetStdin(io.Reader) {}
+
+func (t *TerminalCopy) SetStdout(w io.Writer) { t.terminal = w }
+
+func (t *TerminalCopy) SetStderr(io.Writer) {}
+
+func (e clipboardEnv) providers(filePath string) []ClipboardProvider {
+	return []ClipboardProvider{
+		osc52Clipboard{env: e},
+		commandClipboard{env: e, name: "tmux", args: []string{"tmux", "load-buffer", "-w", "-"}, requires: "TMUX"},
+		commandClipboard{env: e, name: "wl-copy", args: []string{"wl-copy"}, requires: "WAYLAND_DISPLAY"},
+		commandClipboard{env: e, name: "xclip", args: []string{"xclip", "-selection", "clipboard"}, requires: "DISPLAY"},
+		systemClipboard{env: e},
+		FileClipboard{Path: filePath},
+	}
+}
+
+// detect returns the available providers in the order auto tries them.
+func (e clipboardEnv) detect(providers []ClipboardProvider) []ClipboardProvider {
+	order := []string{"wl-copy", "xclip", "system", "tmux", "osc52", "file"}
+	if e.getenv("SSH_TTY") != "" || e.getenv("SSH_CONNECTION") != "" {
+		order = []string{"tmux", "osc52", "file"}
+	}
+
+	var detected []ClipboardProvider
+	for _, name := range order {
+		for _, provider := range providers {
+			if provider.Name() == name && provider.Available() {
+				detected = append(detected, provider)
+			}
+		}
+	}
+	return detected
+}
+
+// osc52Clipboard asks the terminal to set its clipboard with an OSC 52
+// escape sequence, which reaches the local machine over SSH. Inside tmux the
+// sequence is passed through to the terminal around it, which tmux 3.3 and
+// later only do with allow-passthrough on. Terminals don't answer the
+// sequence, so copying with it cannot fail once it is written.
+type osc52Clipboard struct {
+	env clipboardEnv
+}
+
+func (o osc52Clipboard) Name() string { return "osc52" }
+
+func (o osc52Clipboard) Available() bool {
+	term := o.env.getenv("TERM")
+	return term != "" && term != "dumb"
+}
+
+func (o osc52Clipboard) Copy(text string, terminal io.Writer) error {
+	if terminal == nil {
+		return ErrNeedsTerminal
+	}
+	_, err := io.WriteString(terminal, osc52Sequence(text, o.env.getenv("TMUX") != ""))
+	return err
+}
+
+func osc52Sequence(text string, tmux bool) string {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if tmux {
+		return "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return sequence
+}
+
+// commandClipboard pipes the text into a program, available when it is
+// installed and the environment variable it requires is set. The tmux buffer
+// is also handed to the clipboard of the terminal around tmux, with -w.
+type commandClipboard struct {
+	env      clipboardEnv
+	name     string
+	args     []string
+	requires string
+}
+
+func (c commandClipboard) Name() string { return c.name }
+
+func (c commandClipboard) Available() bool {
+	if c.env.getenv(c.requires) == "" {
+		return false
+	}
+	_, err := c.env.lookPath(c.args[0])
+	return err == nil
+}
+
+func (c commandClipboard) Copy(text string, _ io.Writer) error {
+	cmd := exec.Command(c.args[0], c.args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("%w: %s", err, message)
+		}
+		return err
+	}
+	return nil
+}
+
+// systemClipboard uses the clipboard of the operating system, through
+// pbcopy on macOS and the clipboard API on Windows.
+type systemClipboard struct {
+	env clipboardEnv
+}
+
+func (s systemClipboard) Name() string { return "system" }
+
+func (s systemClipboard) Available() bool {
+	if clipboard.Unsupported {
+		return false
+	}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return true
+	}
+	return s.env.getenv("WAYLAND_DISPLAY") != "" || s.env.getenv("DISPLAY") != ""
+}
+
+func (s systemClipboard) Copy(text string, _ io.Writer) error {
+	return clipboard.WriteAll(text)
+}
+
+// FileClipboard writes the text to a file, for when nothing else works.
+type FileClipboard struct {
+	Path string
+}
+
+func (f FileClipboard) Name() string { return "file" }
+
+func (f FileClipboard) Available() bool { return true }
+
+func (f FileClipboard) Copy(text string, _ io.Writer) error {
+	if err := os.WriteFile(f.Path, []byte(text), 0o600); err != nil {
+		return fmt.Errorf("failed to write clipboard file: %w", err)
+	}
+	return nil
+}
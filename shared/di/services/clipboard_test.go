@@ -0,0 +1,134 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("closed") }
+
+func newTestClipboardEnv(env map[string]string, installed ...string) clipboardEnv {
+	return clipboardEnv{
+		getenv: func(name string) string { return env[name] },
+		lookPath: func(program string) (string, error) {
+			for _, name := range installed {
+				if name == program {
+					return "/usr/bin/" + program, nil
+				}
+			}
+			return "", errors.New("not found")
+		},
+	}
+}
+
+func providerNames(providers []ClipboardProvider) string {
+	var names []string
+	for _, provider := range providers {
+		if provider.Name() != "system" {
+			names = append(names, provider.Name())
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+func TestClipboardService_Detect(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		installed []string
+		expected  string
+	}{
+		{"headless", map[string]string{}, nil, "file"},
+		{"desktop", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0", "TERM": "xterm"}, []string{"wl-copy", "xclip"}, "wl-copy xclip osc52 file"},
+		{"missing program", map[string]string{"DISPLAY": ":0", "TERM": "xterm"}, []string{"wl-copy"}, "osc52 file"},
+		{"ssh", map[string]string{"SSH_TTY": "/dev/pts/1", "DISPLAY": "localhost:10.0", "TMUX": "/tmp/tmux", "TERM": "tmux-256color"}, []string{"xclip", "tmux"}, "tmux osc52 file"},
+		{"dumb terminal over ssh", map[string]string{"SSH_CONNECTION": "10.0.0.1 22", "TERM": "dumb"}, nil, "file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := newClipboardService(newTestClipboardEnv(tt.env, tt.installed...), "auto", "")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if names := providerNames(service.Providers()); names != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestClipboardService_Setting(t *testing.T) {
+	env := newTestClipboardEnv(map[string]string{})
+
+	service, err := newClipboardService(env, "tmux", "")
+	if err != nil || providerNames(service.Providers()) != "tmux" {
+		t.Errorf("Expected the chosen provider alone, got %v (%v)", service, err)
+	}
+	if _, err := newClipboardService(env, "pasteboard", ""); err == nil {
+		t.Error("Expected error for an unknown provider")
+	}
+}
+
+func TestClipboardService_Copy(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "clipboard.txt")
+	var terminal bytes.Buffer
+	env := newTestClipboardEnv(map[string]string{"SSH_TTY": "/dev/pts/1", "TERM": "xterm"})
+
+	service, _ := newClipboardService(env, "auto", filePath)
+	if _, err := service.Copy("ls", nil); !errors.Is(err, ErrNeedsTerminal) {
+		t.Fatalf("Expected OSC 52 to need the terminal, got %v", err)
+	}
+
+	provider, err := service.Copy("make deploy", failingWriter{})
+	if err != nil || provider.Name() != "file" {
+		t.Fatalf("Expected the file to be used when the terminal cannot be written, got %v (%v)", provider, err)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != "make deploy" {
+		t.Errorf("Expected the text in the file, got %q", content)
+	}
+
+	if provider, err := service.Copy("ls", &terminal); err != nil || provider.Name() != "osc52" {
+		t.Fatalf("Expected OSC 52 to be used, got %v (%v)", provider, err)
+	}
+	if terminal.String() != "\x1b]52;c;bHM=\a" {
+		t.Errorf("Expected an OSC 52 sequence, got %q", terminal.String())
+	}
+
+	service = &ClipboardService{}
+	if _, err := service.Copy("ls", nil); err == nil {
+		t.Error("Expected error without providers")
+	}
+}
+
+func TestTerminalCopy(t *testing.T) {
+	env := newTestClipboardEnv(map[string]string{"SSH_TTY": "/dev/pts/1", "TERM": "xterm"})
+	service, _ := newClipboardService(env, "auto", filepath.Join(t.TempDir(), "clipboard.txt"))
+
+	terminalCopy := NewTerminalCopy(service, "ls")
+	if err := terminalCopy.Run(); !errors.Is(err, ErrNeedsTerminal) {
+		t.Fatalf("Expected the copy to need the output of the program, got %v", err)
+	}
+
+	var terminal bytes.Buffer
+	terminalCopy.SetStdout(&terminal)
+	if err := terminalCopy.Run(); err != nil || terminalCopy.Provider.Name() != "osc52" {
+		t.Fatalf("Expected OSC 52 to be used, got %v (%v)", terminalCopy.Provider, err)
+	}
+	if terminal.String() != "\x1b]52;c;bHM=\a" {
+		t.Errorf("Expected the sequence on the output of the program, got %q", terminal.String())
+	}
+}
+
+func TestOSC52Sequence_Tmux(t *testing.T) {
+	expected := "\x1bPtmux;\x1b\x1b]52;c;bHM=\a\x1b\\"
+	if sequence := osc52Sequence("ls", true); sequence != expected {
+		t.Errorf("Expected %q, got %q", expected, sequence)
+	}
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/adrg/xdg"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// defaultConfigFile is written by EnsureFile. Its settings match
+// models.DefaultConfig.
+const defaultConfigFile = `# go-workflows configuration
+#
+# Every setting can also be given as an environment variable, such as
+# GO_WORKFLOWS_THEME, or as a command line flag. Flags take precedence over
+# the environment, which takes precedence over this file.
+
+# File the workflows are stored in. Defaults to the XDG data directory.
+# data_file = "~/workflows.json"
+
+# Language of the interface: "en" or "pt-BR". Defaults to the system language.
+# language = "en"
+
+# Color theme: "dark", "light", "high-contrast", a theme defined below or
+# "auto" to pick dark or light from the background of the terminal.
+theme = "auto"
+
+# Folder opened on startup.
+default_folder = "/"
+
+# Where copied commands go: "osc52" (the terminal, also over SSH), "tmux"
+# (the tmux buffer), "wl-copy", "xclip", "system", "file" (clipboard.txt in
+# the state directory) or "auto" to use the first one that works here.
+clipboard = "auto"
+
+# Shell used to run workflows. Defaults to $SHELL.
+# shell = "/bin/bash"
+
+# Number of previous versions of the data file kept on every save.
+backup_retention = 0
+
+# Themes start from the theme named in base and change some of its colors.
+# Colors are ANSI numbers ("205") or hex codes ("#ff5f87"). Available colors:
+# accent, muted, subtle, warning, notification_foreground,
+# notification_background and syntax_command, syntax_keyword, syntax_flag,
+# syntax_string, syntax_variable, syntax_number, syntax_operator,
+# syntax_special and syntax_comment.
+#
+# [themes.mine]
+# base = "dark"
+# accent = "#ff5f87"
+
+# Commands matching a risk rule are flagged in the list, and high risk ones
+# ask for confirmation before they are copied or run. Rules are regular
+# expressions with a level of "medium" or "high". A rule with the ID of a
+# built-in rule replaces it, and disabled turns built-in rules off.
+[risk]
+# disabled = ["sudo"]
+#
+# [[risk.rules]]
+# id = "helm_uninstall"
+# pattern = '\bhelm\s+uninstall\b'
+# level = "high"
+# description = "uninstalls a Helm release"
+
+# Every run of a workflow is recorded in the history, in the XDG state
+# directory. Keeping the last lines of output of each run makes workflows
+# write to a pipe instead of the terminal, which turns off the colors of
+# many programs.
+[history]
+output_lines = 0
+
+[keybindings]
+# Preset the keys below are applied on top of: "default", "vim" or "emacs".
+preset = "default"
+
+[keybindings.keys]
+# help = ["f2"]
+`
+
+// ConfigFileEnv points to a config file other than the one in the XDG
+// config directory.
+const ConfigFileEnv = "GO_WORKFLOWS_CONFIG"
+
+// configEnvVars maps environment variables to the setting they override.
+var configEnvVars = []struct {
+	name  string
+	apply func(config *models.Config, value string) error
+}{
+	{"GO_WORKFLOWS_DATA_FILE", func(c *models.Config, v string) error { c.DataFile = v; return nil }},
+	{"GO_WORKFLOWS_LANGUAGE", func(c *models.Config, v string) error { c.Language = v; return nil }},
+	{"GO_WORKFLOWS_THEME", func(c *models.Config, v string) error { c.Theme = v; return nil }},
+	{"GO_WORKFLOWS_DEFAULT_FOLDER", func(c *models.Config, v string) error { c.DefaultFolder = v; return nil }},
+	{"GO_WORKFLOWS_CLIPBOARD", func(c *models.Config, v string) error { c.Clipboard = v; return nil }},
+	{"GO_WORKFLOWS_SHELL", func(c *models.Config, v string) error { c.Shell = v; return nil }},
+	{"GO_WORKFLOWS_KEYMAP", func(c *models.Config, v string) error { c.Keybindings.Preset = v; return nil }},
+	{"GO_WORKFLOWS_BACKUP_RETENTION", func(c *models.Config, v string) error {
+		retention, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("must be a number: %w", err)
+		}
+		c.BackupRetention = retention
+		return nil
+	}},
+}
+
+// ConfigService resolves the settings of the application. Each setting comes
+// from the first source that sets it: command line flags, environment
+// variables, the config file and finally the defaults.
+type ConfigService struct {
+	filePath   string
+	config     models.Config
+	validation *ValidationService
+}
+
+// NewConfigService finds the config file without reading it: until Load
+// succeeds the settings are the defaults.
+func NewConfigService(appName string, validation *ValidationService) (*ConfigService, error) {
+	filePath := os.Getenv(ConfigFileEnv)
+	if filePath == "" {
+		var err error
+		filePath, err = xdg.ConfigFile(fmt.Sprintf("%s/config.toml", appName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine config file path: %w", err)
+		}
+	}
+
+	return &ConfigService{
+		filePath:   filePath,
+		config:     models.DefaultConfig(),
+		validation: validation,
+	}, nil
+}
+
+// Load reads the config file and the environment on top of the defaults.
+// The settings stay as they were when it fails.
+func (c *ConfigService) Load() error {
+	config := models.DefaultConfig()
+
+	metadata, err := toml.DecodeFile(c.filePath, &config)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file %s: %w", c.filePath, err)
+	}
+	if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = key.String()
+		}
+		return fmt.Errorf("unknown settings in config file %s: %s", c.filePath, strings.Join(keys, ", "))
+	}
+
+	for _, env := range configEnvVars {
+		value, ok := os.LookupEnv(env.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := env.apply(&config, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", env.name, err)
+		}
+	}
+
+	return c.set(config)
+}
+
+// ApplyFlags overrides the settings given on the command line.
+func (c *ConfigService) ApplyFlags(flags models.Config) error {
+	return c.set(c.config.Merge(flags))
+}
+
+func (c *ConfigService) set(config models.Config) error {
+	config.DataFile = expandHome(config.DataFile)
+
+	if err := c.validation.Validate(config); err != nil {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(c.validation.GetValidationErrors(err), "; "))
+	}
+
+	c.config = config
+	return nil
+}
+
+func (c *ConfigService) Config() models.Config {
+	return c.config
+}
+
+func (c *ConfigService) FilePath() string {
+	return c.filePath
+}
+
+// Render encodes the effective settings in the format of the config file.
+func (c *ConfigService) Render() (string, error) {
+	var buffer bytes.Buffer
+	if err := toml.NewEncoder(&buffer).Encode(c.config); err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// EnsureFile writes the default settings to the config file unless it
+// already exists.
+func (c *ConfigService) EnsureFile() error {
+	if _, err := os.Stat(c.filePath); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.filePath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(c.filePath, []byte(defaultConfigFile), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+func expandHome(path string) string {
+	if path == "~" {
+		return xdg.Home
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return filepath.Join(xdg.Home, rest)
+	}
+	return path
+}
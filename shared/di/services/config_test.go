@@ -0,0 +1,151 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+func newTestConfigService(t *testing.T, content string) *ConfigService {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "config.toml")
+	if content != "" {
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+
+	return &ConfigService{
+		filePath:   filePath,
+		validation: NewValidationService(),
+	}
+}
+
+func TestConfigService_Defaults(t *testing.T) {
+	service := newTestConfigService(t, "")
+	if err := service.Load(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if config := service.Config(); config.DefaultFolder != "/" || config.Theme != models.ThemeAuto {
+		t.Errorf("Expected default config, got %+v", config)
+	}
+}
+
+func TestConfigService_Precedence(t *testing.T) {
+	service := newTestConfigService(t, `
+language = "en"
+theme = "dark"
+shell = "/bin/bash"
+backup_retention = 2
+
+[keybindings]
+preset = "vim"
+
+[keybindings.keys]
+delete = ["X"]
+`)
+	t.Setenv("GO_WORKFLOWS_THEME", "light")
+	t.Setenv("GO_WORKFLOWS_SHELL", "/bin/zsh")
+
+	if err := service.Load(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if err := service.ApplyFlags(models.Config{Shell: "/bin/fish"}); err != nil {
+		t.Fatalf("Failed to apply flags: %v", err)
+	}
+
+	config := service.Config()
+	if config.Language != "en" {
+		t.Errorf("Expected language from file, got %q", config.Language)
+	}
+	if config.Theme != "light" {
+		t.Errorf("Expected theme from environment, got %q", config.Theme)
+	}
+	if config.Shell != "/bin/fish" {
+		t.Errorf("Expected shell from flags, got %q", config.Shell)
+	}
+	if config.DefaultFolder != "/" {
+		t.Errorf("Expected default folder from defaults, got %q", config.DefaultFolder)
+	}
+	if config.BackupRetention != 2 {
+		t.Errorf("Expected backup retention 2, got %d", config.BackupRetention)
+	}
+	if config.Keybindings.Preset != "vim" || len(config.Keybindings.Keys["delete"]) != 1 {
+		t.Errorf("Expected keybindings from file, got %+v", config.Keybindings)
+	}
+}
+
+func TestConfigService_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "malformed file", content: "theme = ", wantErr: "failed to read config file"},
+		{name: "unknown setting", content: "[keybindings]\nlanguage = \"en\"", wantErr: "unknown settings in config file"},
+		{name: "unknown language", content: `language = "xx"`, wantErr: "invalid configuration"},
+		{name: "relative default folder", content: `default_folder = "docs"`, wantErr: "invalid configuration"},
+		{name: "invalid theme color", content: "[themes.mine]\naccent = \"pink\"", wantErr: "invalid configuration"},
+		{name: "negative retention", content: `backup_retention = -1`, wantErr: "invalid configuration"},
+		{name: "unknown clipboard", content: `clipboard = "pasteboard"`, wantErr: "invalid configuration"},
+		{name: "negative history output", content: "[history]\noutput_lines = -1", wantErr: "invalid configuration"},
+		{name: "non numeric env", env: map[string]string{"GO_WORKFLOWS_BACKUP_RETENTION": "many"}, wantErr: "invalid GO_WORKFLOWS_BACKUP_RETENTION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			service := newTestConfigService(t, tt.content)
+			err := service.Load()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConfigService_EnsureFileAndRender(t *testing.T) {
+	service := newTestConfigService(t, "")
+	service.filePath = filepath.Join(t.TempDir(), "nested", "config.toml")
+
+	if err := service.EnsureFile(); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+	if err := service.Load(); err != nil {
+		t.Fatalf("Failed to load created config: %v", err)
+	}
+	expected := models.DefaultConfig()
+	expected.Keybindings = models.KeyBindings{Preset: "default", Keys: map[string][]string{}}
+	if !reflect.DeepEqual(service.Config(), expected) {
+		t.Errorf("Expected created config to hold the defaults, got %+v", service.Config())
+	}
+
+	rendered, err := service.Render()
+	if err != nil {
+		t.Fatalf("Failed to render config: %v", err)
+	}
+	if !strings.Contains(rendered, `default_folder = "/"`) {
+		t.Errorf("Expected rendered config to contain the default folder, got:\n%s", rendered)
+	}
+
+	if err := os.WriteFile(service.filePath, []byte(`theme = "dark"`), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := service.EnsureFile(); err != nil {
+		t.Fatalf("Failed to keep config file: %v", err)
+	}
+	data, _ := os.ReadFile(service.filePath)
+	if string(data) != `theme = "dark"` {
+		t.Errorf("Expected existing config to be kept, got %q", data)
+	}
+}
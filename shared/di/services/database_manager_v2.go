@@ -2,6 +2,8 @@ package services
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,6 +30,26 @@ func NewDatabaseManagerV2(persistenceService *PersistenceService, validationServ
 }
 
 func (dm *DatabaseManagerV2) CreateFolder(name, description, parentPath string) (*models.FolderV2, error) {
+	folder, err := dm.addFolder(&dm.database, name, description, parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dm.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save after creating folder: %w", err)
+	}
+
+	for i, f := range dm.database.Folders {
+		if f.Path == folder.Path {
+			return &dm.database.Folders[i], nil
+		}
+	}
+
+	return &folder, nil
+}
+
+// addFolder validates a new folder and adds it to database, without saving.
+func (dm *DatabaseManagerV2) addFolder(database *models.DatabaseV2, name, description, parentPath string) (models.FolderV2, error) {
 	if parentPath == "" {
 		parentPath = "/"
 	}
@@ -43,8 +65,8 @@ func (dm *DatabaseManagerV2) CreateFolder(name, description, parentPath string)
 	}
 
 	if parentPath != "/" {
-		if _, found := dm.database.GetFolderByPath(parentPath); !found {
-			return nil, fmt.Errorf("parent folder %s does not exist", parentPath)
+		if _, found := database.GetFolderByPath(parentPath); !found {
+			return models.FolderV2{}, fmt.Errorf("parent folder %s does not exist", parentPath)
 		}
 	}
 
@@ -61,29 +83,19 @@ func (dm *DatabaseManagerV2) CreateFolder(name, description, parentPath string)
 		DateAdded:   time.Now(),
 		DateUpdated: time.Now(),
 		Metadata:    make(map[string]string),
+		Position:    database.NextPosition(parentPath),
 	}
 	folder.GenerateID()
 
 	if err := dm.validationService.Validate(folder); err != nil {
 		validationErrors := dm.validationService.GetValidationErrors(err)
-		return nil, fmt.Errorf("validation failed: %s", strings.Join(validationErrors, ", "))
-	}
-
-	if err := dm.database.AddFolder(folder); err != nil {
-		return nil, err
-	}
-
-	if err := dm.Save(); err != nil {
-		return nil, fmt.Errorf("failed to save after creating folder: %w", err)
+		return models.FolderV2{}, fmt.Errorf("validation failed: %s", strings.Join(validationErrors, ", "))
 	}
 
-	for i, f := range dm.database.Folders {
-		if f.Path == folderPath {
-			return &dm.database.Folders[i], nil
-		}
+	if err := database.AddFolder(folder); err != nil {
+		return models.FolderV2{}, err
 	}
-
-	return &folder, nil
+	return folder, nil
 }
 
 func (dm *DatabaseManagerV2) GetFolder(path string) (*models.FolderV2, error) {
@@ -107,6 +119,170 @@ func (dm *DatabaseManagerV2) GetFolderContents(folderPath string) ([]models.Fold
 	return subfolders, items, nil
 }
 
+func (dm *DatabaseManagerV2) UpdateFolder(path, name, description string) (*models.FolderV2, error) {
+	currentFolder, found := dm.database.GetFolderByPath(path)
+	if !found {
+		return nil, fmt.Errorf("folder %s not found", path)
+	}
+
+	database := dm.database.Clone()
+	newPath := path
+	if name != currentFolder.Name {
+		var err error
+		database, newPath, err = dm.relocateFolder(database, path, parentOf(*currentFolder), name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	folder, _ := database.GetFolderByPath(newPath)
+	folder.Description = description
+	folder.DateUpdated = time.Now()
+
+	if err := dm.validationService.Validate(*folder); err != nil {
+		validationErrors := dm.validationService.GetValidationErrors(err)
+		return nil, fmt.Errorf("validation failed: %s", strings.Join(validationErrors, ", "))
+	}
+
+	dm.database = database
+	if err := dm.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save after updating folder: %w", err)
+	}
+
+	return dm.GetFolder(newPath)
+}
+
+// RenameFolder changes the name of the folder at path, rewriting the paths of
+// every descendant folder and item in a single save.
+func (dm *DatabaseManagerV2) RenameFolder(path, newName string) (*models.FolderV2, error) {
+	currentFolder, found := dm.database.GetFolderByPath(path)
+	if !found {
+		return nil, fmt.Errorf("folder %s not found", path)
+	}
+
+	return dm.commitRelocation(path, parentOf(*currentFolder), newName)
+}
+
+// MoveFolder moves the folder at path, together with its whole subtree, under
+// newParentPath in a single save.
+func (dm *DatabaseManagerV2) MoveFolder(path, newParentPath string) (*models.FolderV2, error) {
+	currentFolder, found := dm.database.GetFolderByPath(path)
+	if !found {
+		return nil, fmt.Errorf("folder %s not found", path)
+	}
+
+	return dm.commitRelocation(path, newParentPath, currentFolder.Name)
+}
+
+func (dm *DatabaseManagerV2) commitRelocation(path, newParentPath, newName string) (*models.FolderV2, error) {
+	database, newPath, err := dm.relocateFolder(dm.database.Clone(), path, newParentPath, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	dm.database = database
+	if err := dm.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save after relocating folder: %w", err)
+	}
+
+	return dm.GetFolder(newPath)
+}
+
+// relocateFolder rewrites the folder at path to live at newParentPath/newName
+// on the given database copy. The copy is only returned when every rewritten
+// path is valid, so callers can discard it on error.
+func (dm *DatabaseManagerV2) relocateFolder(database models.DatabaseV2, path, newParentPath, newName string) (models.DatabaseV2, string, error) {
+	if path == "/" || path == "" {
+		return database, "", fmt.Errorf("cannot relocate root folder")
+	}
+	if newName == "" {
+		return database, "", fmt.Errorf("folder name cannot be empty")
+	}
+	if strings.Contains(newName, "/") {
+		return database, "", fmt.Errorf("folder name %q cannot contain '/'", newName)
+	}
+
+	if newParentPath == "" {
+		newParentPath = "/"
+	}
+	if newParentPath != "/" {
+		if _, found := database.GetFolderByPath(newParentPath); !found {
+			return database, "", fmt.Errorf("destination folder %s does not exist", newParentPath)
+		}
+	}
+	if newParentPath == path || strings.HasPrefix(newParentPath, path+"/") {
+		return database, "", fmt.Errorf("cannot move folder %s into itself or one of its subfolders", path)
+	}
+
+	newPath := strings.TrimSuffix(newParentPath, "/") + "/" + newName
+	if newPath == path {
+		return database, newPath, nil
+	}
+	if _, exists := database.GetFolderByPath(newPath); exists {
+		return database, "", fmt.Errorf("folder with path %s already exists", newPath)
+	}
+
+	normalizedParentPath := newParentPath
+	if newParentPath == "/" {
+		normalizedParentPath = ""
+	}
+	nextPosition := database.NextPosition(newParentPath)
+
+	now := time.Now()
+	for i, folder := range database.Folders {
+		switch {
+		case folder.Path == path:
+			if folder.ParentPath != normalizedParentPath {
+				database.Folders[i].Position = nextPosition
+			}
+			database.Folders[i].Name = newName
+			database.Folders[i].Path = newPath
+			database.Folders[i].ParentPath = normalizedParentPath
+			database.Folders[i].DateUpdated = now
+		case strings.HasPrefix(folder.Path, path+"/"):
+			database.Folders[i].Path = rebasePath(folder.Path, path, newPath)
+			database.Folders[i].ParentPath = rebasePath(folder.ParentPath, path, newPath)
+		default:
+			continue
+		}
+
+		if err := dm.validationService.Validate(database.Folders[i]); err != nil {
+			validationErrors := dm.validationService.GetValidationErrors(err)
+			return database, "", fmt.Errorf("validation failed for folder %s: %s", database.Folders[i].Path, strings.Join(validationErrors, ", "))
+		}
+	}
+
+	for i, item := range database.Items {
+		if item.FolderPath != path && !strings.HasPrefix(item.FolderPath, path+"/") {
+			continue
+		}
+
+		database.Items[i].FolderPath = rebasePath(item.FolderPath, path, newPath)
+		if err := dm.validationService.ValidateVar(database.Items[i].FolderPath, "required,folder_path"); err != nil {
+			return database, "", fmt.Errorf("validation failed for item %s: invalid folder path %s", item.ID, database.Items[i].FolderPath)
+		}
+	}
+
+	return database, newPath, nil
+}
+
+func rebasePath(path, oldPrefix, newPrefix string) string {
+	if path == oldPrefix {
+		return newPrefix
+	}
+	if strings.HasPrefix(path, oldPrefix+"/") {
+		return newPrefix + strings.TrimPrefix(path, oldPrefix)
+	}
+	return path
+}
+
+func parentOf(folder models.FolderV2) string {
+	if folder.ParentPath == "" {
+		return "/"
+	}
+	return folder.ParentPath
+}
+
 func (dm *DatabaseManagerV2) DeleteFolder(path string, force bool) error {
 	if path == "/" {
 		return fmt.Errorf("cannot delete root folder")
@@ -142,6 +318,23 @@ func (dm *DatabaseManagerV2) DeleteFolder(path string, force bool) error {
 }
 
 func (dm *DatabaseManagerV2) CreateItem(title, description, command, folderPath string, tags []string, metadata map[string]string) (*models.ItemV2, error) {
+	return dm.createItem(models.ItemV2{
+		Title:    title,
+		Desc:     description,
+		Command:  command,
+		Tags:     tags,
+		Metadata: metadata,
+	}, folderPath)
+}
+
+// AddItem creates item, with every setting it has, in the folder at
+// folderPath. It is validated and saved as a whole, so that an item that
+// fails never reaches the file half built.
+func (dm *DatabaseManagerV2) AddItem(item models.ItemV2, folderPath string) (*models.ItemV2, error) {
+	return dm.createItem(item.Clone(), folderPath)
+}
+
+func (dm *DatabaseManagerV2) createItem(item models.ItemV2, folderPath string) (*models.ItemV2, error) {
 	if folderPath == "" {
 		folderPath = "/"
 	}
@@ -152,25 +345,24 @@ func (dm *DatabaseManagerV2) CreateItem(title, description, command, folderPath
 		}
 	}
 
-	if tags == nil {
-		tags = []string{}
+	if item.Tags == nil {
+		item.Tags = []string{}
 	}
-	if metadata == nil {
-		metadata = make(map[string]string)
+	if item.Metadata == nil {
+		item.Metadata = make(map[string]string)
 	}
 
-	item := models.ItemV2{
-		Title:       title,
-		Desc:        description,
-		Command:     command,
-		FolderPath:  folderPath,
-		DateAdded:   time.Now(),
-		DateUpdated: time.Now(),
-		Tags:        tags,
-		Metadata:    metadata,
-	}
+	item.FolderPath = folderPath
+	item.DateAdded = time.Now()
+	item.DateUpdated = time.Now()
+	item.Position = dm.database.NextPosition(folderPath)
 	item.GenerateID()
 
+	item, err := dm.database.LinkIncludes(item)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := dm.validationService.Validate(item); err != nil {
 		validationErrors := dm.validationService.GetValidationErrors(err)
 		return nil, fmt.Errorf("validation failed: %s", strings.Join(validationErrors, ", "))
@@ -184,12 +376,9 @@ func (dm *DatabaseManagerV2) CreateItem(title, description, command, folderPath
 		return nil, fmt.Errorf("failed to save after creating item: %w", err)
 	}
 
-	for idx, i := range dm.database.Items {
-		if i.Title == title && i.FolderPath == folderPath {
-			return &dm.database.Items[idx], nil
-		}
+	if created, found := dm.database.GetItemByID(item.ID); found {
+		return created, nil
 	}
-
 	return &item, nil
 }
 
@@ -225,8 +414,9 @@ func (dm *DatabaseManagerV2) UpdateItem(id string, title, description, command,
 	if command != "" {
 		updatedItem.Command = command
 	}
-	if folderPath != "" {
+	if folderPath != "" && folderPath != currentItem.FolderPath {
 		updatedItem.FolderPath = folderPath
+		updatedItem.Position = dm.database.NextPosition(folderPath)
 	}
 	if tags != nil {
 		updatedItem.Tags = tags
@@ -235,6 +425,11 @@ func (dm *DatabaseManagerV2) UpdateItem(id string, title, description, command,
 		updatedItem.Metadata = metadata
 	}
 
+	updatedItem, err := dm.database.LinkIncludes(updatedItem)
+	if err != nil {
+		return err
+	}
+
 	if err := dm.validationService.Validate(updatedItem); err != nil {
 		validationErrors := dm.validationService.GetValidationErrors(err)
 		return fmt.Errorf("validation failed: %s", strings.Join(validationErrors, ", "))
@@ -247,6 +442,59 @@ func (dm *DatabaseManagerV2) UpdateItem(id string, title, description, command,
 	return dm.Save()
 }
 
+// SetFolderRunSettings sets the defaults the items inside the folder at path
+// inherit, replacing the ones it had.
+func (dm *DatabaseManagerV2) SetFolderRunSettings(path string, settings models.RunSettings) error {
+	currentFolder, found := dm.database.GetFolderByPath(path)
+	if !found {
+		return fmt.Errorf("folder %s not found", path)
+	}
+
+	updatedFolder := currentFolder.Clone()
+	updatedFolder.RunSettings = settings.Clone()
+	updatedFolder.DateUpdated = time.Now()
+
+	if err := dm.validationService.Validate(updatedFolder); err != nil {
+		validationErrors := dm.validationService.GetValidationErrors(err)
+		return fmt.Errorf("validation failed: %s", strings.Join(validationErrors, ", "))
+	}
+
+	*currentFolder = updatedFolder
+	return dm.Save()
+}
+
+// FolderRunSettings returns the run settings the items inside the folder at
+// path inherit: those of the folder, with the ones it leaves empty taken from
+// its parents, the nearest one winning.
+func (dm *DatabaseManagerV2) FolderRunSettings(path string) models.RunSettings {
+	var settings models.RunSettings
+	for path != "" && path != "/" {
+		if folder, found := dm.database.GetFolderByPath(path); found {
+			settings = settings.Inherit(folder.RunSettings)
+		}
+		path = path[:strings.LastIndex(path, "/")]
+	}
+	return settings
+}
+
+// RunSettingsOf returns the settings item runs with, inheriting from the
+// folders it is in.
+func (dm *DatabaseManagerV2) RunSettingsOf(item models.ItemV2) models.RunSettings {
+	return item.RunSettings.Inherit(dm.FolderRunSettings(item.FolderPath))
+}
+
+// ExpandIncludes returns item ready to run on p, with the commands of the
+// workflows it includes in place of its includes.
+func (dm *DatabaseManagerV2) ExpandIncludes(item models.ItemV2, p models.Platform) (models.ItemV2, error) {
+	return dm.database.ExpandIncludes(item, p)
+}
+
+// DescribeIncludes returns item with its includes naming workflows by path
+// instead of ID, and the first include that cannot be expanded.
+func (dm *DatabaseManagerV2) DescribeIncludes(item models.ItemV2) (models.ItemV2, error) {
+	return dm.database.DescribeIncludes(item), dm.database.CheckIncludes(item)
+}
+
 func (dm *DatabaseManagerV2) DeleteItem(id string) error {
 	if err := dm.database.DeleteItem(id); err != nil {
 		return err
@@ -268,6 +516,9 @@ func (dm *DatabaseManagerV2) MoveItem(id, newFolderPath string) error {
 	}
 
 	updatedItem := *currentItem
+	if updatedItem.FolderPath != newFolderPath {
+		updatedItem.Position = dm.database.NextPosition(newFolderPath)
+	}
 	updatedItem.FolderPath = newFolderPath
 	updatedItem.DateUpdated = time.Now()
 
@@ -278,6 +529,442 @@ func (dm *DatabaseManagerV2) MoveItem(id, newFolderPath string) error {
 	return dm.Save()
 }
 
+// DuplicateItem copies the item with the given ID into destinationPath under a
+// fresh ID. The copy's title gets a suffix when it collides with an existing
+// item in the destination folder.
+func (dm *DatabaseManagerV2) DuplicateItem(id, destinationPath string) (*models.ItemV2, error) {
+	database := dm.database.Clone()
+
+	newItem, err := dm.copyItem(&database, id, destinationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dm.database = database
+	if err := dm.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save after duplicating item: %w", err)
+	}
+
+	return dm.GetItem(newItem.ID)
+}
+
+// CopyFolder deep-copies the folder at path, including every descendant folder
+// and item, into destinationParentPath. All copies get fresh IDs and the new
+// top-level folder gets a suffix when its name is already taken.
+func (dm *DatabaseManagerV2) CopyFolder(path, destinationParentPath string) (*models.FolderV2, error) {
+	database := dm.database.Clone()
+
+	newFolder, err := dm.copyFolder(&database, path, destinationParentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dm.database = database
+	if err := dm.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save after copying folder: %w", err)
+	}
+
+	return dm.GetFolder(newFolder.Path)
+}
+
+// PasteEntries copies or moves the given items and folders into
+// destinationPath and saves once. Copies resolve name collisions with a
+// suffix, while moves keep their names and fail on folder collisions.
+func (dm *DatabaseManagerV2) PasteEntries(itemIDs, folderPaths []string, destinationPath string, move bool) error {
+	database := dm.database.Clone()
+	if err := dm.pasteEntries(&database, itemIDs, folderPaths, destinationPath, move); err != nil {
+		return err
+	}
+
+	dm.database = database
+	return dm.Save()
+}
+
+// PasteEntriesIntoNewFolder creates the folder name inside parentPath and
+// pastes the given items and folders into it, saving once. It returns the
+// new folder.
+func (dm *DatabaseManagerV2) PasteEntriesIntoNewFolder(itemIDs, folderPaths []string, parentPath, name string, move bool) (*models.FolderV2, error) {
+	database := dm.database.Clone()
+	folder, err := dm.addFolder(&database, name, "", parentPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := dm.pasteEntries(&database, itemIDs, folderPaths, folder.Path, move); err != nil {
+		return nil, err
+	}
+
+	dm.database = database
+	if err := dm.Save(); err != nil {
+		return nil, err
+	}
+	return dm.GetFolder(folder.Path)
+}
+
+func (dm *DatabaseManagerV2) pasteEntries(database *models.DatabaseV2, itemIDs, folderPaths []string, destinationPath string, move bool) error {
+	if destinationPath == "" {
+		destinationPath = "/"
+	}
+	if destinationPath != "/" {
+		if _, found := database.GetFolderByPath(destinationPath); !found {
+			return fmt.Errorf("destination folder %s does not exist", destinationPath)
+		}
+	}
+
+	itemIDs, folderPaths = dm.outermostEntries(itemIDs, folderPaths)
+
+	for _, path := range folderPaths {
+		var err error
+		if move {
+			folder, found := database.GetFolderByPath(path)
+			if !found {
+				return fmt.Errorf("folder %s not found", path)
+			}
+			*database, _, err = dm.relocateFolder(*database, path, destinationPath, folder.Name)
+		} else {
+			_, err = dm.copyFolder(database, path, destinationPath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range itemIDs {
+		if move {
+			item, found := database.GetItemByID(id)
+			if !found {
+				return fmt.Errorf("item %s not found", id)
+			}
+			if item.FolderPath != destinationPath {
+				item.Position = database.NextPosition(destinationPath)
+				item.FolderPath = destinationPath
+				item.DateUpdated = time.Now()
+			}
+			continue
+		}
+
+		if _, err := dm.copyItem(database, id, destinationPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reorder sets the manual order of the folders and items directly inside
+// folderPath. ids lists folder and item IDs in their new order; entries left
+// out keep their relative order after the listed ones.
+func (dm *DatabaseManagerV2) Reorder(folderPath string, ids []string) error {
+	if folderPath == "" {
+		folderPath = "/"
+	}
+	if folderPath != "/" {
+		if _, found := dm.database.GetFolderByPath(folderPath); !found {
+			return fmt.Errorf("folder %s does not exist", folderPath)
+		}
+	}
+
+	type sibling struct {
+		id       string
+		position int
+	}
+
+	var siblings []sibling
+	for _, folder := range dm.database.GetSubfolders(folderPath) {
+		siblings = append(siblings, sibling{id: folder.ID, position: folder.Position})
+	}
+	for _, item := range dm.database.GetItemsByFolder(folderPath) {
+		siblings = append(siblings, sibling{id: item.ID, position: item.Position})
+	}
+	sort.SliceStable(siblings, func(i, j int) bool {
+		return siblings[i].position < siblings[j].position
+	})
+
+	positions := make(map[string]int, len(siblings))
+	for _, id := range ids {
+		if !slices.ContainsFunc(siblings, func(s sibling) bool { return s.id == id }) {
+			return fmt.Errorf("entry %s is not inside folder %s", id, folderPath)
+		}
+		if _, duplicate := positions[id]; duplicate {
+			return fmt.Errorf("entry %s is listed more than once", id)
+		}
+		positions[id] = len(positions)
+	}
+	for _, s := range siblings {
+		if _, listed := positions[s.id]; !listed {
+			positions[s.id] = len(positions)
+		}
+	}
+
+	database := dm.database.Clone()
+	for i, folder := range database.Folders {
+		if position, found := positions[folder.ID]; found && folder.IsChildOf(folderPath) {
+			database.Folders[i].Position = position
+		}
+	}
+	for i, item := range database.Items {
+		if position, found := positions[item.ID]; found && item.FolderPath == folderPath {
+			database.Items[i].Position = position
+		}
+	}
+
+	dm.database = database
+	return dm.Save()
+}
+
+// DeleteEntries removes the given items and folders, including everything
+// inside the folders, and saves once.
+func (dm *DatabaseManagerV2) DeleteEntries(itemIDs, folderPaths []string) error {
+	database := dm.database.Clone()
+
+	for _, path := range folderPaths {
+		if path == "/" || path == "" {
+			return fmt.Errorf("cannot delete root folder")
+		}
+		if _, found := database.GetFolderByPath(path); !found {
+			// Already removed together with a selected ancestor.
+			if isInsideAny(path[:strings.LastIndex(path, "/")], folderPaths) {
+				continue
+			}
+			return fmt.Errorf("folder %s not found", path)
+		}
+
+		descendantFolders, descendantItems := database.GetDescendants(path)
+		for _, item := range descendantItems {
+			if err := database.DeleteItem(item.ID); err != nil {
+				return err
+			}
+		}
+		for i := len(descendantFolders) - 1; i >= 0; i-- {
+			if err := database.DeleteFolder(descendantFolders[i].Path); err != nil {
+				return err
+			}
+		}
+		if err := database.DeleteFolder(path); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range itemIDs {
+		item, found := dm.database.GetItemByID(id)
+		if !found {
+			return fmt.Errorf("item %s not found", id)
+		}
+		if _, stillThere := database.GetItemByID(id); !stillThere && isInsideAny(item.FolderPath, folderPaths) {
+			continue
+		}
+		if err := database.DeleteItem(id); err != nil {
+			return err
+		}
+	}
+
+	dm.database = database
+	return dm.Save()
+}
+
+// UpdateTags adds and removes tags on every given item and saves once. Tags
+// are compared case-insensitively and never duplicated.
+func (dm *DatabaseManagerV2) UpdateTags(itemIDs, addTags, removeTags []string) error {
+	database := dm.database.Clone()
+	now := time.Now()
+
+	for _, id := range itemIDs {
+		item, found := database.GetItemByID(id)
+		if !found {
+			return fmt.Errorf("item %s not found", id)
+		}
+
+		tags := []string{}
+		for _, tag := range item.Tags {
+			if !containsFold(removeTags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		for _, tag := range addTags {
+			if !containsFold(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+
+		item.Tags = tags
+		item.DateUpdated = now
+
+		if err := dm.validationService.Validate(*item); err != nil {
+			validationErrors := dm.validationService.GetValidationErrors(err)
+			return fmt.Errorf("validation failed for item %s: %s", item.Title, strings.Join(validationErrors, ", "))
+		}
+	}
+
+	dm.database = database
+	return dm.Save()
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// outermostEntries drops the items and folders inside one of folderPaths,
+// which come along with it.
+func (dm *DatabaseManagerV2) outermostEntries(itemIDs, folderPaths []string) ([]string, []string) {
+	var outerFolders []string
+	for _, path := range folderPaths {
+		if parent := path[:max(strings.LastIndex(path, "/"), 0)]; parent == "" || !isInsideAny(parent, folderPaths) {
+			outerFolders = append(outerFolders, path)
+		}
+	}
+
+	var outerItems []string
+	for _, id := range itemIDs {
+		if item, found := dm.database.GetItemByID(id); !found || !isInsideAny(item.FolderPath, outerFolders) {
+			outerItems = append(outerItems, id)
+		}
+	}
+	return outerItems, outerFolders
+}
+
+func isInsideAny(path string, folderPaths []string) bool {
+	for _, folderPath := range folderPaths {
+		if path == folderPath || strings.HasPrefix(path, folderPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (dm *DatabaseManagerV2) copyItem(database *models.DatabaseV2, id, destinationPath string) (*models.ItemV2, error) {
+	if destinationPath == "" {
+		destinationPath = "/"
+	}
+
+	item, found := database.GetItemByID(id)
+	if !found {
+		return nil, fmt.Errorf("item %s not found", id)
+	}
+
+	newItem := item.Clone()
+	newItem.ID = ""
+	newItem.GenerateID()
+	newItem.FolderPath = destinationPath
+	newItem.Title = uniqueName(item.Title, func(title string) bool {
+		for _, existing := range database.GetItemsByFolder(destinationPath) {
+			if existing.Title == title {
+				return true
+			}
+		}
+		return false
+	})
+	newItem.DateAdded = time.Now()
+	newItem.DateUpdated = newItem.DateAdded
+	newItem.Position = database.NextPosition(destinationPath)
+
+	if err := dm.validationService.Validate(newItem); err != nil {
+		validationErrors := dm.validationService.GetValidationErrors(err)
+		return nil, fmt.Errorf("validation failed: %s", strings.Join(validationErrors, ", "))
+	}
+
+	if err := database.AddItem(newItem); err != nil {
+		return nil, err
+	}
+
+	return &newItem, nil
+}
+
+func (dm *DatabaseManagerV2) copyFolder(database *models.DatabaseV2, path, destinationParentPath string) (*models.FolderV2, error) {
+	if destinationParentPath == "" {
+		destinationParentPath = "/"
+	}
+
+	source, found := database.GetFolderByPath(path)
+	if !found {
+		return nil, fmt.Errorf("folder %s not found", path)
+	}
+	if destinationParentPath != "/" {
+		if _, found := database.GetFolderByPath(destinationParentPath); !found {
+			return nil, fmt.Errorf("destination folder %s does not exist", destinationParentPath)
+		}
+	}
+
+	parentPrefix := strings.TrimSuffix(destinationParentPath, "/")
+	newName := uniqueName(source.Name, func(name string) bool {
+		_, exists := database.GetFolderByPath(parentPrefix + "/" + name)
+		return exists
+	})
+	newPath := parentPrefix + "/" + newName
+
+	// Snapshot the subtree before adding anything so that copying a folder
+	// into one of its own descendants terminates.
+	descendantFolders, descendantItems := database.GetDescendants(path)
+
+	now := time.Now()
+	root := source.Clone()
+	root.ID = ""
+	root.GenerateID()
+	root.Name = newName
+	root.Path = newPath
+	root.ParentPath = parentPrefix
+	root.DateAdded = now
+	root.DateUpdated = now
+	root.Position = database.NextPosition(destinationParentPath)
+
+	newFolders := []models.FolderV2{root}
+	for _, descendant := range descendantFolders {
+		folder := descendant.Clone()
+		folder.ID = ""
+		folder.GenerateID()
+		folder.Path = rebasePath(folder.Path, path, newPath)
+		folder.ParentPath = rebasePath(folder.ParentPath, path, newPath)
+		folder.DateAdded = now
+		folder.DateUpdated = now
+		newFolders = append(newFolders, folder)
+	}
+
+	for _, folder := range newFolders {
+		if err := dm.validationService.Validate(folder); err != nil {
+			validationErrors := dm.validationService.GetValidationErrors(err)
+			return nil, fmt.Errorf("validation failed for folder %s: %s", folder.Path, strings.Join(validationErrors, ", "))
+		}
+		if err := database.AddFolder(folder); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range descendantItems {
+		newItem := item.Clone()
+		newItem.ID = ""
+		newItem.GenerateID()
+		newItem.FolderPath = rebasePath(item.FolderPath, path, newPath)
+		newItem.DateAdded = now
+		newItem.DateUpdated = now
+
+		if err := dm.validationService.Validate(newItem); err != nil {
+			validationErrors := dm.validationService.GetValidationErrors(err)
+			return nil, fmt.Errorf("validation failed for item %s: %s", item.Title, strings.Join(validationErrors, ", "))
+		}
+		if err := database.AddItem(newItem); err != nil {
+			return nil, err
+		}
+	}
+
+	return &root, nil
+}
+
+// uniqueName returns name, or name with a " copy" / " copy N" suffix, such that
+// exists reports false for it.
+func uniqueName(name string, exists func(string) bool) string {
+	if !exists(name) {
+		return name
+	}
+
+	candidate := name + " copy"
+	for n := 2; exists(candidate); n++ {
+		candidate = fmt.Sprintf("%s copy %d", name, n)
+	}
+	return candidate
+}
+
 func (dm *DatabaseManagerV2) Search(criteria models.SearchCriteria) models.SearchResult {
 	return dm.database.Search(criteria)
 }
@@ -346,32 +1033,22 @@ func (dm *DatabaseManagerV2) GetStatistics() map[string]interface{} {
 	return stats
 }
 
-func (dm *DatabaseManagerV2) GetFolderTree() map[string]interface{} {
-	tree := make(map[string]interface{})
-
-	rootFolders := dm.database.GetSubfolders("/")
-	tree["folders"] = dm.buildFolderTree(rootFolders)
-
-	rootItems := dm.database.GetItemsByFolder("/")
-	tree["items"] = rootItems
-
-	return tree
+func (dm *DatabaseManagerV2) GetFolderTree() models.FolderTree {
+	return models.FolderTree{
+		Folders: dm.buildFolderTree(dm.database.GetSubfolders("/")),
+		Items:   dm.database.GetItemsByFolder("/"),
+	}
 }
 
-func (dm *DatabaseManagerV2) buildFolderTree(folders []models.FolderV2) []map[string]interface{} {
-	var tree []map[string]interface{}
+func (dm *DatabaseManagerV2) buildFolderTree(folders []models.FolderV2) []models.FolderNode {
+	var tree []models.FolderNode
 
 	for _, folder := range folders {
-		folderNode := make(map[string]interface{})
-		folderNode["folder"] = folder
-
-		subfolders := dm.database.GetSubfolders(folder.Path)
-		folderNode["subfolders"] = dm.buildFolderTree(subfolders)
-
-		items := dm.database.GetItemsByFolder(folder.Path)
-		folderNode["items"] = items
-
-		tree = append(tree, folderNode)
+		tree = append(tree, models.FolderNode{
+			Folder:     folder,
+			Subfolders: dm.buildFolderTree(dm.database.GetSubfolders(folder.Path)),
+			Items:      dm.database.GetItemsByFolder(folder.Path),
+		})
 	}
 
 	return tree
@@ -2,6 +2,7 @@ package services
 
 import (
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -25,6 +26,15 @@ func createTestDatabaseManager(dataFilePath string) (*DatabaseManagerV2, error)
 	return NewDatabaseManagerV2(persistence, validation)
 }
 
+func createManagerFromFile(dataFilePath string) (*DatabaseManagerV2, error) {
+	persistence := &PersistenceService{
+		dataFilePath: dataFilePath,
+		appName:      "test-app",
+	}
+
+	return NewDatabaseManagerV2(persistence, NewValidationService())
+}
+
 func TestDatabaseManagerV2_CreateFolder(t *testing.T) {
 	tempDir := t.TempDir()
 	testDataFile := filepath.Join(tempDir, "test_db_manager.json")
@@ -409,6 +419,918 @@ func TestDatabaseManagerV2_DeleteFolder(t *testing.T) {
 	}
 }
 
+func TestDatabaseManagerV2_UpdateFolder(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_update_folder.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	if _, err = manager.CreateFolder("scripts", "Scripts folder", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if _, err = manager.CreateFolder("utils", "Utilities", "/scripts"); err != nil {
+		t.Fatalf("Failed to create subfolder: %v", err)
+	}
+	if _, err = manager.CreateFolder("tools", "Tools folder", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	item, err := manager.CreateItem("Nested", "Nested item", "echo nested", "/scripts/utils", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	folder, err := manager.UpdateFolder("/scripts", "scripts", "Updated description")
+	if err != nil {
+		t.Fatalf("Failed to update folder description: %v", err)
+	}
+	if folder.Description != "Updated description" {
+		t.Errorf("Expected updated description, got %q", folder.Description)
+	}
+	if folder.Path != "/scripts" {
+		t.Errorf("Expected path to stay '/scripts', got %q", folder.Path)
+	}
+
+	folder, err = manager.UpdateFolder("/scripts", "bin", "Binaries")
+	if err != nil {
+		t.Fatalf("Failed to rename folder: %v", err)
+	}
+	if folder.Path != "/bin" {
+		t.Errorf("Expected renamed path '/bin', got %q", folder.Path)
+	}
+
+	subfolder, err := manager.GetFolder("/bin/utils")
+	if err != nil {
+		t.Fatalf("Expected subfolder to follow rename: %v", err)
+	}
+	if subfolder.ParentPath != "/bin" {
+		t.Errorf("Expected subfolder parent '/bin', got %q", subfolder.ParentPath)
+	}
+
+	movedItem, err := manager.GetItem(item.ID)
+	if err != nil {
+		t.Fatalf("Failed to get item: %v", err)
+	}
+	if movedItem.FolderPath != "/bin/utils" {
+		t.Errorf("Expected item folder path '/bin/utils', got %q", movedItem.FolderPath)
+	}
+
+	if _, err = manager.UpdateFolder("/bin", "tools", ""); err == nil {
+		t.Error("Expected error when renaming folder onto an existing path")
+	}
+
+	if _, err = manager.UpdateFolder("/bin", "bad/name", ""); err == nil {
+		t.Error("Expected validation error for name containing a slash")
+	}
+
+	if _, err = manager.UpdateFolder("/missing", "x", ""); err == nil {
+		t.Error("Expected error when updating non-existent folder")
+	}
+}
+
+func TestDatabaseManagerV2_RenameFolder(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_rename_folder.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	for _, f := range []struct{ name, parent string }{
+		{"infra", "/"},
+		{"aws", "/infra"},
+		{"iam", "/infra/aws"},
+		{"ops", "/"},
+	} {
+		if _, err := manager.CreateFolder(f.name, "", f.parent); err != nil {
+			t.Fatalf("Failed to create folder %s: %v", f.name, err)
+		}
+	}
+	item, err := manager.CreateItem("List roles", "", "aws iam list-roles", "/infra/aws/iam", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	folder, err := manager.RenameFolder("/infra", "platform")
+	if err != nil {
+		t.Fatalf("Failed to rename folder: %v", err)
+	}
+	if folder.Path != "/platform" || folder.Name != "platform" {
+		t.Errorf("Expected renamed folder at '/platform', got %q (%q)", folder.Path, folder.Name)
+	}
+
+	deep, err := manager.GetFolder("/platform/aws/iam")
+	if err != nil {
+		t.Fatalf("Expected nested folder to be rewritten: %v", err)
+	}
+	if deep.ParentPath != "/platform/aws" {
+		t.Errorf("Expected parent '/platform/aws', got %q", deep.ParentPath)
+	}
+
+	updatedItem, _ := manager.GetItem(item.ID)
+	if updatedItem.FolderPath != "/platform/aws/iam" {
+		t.Errorf("Expected item folder '/platform/aws/iam', got %q", updatedItem.FolderPath)
+	}
+
+	reloaded, err := createManagerFromFile(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to reload database: %v", err)
+	}
+	if _, err := reloaded.GetFolder("/platform/aws"); err != nil {
+		t.Error("Expected renamed subtree to be persisted")
+	}
+
+	if _, err := manager.RenameFolder("/platform", "ops"); err == nil {
+		t.Error("Expected error when renaming onto an existing folder")
+	}
+	if _, err := manager.RenameFolder("/platform", "a/b"); err == nil {
+		t.Error("Expected error for name containing a slash")
+	}
+	if _, err := manager.RenameFolder("/platform", "bad*name"); err == nil {
+		t.Error("Expected validation error for invalid characters")
+	}
+	if _, err := manager.GetFolder("/platform/aws/iam"); err != nil {
+		t.Error("Expected failed renames to leave the database untouched")
+	}
+	if _, err := manager.RenameFolder("/missing", "x"); err == nil {
+		t.Error("Expected error when renaming non-existent folder")
+	}
+}
+
+func TestDatabaseManagerV2_MoveFolder(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_move_folder.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	for _, f := range []struct{ name, parent string }{
+		{"infra", "/"},
+		{"aws", "/infra"},
+		{"iam", "/infra/aws"},
+		{"archive", "/"},
+		{"aws", "/archive"},
+	} {
+		if _, err := manager.CreateFolder(f.name, "", f.parent); err != nil {
+			t.Fatalf("Failed to create folder %s: %v", f.name, err)
+		}
+	}
+	item, err := manager.CreateItem("Whoami", "", "aws sts get-caller-identity", "/infra/aws", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	if _, err := manager.MoveFolder("/infra", "/infra/aws/iam"); err == nil {
+		t.Error("Expected error when moving a folder into its own subtree")
+	}
+	if _, err := manager.MoveFolder("/infra", "/infra"); err == nil {
+		t.Error("Expected error when moving a folder into itself")
+	}
+	if _, err := manager.MoveFolder("/infra/aws", "/archive"); err == nil {
+		t.Error("Expected error when destination already has a folder with the same name")
+	}
+	if _, err := manager.MoveFolder("/infra/aws", "/missing"); err == nil {
+		t.Error("Expected error when destination does not exist")
+	}
+
+	folder, err := manager.MoveFolder("/infra/aws/iam", "/")
+	if err != nil {
+		t.Fatalf("Failed to move folder to root: %v", err)
+	}
+	if folder.Path != "/iam" || folder.ParentPath != "" {
+		t.Errorf("Expected '/iam' with empty parent, got %q with parent %q", folder.Path, folder.ParentPath)
+	}
+
+	folder, err = manager.MoveFolder("/infra", "/archive/aws")
+	if err != nil {
+		t.Fatalf("Failed to move folder: %v", err)
+	}
+	if folder.Path != "/archive/aws/infra" {
+		t.Errorf("Expected '/archive/aws/infra', got %q", folder.Path)
+	}
+
+	movedItem, _ := manager.GetItem(item.ID)
+	if movedItem.FolderPath != "/archive/aws/infra/aws" {
+		t.Errorf("Expected item folder '/archive/aws/infra/aws', got %q", movedItem.FolderPath)
+	}
+
+	if issues := manager.ValidateDatabase(); len(issues) > 0 {
+		t.Errorf("Expected consistent database after move, got issues: %v", issues)
+	}
+}
+
+func TestDatabaseManagerV2_DuplicateItem(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_duplicate_item.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	if _, err := manager.CreateFolder("other", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	item, err := manager.CreateItem("Deploy", "Deploy app", "make deploy", "/", []string{"ops"}, map[string]string{"env": "prod"})
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	first, err := manager.DuplicateItem(item.ID, "/")
+	if err != nil {
+		t.Fatalf("Failed to duplicate item: %v", err)
+	}
+	if first.ID == item.ID {
+		t.Error("Expected duplicate to get a fresh ID")
+	}
+	if first.Title != "Deploy copy" {
+		t.Errorf("Expected title 'Deploy copy', got %q", first.Title)
+	}
+	if first.Command != item.Command || first.Metadata["env"] != "prod" || len(first.Tags) != 1 {
+		t.Error("Expected duplicate to keep command, tags and metadata")
+	}
+
+	second, err := manager.DuplicateItem(item.ID, "/")
+	if err != nil {
+		t.Fatalf("Failed to duplicate item again: %v", err)
+	}
+	if second.Title != "Deploy copy 2" {
+		t.Errorf("Expected title 'Deploy copy 2', got %q", second.Title)
+	}
+
+	elsewhere, err := manager.DuplicateItem(item.ID, "/other")
+	if err != nil {
+		t.Fatalf("Failed to duplicate item into other folder: %v", err)
+	}
+	if elsewhere.Title != "Deploy" || elsewhere.FolderPath != "/other" {
+		t.Errorf("Expected 'Deploy' in '/other', got %q in %q", elsewhere.Title, elsewhere.FolderPath)
+	}
+
+	if _, err := manager.DuplicateItem("missing", "/"); err == nil {
+		t.Error("Expected error when duplicating non-existent item")
+	}
+}
+
+func TestDatabaseManagerV2_CopyFolder(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_copy_folder.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	for _, f := range []struct{ name, parent string }{
+		{"infra", "/"},
+		{"aws", "/infra"},
+		{"archive", "/"},
+	} {
+		if _, err := manager.CreateFolder(f.name, "", f.parent); err != nil {
+			t.Fatalf("Failed to create folder %s: %v", f.name, err)
+		}
+	}
+	original, err := manager.CreateItem("Whoami", "", "aws sts get-caller-identity", "/infra/aws", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	copied, err := manager.CopyFolder("/infra", "/")
+	if err != nil {
+		t.Fatalf("Failed to copy folder in place: %v", err)
+	}
+	if copied.Path != "/infra copy" {
+		t.Errorf("Expected '/infra copy', got %q", copied.Path)
+	}
+
+	_, copiedItems, err := manager.GetFolderContents("/infra copy/aws")
+	if err != nil {
+		t.Fatalf("Expected copied subfolder to exist: %v", err)
+	}
+	if len(copiedItems) != 1 || copiedItems[0].ID == original.ID {
+		t.Errorf("Expected one copied item with a fresh ID, got %+v", copiedItems)
+	}
+
+	if _, err := manager.CopyFolder("/infra", "/archive"); err != nil {
+		t.Fatalf("Failed to copy folder into archive: %v", err)
+	}
+	if _, err := manager.GetFolder("/archive/infra/aws"); err != nil {
+		t.Error("Expected nested copy under '/archive/infra/aws'")
+	}
+
+	if _, err := manager.CopyFolder("/infra", "/infra/aws"); err != nil {
+		t.Fatalf("Failed to copy folder into its own subtree: %v", err)
+	}
+	if _, err := manager.GetFolder("/infra/aws/infra/aws"); err != nil {
+		t.Error("Expected snapshot copy under '/infra/aws/infra/aws'")
+	}
+	if _, err := manager.GetFolder("/infra/aws/infra/aws/infra"); err == nil {
+		t.Error("Expected copy into own subtree to stop after one level")
+	}
+
+	ids := make(map[string]bool)
+	db := manager.GetDatabase()
+	for _, folder := range db.Folders {
+		if ids[folder.ID] {
+			t.Errorf("Duplicate folder ID %s", folder.ID)
+		}
+		ids[folder.ID] = true
+	}
+	for _, item := range db.Items {
+		if ids[item.ID] {
+			t.Errorf("Duplicate item ID %s", item.ID)
+		}
+		ids[item.ID] = true
+	}
+
+	if issues := manager.ValidateDatabase(); len(issues) > 0 {
+		t.Errorf("Expected consistent database after copies, got issues: %v", issues)
+	}
+}
+
+func TestDatabaseManagerV2_PasteEntries(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_paste_entries.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	for _, name := range []string{"src", "dst"} {
+		if _, err := manager.CreateFolder(name, "", "/"); err != nil {
+			t.Fatalf("Failed to create folder %s: %v", name, err)
+		}
+	}
+	if _, err := manager.CreateFolder("nested", "", "/src"); err != nil {
+		t.Fatalf("Failed to create nested folder: %v", err)
+	}
+	item, err := manager.CreateItem("Build", "", "make", "/src", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	if err := manager.PasteEntries([]string{item.ID}, []string{"/src/nested"}, "/dst", false); err != nil {
+		t.Fatalf("Failed to paste copies: %v", err)
+	}
+	_, srcItems, _ := manager.GetFolderContents("/src")
+	dstFolders, dstItems, _ := manager.GetFolderContents("/dst")
+	if len(srcItems) != 1 || len(dstItems) != 1 || len(dstFolders) != 1 {
+		t.Errorf("Expected copies to leave sources in place, got %d src items, %d dst items, %d dst folders",
+			len(srcItems), len(dstItems), len(dstFolders))
+	}
+
+	if err := manager.PasteEntries([]string{item.ID}, nil, "/dst", true); err != nil {
+		t.Fatalf("Failed to paste cut item: %v", err)
+	}
+	movedItem, _ := manager.GetItem(item.ID)
+	if movedItem.FolderPath != "/dst" {
+		t.Errorf("Expected cut item to move to '/dst', got %q", movedItem.FolderPath)
+	}
+
+	if err := manager.PasteEntries(nil, []string{"/src/nested"}, "/dst", true); err == nil {
+		t.Error("Expected error when moving a folder onto an existing name")
+	}
+	if _, err := manager.GetFolder("/src/nested"); err != nil {
+		t.Error("Expected failed paste to leave the database untouched")
+	}
+
+	if err := manager.PasteEntries(nil, []string{"/src"}, "/missing", false); err == nil {
+		t.Error("Expected error when pasting into a non-existent folder")
+	}
+}
+
+func TestDatabaseManagerV2_PasteEntriesIntoNewFolder(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_paste_new_folder.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+	if _, err := manager.CreateFolder("src", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	item, err := manager.CreateItem("Build", "", "make", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	itemID := item.ID
+
+	if _, err := manager.PasteEntriesIntoNewFolder([]string{itemID, "missing"}, nil, "/", "broken", true); err == nil {
+		t.Error("Expected error when moving a missing item")
+	}
+	if _, err := manager.GetFolder("/broken"); err == nil {
+		t.Error("Expected a failed move to leave no folder behind")
+	}
+
+	folder, err := manager.PasteEntriesIntoNewFolder([]string{itemID}, []string{"/src"}, "/", "ci", true)
+	if err != nil {
+		t.Fatalf("Failed to move into a new folder: %v", err)
+	}
+	reloaded, err := createManagerFromFile(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to reload database: %v", err)
+	}
+	folders, items, _ := reloaded.GetFolderContents(folder.Path)
+	if folder.Path != "/ci" || len(folders) != 1 || len(items) != 1 {
+		t.Errorf("Expected the entries saved in /ci, got %s with %d folders and %d items", folder.Path, len(folders), len(items))
+	}
+}
+
+func TestDatabaseManagerV2_PasteNestedEntries(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_paste_nested_entries.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	for _, folder := range [][2]string{{"a", "/"}, {"b", "/a"}, {"copies", "/"}, {"moved", "/"}} {
+		if _, err := manager.CreateFolder(folder[0], "", folder[1]); err != nil {
+			t.Fatalf("Failed to create folder %s: %v", folder[0], err)
+		}
+	}
+	item, err := manager.CreateItem("Build", "", "make", "/a/b", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	itemID := item.ID
+
+	if err := manager.PasteEntries([]string{itemID}, []string{"/a", "/a/b"}, "/copies", false); err != nil {
+		t.Fatalf("Failed to copy a folder with its subfolder: %v", err)
+	}
+	copiedFolders, copiedItems, _ := manager.GetFolderContents("/copies")
+	nestedFolders, nestedItems, _ := manager.GetFolderContents("/copies/a/b")
+	if len(copiedFolders) != 1 || len(copiedItems) != 0 || len(nestedFolders) != 0 || len(nestedItems) != 1 {
+		t.Errorf("Expected a single copy of the tree, got %d folders and %d items at the top, %d and %d in b",
+			len(copiedFolders), len(copiedItems), len(nestedFolders), len(nestedItems))
+	}
+
+	if err := manager.PasteEntries([]string{itemID}, []string{"/a/b", "/a"}, "/moved", true); err != nil {
+		t.Fatalf("Failed to move a folder with its subfolder: %v", err)
+	}
+	if _, err := manager.GetFolder("/moved/a/b"); err != nil {
+		t.Errorf("Expected the subfolder to move with its parent: %v", err)
+	}
+	if moved, _ := manager.GetItem(itemID); moved.FolderPath != "/moved/a/b" {
+		t.Errorf("Expected the item to move with its folder, got %q", moved.FolderPath)
+	}
+}
+
+func TestDatabaseManagerV2_DeleteEntries(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_delete_entries.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	for _, f := range []struct{ name, parent string }{
+		{"old", "/"},
+		{"nested", "/old"},
+		{"keep", "/"},
+	} {
+		if _, err := manager.CreateFolder(f.name, "", f.parent); err != nil {
+			t.Fatalf("Failed to create folder %s: %v", f.name, err)
+		}
+	}
+	nestedItem, err := manager.CreateItem("Nested", "", "echo nested", "/old/nested", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	rootItem, err := manager.CreateItem("Root", "", "echo root", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	keptItem, err := manager.CreateItem("Kept", "", "echo kept", "/keep", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	err = manager.DeleteEntries([]string{rootItem.ID, nestedItem.ID}, []string{"/old", "/old/nested"})
+	if err != nil {
+		t.Fatalf("Failed to delete entries: %v", err)
+	}
+
+	db := manager.GetDatabase()
+	if len(db.Folders) != 1 || db.Folders[0].Path != "/keep" {
+		t.Errorf("Expected only '/keep' to remain, got %+v", db.Folders)
+	}
+	if len(db.Items) != 1 || db.Items[0].ID != keptItem.ID {
+		t.Errorf("Expected only the kept item to remain, got %+v", db.Items)
+	}
+
+	if err := manager.DeleteEntries([]string{"missing"}, nil); err == nil {
+		t.Error("Expected error when deleting non-existent item")
+	}
+	if err := manager.DeleteEntries(nil, []string{"/"}); err == nil {
+		t.Error("Expected error when deleting root folder")
+	}
+	if len(manager.GetDatabase().Items) != 1 {
+		t.Error("Expected failed bulk delete to leave the database untouched")
+	}
+}
+
+func TestDatabaseManagerV2_UpdateTags(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_update_tags.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	first, err := manager.CreateItem("First", "", "echo 1", "/", []string{"old", "Shared"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	second, err := manager.CreateItem("Second", "", "echo 2", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	if err := manager.UpdateTags([]string{first.ID, second.ID}, []string{"shared", "new"}, []string{"OLD"}); err != nil {
+		t.Fatalf("Failed to update tags: %v", err)
+	}
+
+	updatedFirst, _ := manager.GetItem(first.ID)
+	if strings.Join(updatedFirst.Tags, ",") != "Shared,new" {
+		t.Errorf("Expected tags 'Shared,new', got %v", updatedFirst.Tags)
+	}
+	updatedSecond, _ := manager.GetItem(second.ID)
+	if strings.Join(updatedSecond.Tags, ",") != "shared,new" {
+		t.Errorf("Expected tags 'shared,new', got %v", updatedSecond.Tags)
+	}
+
+	if err := manager.UpdateTags([]string{first.ID}, []string{"bad#tag"}, nil); err == nil {
+		t.Error("Expected validation error for invalid tag")
+	}
+	unchanged, _ := manager.GetItem(first.ID)
+	if len(unchanged.Tags) != 2 {
+		t.Error("Expected failed tag update to leave the item untouched")
+	}
+}
+
+func TestDatabaseManagerV2_AddItem(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_add_item.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	if _, err := manager.CreateFolder("ops", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	auth, err := manager.CreateItem("Auth", "", "vault login", "/ops", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	authID := auth.ID
+
+	variables := []models.Variable{{Name: "path", Type: models.VariableString, Default: ".", Required: true}}
+	item, err := manager.AddItem(models.ItemV2{
+		Title:       "Open",
+		Command:     "xdg-open {{path}}",
+		Language:    models.LanguageShell,
+		Variables:   variables,
+		Variants:    []models.Variant{{OS: "darwin", Command: "{{include /ops/Auth}} && open {{path}}"}},
+		RunSettings: models.RunSettings{WorkingDir: "~/work", Env: map[string]string{"STAGE": "prod"}},
+	}, "/ops")
+	if err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	steps := []models.Step{
+		{Title: "Build", Command: "make build"},
+		{Title: "Test", Command: "make test", ContinueOnError: true},
+	}
+	release, err := manager.AddItem(models.ItemV2{Title: "Release", Desc: "Builds and tests", Steps: steps}, "/")
+	if err != nil {
+		t.Fatalf("Failed to add multi-step item: %v", err)
+	}
+	query, err := manager.AddItem(models.ItemV2{Title: "Query", Command: "SELECT * FROM users"}, "/")
+	if err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	if query.GetLanguage() != models.LanguageSQL {
+		t.Errorf("Expected detected language %q, got %q", models.LanguageSQL, query.GetLanguage())
+	}
+
+	reloaded, err := createManagerFromFile(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to reload database: %v", err)
+	}
+	saved, err := reloaded.GetItem(item.ID)
+	if err != nil {
+		t.Fatalf("Expected the item to be saved: %v", err)
+	}
+	if saved.FolderPath != "/ops" || saved.Language != models.LanguageShell || saved.RunSettings.Env["STAGE"] != "prod" {
+		t.Errorf("Expected every setting to be saved, got %+v", saved)
+	}
+	if !reflect.DeepEqual(saved.Variables, variables) {
+		t.Errorf("Expected saved variables %+v, got %+v", variables, saved.Variables)
+	}
+	if saved.Variants[0].Command != "{{include "+authID+"}} && open {{path}}" {
+		t.Errorf("Expected the include of the variant to name an ID, got %q", saved.Variants[0].Command)
+	}
+	savedRelease, err := reloaded.GetItem(release.ID)
+	if err != nil {
+		t.Fatalf("Expected the multi-step item to be saved: %v", err)
+	}
+	if !savedRelease.IsMultiStep() || savedRelease.Command != "" || len(savedRelease.Steps) != 2 || !savedRelease.Steps[1].ContinueOnError {
+		t.Errorf("Expected saved steps %+v, got %+v", steps, savedRelease)
+	}
+
+	tests := []struct {
+		name          string
+		item          models.ItemV2
+		errorContains string
+	}{
+		{"unknown language", models.ItemV2{Command: "ls", Language: "cobol"}, "validation failed"},
+		{"step without a command", models.ItemV2{Steps: []models.Step{{Title: "Build"}}}, "validation failed"},
+		{"no command or steps", models.ItemV2{}, "validation failed"},
+		{"invalid variable default", models.ItemV2{Command: "kubectl scale --replicas={{replicas}}",
+			Variables: []models.Variable{{Name: "replicas", Type: models.VariableInt, Default: "many"}}}, "validation failed"},
+		{"unknown system", models.ItemV2{Command: "xdg-open .", Variants: []models.Variant{{OS: "plan9", Command: "open ."}}}, "validation failed"},
+		{"relative directory", models.ItemV2{Command: "make", RunSettings: models.RunSettings{WorkingDir: "relative"}}, "validation failed"},
+		// A dangling include in a variant used to leave the item behind
+		// without its variants.
+		{"dangling include in a variant", models.ItemV2{Command: "ls",
+			Variants: []models.Variant{{OS: "darwin", Command: "{{include /ops/missing}}"}}}, "no workflow has this ID or path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.item.Title = "Broken"
+			if _, err := manager.AddItem(tt.item, "/"); err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+
+	reloaded, err = createManagerFromFile(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to reload database: %v", err)
+	}
+	if items := len(reloaded.GetDatabase().Items); items != 4 || len(manager.GetDatabase().Items) != 4 {
+		t.Errorf("Expected no half built item, got %d saved items", items)
+	}
+	if _, err := manager.AddItem(models.ItemV2{Title: "Orphan", Command: "ls"}, "/missing"); err == nil {
+		t.Error("Expected error for a missing folder")
+	}
+}
+
+func TestDatabaseManagerV2_Includes(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_includes.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	if _, err := manager.CreateFolder("ops", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	auth, err := manager.CreateItem("Auth", "", "vault login", "/ops", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	authID := auth.ID
+	pods, err := manager.CreateItem("Pods", "", "{{include /ops/Auth}}\nkubectl get pods", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item with an include: %v", err)
+	}
+	if pods.Command != "{{include "+authID+"}}\nkubectl get pods" {
+		t.Errorf("Expected the include to be saved by ID, got %q", pods.Command)
+	}
+	podsID := pods.ID
+
+	if err := manager.UpdateItem(authID, "Login", "", "", "", nil, nil); err != nil {
+		t.Fatalf("Failed to rename item: %v", err)
+	}
+	if err := manager.MoveItem(authID, "/"); err != nil {
+		t.Fatalf("Failed to move item: %v", err)
+	}
+	pods, _ = manager.GetItem(podsID)
+	expanded, err := manager.ExpandIncludes(*pods, models.Platform{})
+	if err != nil || expanded.Command != "vault login\nkubectl get pods" {
+		t.Errorf("Expected the include to follow the renamed item, got %q (%v)", expanded.Command, err)
+	}
+	described, err := manager.DescribeIncludes(*pods)
+	if err != nil || described.Command != "{{include /Login}}\nkubectl get pods" {
+		t.Errorf("Expected the include to show the new path, got %q (%v)", described.Command, err)
+	}
+
+	if _, err := manager.CreateItem("Broken", "", "{{include /ops/Auth}}", "/", nil, nil); err == nil || !strings.Contains(err.Error(), "no workflow has this ID or path") {
+		t.Errorf("Expected error for a dangling include, got %v", err)
+	}
+	if err := manager.UpdateItem(authID, "", "", "{{include "+podsID+"}}", "", nil, nil); err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("Expected error for an include cycle, got %v", err)
+	}
+
+	if err := manager.DeleteItem(authID); err != nil {
+		t.Fatalf("Failed to delete item: %v", err)
+	}
+	pods, _ = manager.GetItem(podsID)
+	if _, err := manager.DescribeIncludes(*pods); err == nil || !strings.Contains(err.Error(), authID) {
+		t.Errorf("Expected the deleted item to be reported, got %v", err)
+	}
+}
+
+func TestDatabaseManagerV2_RunSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_run_settings.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	if _, err := manager.CreateFolder("Work", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if _, err := manager.CreateFolder("API", "", "/Work"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	item, err := manager.AddItem(models.ItemV2{
+		Title:       "Deploy",
+		Command:     "make deploy",
+		RunSettings: models.RunSettings{Env: map[string]string{"STAGE": "prod"}},
+	}, "/Work/API")
+	if err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+
+	if err := manager.SetFolderRunSettings("/Work", models.RunSettings{
+		WorkingDir:  "~/work",
+		Interpreter: models.InterpreterZsh,
+		Env:         map[string]string{"STAGE": "dev", "REGION": "eu"},
+	}); err != nil {
+		t.Fatalf("Failed to set folder run settings: %v", err)
+	}
+	if err := manager.SetFolderRunSettings("/Work/API", models.RunSettings{WorkingDir: "~/work/api"}); err != nil {
+		t.Fatalf("Failed to set folder run settings: %v", err)
+	}
+
+	reloaded, err := createManagerFromFile(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to reload database: %v", err)
+	}
+	updated, _ := reloaded.GetItem(item.ID)
+	expected := models.RunSettings{
+		WorkingDir:  "~/work/api",
+		Interpreter: models.InterpreterZsh,
+		Env:         map[string]string{"STAGE": "prod", "REGION": "eu"},
+	}
+	if settings := reloaded.RunSettingsOf(*updated); !reflect.DeepEqual(settings, expected) {
+		t.Errorf("Expected inherited settings %+v, got %+v", expected, settings)
+	}
+
+	if err := manager.SetFolderRunSettings("/Work", models.RunSettings{Interpreter: "ruby"}); err == nil {
+		t.Error("Expected validation error for an unknown interpreter")
+	}
+	if err := manager.SetFolderRunSettings("/Missing", models.RunSettings{}); err == nil {
+		t.Error("Expected error for missing folder")
+	}
+}
+
+func TestDatabaseManagerV2_Reorder(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_reorder.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	folder, err := manager.CreateFolder("steps", "", "/")
+	if err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	first, err := manager.CreateItem("First", "", "echo 1", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	second, err := manager.CreateItem("Second", "", "echo 2", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	nested, err := manager.CreateItem("Nested", "", "echo nested", "/steps", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	if folder.Position != 0 || first.Position != 1 || second.Position != 2 {
+		t.Fatalf("Expected new entries to be appended, got positions %d, %d, %d", folder.Position, first.Position, second.Position)
+	}
+	if nested.Position != 0 {
+		t.Errorf("Expected positions to be counted per folder, got %d", nested.Position)
+	}
+
+	if err := manager.Reorder("/", []string{second.ID, folder.ID}); err != nil {
+		t.Fatalf("Failed to reorder: %v", err)
+	}
+
+	reloaded, err := createManagerFromFile(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to reload database: %v", err)
+	}
+	positions := map[string]int{}
+	for _, f := range reloaded.GetDatabase().Folders {
+		positions[f.ID] = f.Position
+	}
+	for _, item := range reloaded.GetDatabase().Items {
+		positions[item.ID] = item.Position
+	}
+	if positions[second.ID] != 0 || positions[folder.ID] != 1 || positions[first.ID] != 2 {
+		t.Errorf("Expected order second, folder, first; got positions %v", positions)
+	}
+	if positions[nested.ID] != 0 {
+		t.Errorf("Expected entries of other folders to keep their position, got %d", positions[nested.ID])
+	}
+
+	third, err := manager.CreateItem("Third", "", "echo 3", "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	if third.Position != 3 {
+		t.Errorf("Expected new item at the end of the order, got position %d", third.Position)
+	}
+
+	if err := manager.MoveItem(nested.ID, "/"); err != nil {
+		t.Fatalf("Failed to move item: %v", err)
+	}
+	moved, _ := manager.GetItem(nested.ID)
+	if moved.Position != 4 {
+		t.Errorf("Expected moved item at the end of the order, got position %d", moved.Position)
+	}
+
+	if err := manager.Reorder("/", []string{first.ID, first.ID}); err == nil {
+		t.Error("Expected error for duplicate IDs")
+	}
+	if err := manager.Reorder("/steps", []string{first.ID}); err == nil {
+		t.Error("Expected error for an entry outside the folder")
+	}
+	if err := manager.Reorder("/missing", nil); err == nil {
+		t.Error("Expected error for a missing folder")
+	}
+}
+
+func TestDatabaseManagerV2_GetFolderTree(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "test_folder_tree.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+
+	if _, err := manager.CreateFolder("infra", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if _, err := manager.CreateFolder("aws", "", "/infra"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if _, err := manager.CreateFolder("docs", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if _, err := manager.CreateItem("Root", "", "echo root", "/", nil, nil); err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	if _, err := manager.CreateItem("IAM", "", "aws iam list-users", "/infra/aws", nil, nil); err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	tree := manager.GetFolderTree()
+
+	if len(tree.Folders) != 2 {
+		t.Fatalf("Expected 2 root folders, got %d", len(tree.Folders))
+	}
+	if len(tree.Items) != 1 || tree.Items[0].Title != "Root" {
+		t.Errorf("Expected the root item at the top level, got %+v", tree.Items)
+	}
+
+	infra := tree.Folders[0]
+	if infra.Folder.Path != "/infra" || len(infra.Subfolders) != 1 {
+		t.Fatalf("Expected /infra with one subfolder, got %+v", infra)
+	}
+	aws := infra.Subfolders[0]
+	if aws.Folder.Path != "/infra/aws" || len(aws.Items) != 1 || aws.Items[0].Title != "IAM" {
+		t.Errorf("Expected /infra/aws holding IAM, got %+v", aws)
+	}
+	if len(tree.Folders[1].Subfolders) != 0 {
+		t.Errorf("Expected /docs to have no subfolders, got %+v", tree.Folders[1].Subfolders)
+	}
+}
+
 func TestDatabaseManagerV2_MoveItem(t *testing.T) {
 	tempDir := t.TempDir()
 	testDataFile := filepath.Join(tempDir, "test_move_item.json")
@@ -606,3 +1528,39 @@ func TestDatabaseManagerV2_ValidateDatabase(t *testing.T) {
 		t.Error("Expected orphaned item validation issue")
 	}
 }
+
+func TestDatabaseManagerV2_Backups(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "data.json")
+
+	manager, err := createTestDatabaseManager(testDataFile)
+	if err != nil {
+		t.Fatalf("Failed to create database manager: %v", err)
+	}
+	manager.persistenceService.SetBackupRetention(2)
+
+	if _, err := manager.CreateFolder("first", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if _, err := manager.CreateFolder("second", "", "/"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	// Saving what the file already holds keeps the backups.
+	if err := manager.Save(); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	for n, expected := range map[int]string{1: "first", 2: ""} {
+		backup, err := createManagerFromFile(manager.persistenceService.backupPath(n))
+		if err != nil {
+			t.Fatalf("Failed to load backup %d: %v", n, err)
+		}
+		var names []string
+		for _, folder := range backup.GetDatabase().Folders {
+			names = append(names, folder.Name)
+		}
+		if strings.Join(names, ", ") != expected {
+			t.Errorf("Expected backup %d to hold the folders %q, got %v", n, expected, names)
+		}
+	}
+}
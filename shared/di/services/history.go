@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/adrg/xdg"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// HistoryService records every run of a workflow in an append-only file of
+// JSON lines in the state directory, out of the synced data file.
+type HistoryService struct {
+	filePath string
+}
+
+func NewHistoryService(appName string) (*HistoryService, error) {
+	filePath, err := xdg.StateFile(fmt.Sprintf("%s/history.jsonl", appName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine history file path: %w", err)
+	}
+
+	return &HistoryService{filePath: filePath}, nil
+}
+
+func (h *HistoryService) GetFilePath() string {
+	return h.filePath
+}
+
+// Append adds record at the end of the history file.
+func (h *HistoryService) Append(record models.HistoryRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history record: %w", err)
+	}
+
+	// Records hold commands, their output and the values of variables,
+	// which may be secrets, so only the user may read them.
+	file, err := os.OpenFile(h.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	return nil
+}
+
+// List returns up to limit records matching filter, most recent first, or
+// all of them when limit is 0. Lines that cannot be read, such as one cut
+// short by a crash, are skipped.
+func (h *HistoryService) List(filter string, limit int) ([]models.HistoryRecord, error) {
+	file, err := os.Open(h.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	defer file.Close()
+
+	var records []models.HistoryRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var record models.HistoryRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if record.Matches(filter) {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	slices.Reverse(records)
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
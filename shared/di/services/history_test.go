@@ -0,0 +1,86 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+func newTestHistoryService(t *testing.T) *HistoryService {
+	t.Helper()
+	return &HistoryService{filePath: filepath.Join(t.TempDir(), "history.jsonl")}
+}
+
+func TestHistoryService_AppendAndList(t *testing.T) {
+	service := newTestHistoryService(t)
+
+	records, err := service.List("", 0)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("Expected an empty history without a file, got %v (%v)", records, err)
+	}
+
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, record := range []models.HistoryRecord{
+		{ItemID: "item_1", Title: "Build", Command: "make", StartedAt: start},
+		{ItemID: "item_2", Title: "Deploy", Command: "make deploy", Variables: map[string]string{"stage": "prod"}, ExitCode: 2, DurationMS: 1500},
+		{ItemID: "item_1", Title: "Build", Command: "make", Output: "ok"},
+	} {
+		record.StartedAt = start.Add(time.Duration(i) * time.Minute)
+		if err := service.Append(record); err != nil {
+			t.Fatalf("Failed to append: %v", err)
+		}
+	}
+
+	info, err := os.Stat(service.GetFilePath())
+	if err != nil {
+		t.Fatalf("Failed to stat the history file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Expected a history file only the user can read, got %v", perm)
+	}
+
+	records, err = service.List("", 0)
+	if err != nil {
+		t.Fatalf("Failed to list: %v", err)
+	}
+	if len(records) != 3 || records[0].Output != "ok" || records[2].StartedAt != start {
+		t.Fatalf("Expected the records most recent first, got %+v", records)
+	}
+
+	failed, _ := service.List("failed", 0)
+	if len(failed) != 1 || failed[0].Variables["stage"] != "prod" || failed[0].Duration() != 1500*time.Millisecond {
+		t.Errorf("Expected the failed run, got %+v", failed)
+	}
+	if limited, _ := service.List("build", 1); len(limited) != 1 || limited[0].Output != "ok" {
+		t.Errorf("Expected the latest build, got %+v", limited)
+	}
+}
+
+func TestHistoryService_SkipsBrokenLines(t *testing.T) {
+	service := newTestHistoryService(t)
+	if err := service.Append(models.HistoryRecord{ItemID: "item_1"}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+
+	file, err := os.OpenFile(service.GetFilePath(), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("Failed to open history: %v", err)
+	}
+	file.WriteString(`{"item_id": "item_2", "tit` + "\n")
+	file.Close()
+
+	if err := service.Append(models.HistoryRecord{ItemID: "item_3"}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+
+	records, err := service.List("", 0)
+	if err != nil {
+		t.Fatalf("Failed to list: %v", err)
+	}
+	if len(records) != 2 || records[0].ItemID != "item_3" || records[1].ItemID != "item_1" {
+		t.Errorf("Expected the broken line to be skipped, got %+v", records)
+	}
+}
@@ -86,6 +86,13 @@ func (i *I18nService) Translate(key string) string {
 	})
 }
 
+func (i *I18nService) TranslateWithData(key string, data map[string]interface{}) string {
+	return localizerInstance.MustLocalize(&i18n.LocalizeConfig{
+		MessageID:    key,
+		TemplateData: data,
+	})
+}
+
 var i18nContextKey = &struct{}{}
 
 func WithI18n(ctx context.Context, service *I18nService) context.Context {
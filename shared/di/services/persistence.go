@@ -1,9 +1,11 @@
 package services
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/adrg/xdg"
 
@@ -11,8 +13,9 @@ import (
 )
 
 type PersistenceService struct {
-	dataFilePath string
-	appName      string
+	dataFilePath    string
+	appName         string
+	backupRetention int
 }
 
 type DatabaseVersion struct {
@@ -20,9 +23,20 @@ type DatabaseVersion struct {
 }
 
 func NewPersistenceService(appName string) (*PersistenceService, error) {
-	dataFile, err := xdg.DataFile(fmt.Sprintf("%s/data.json", appName))
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine data file path: %w", err)
+	return NewPersistenceServiceWithDataFile(appName, "")
+}
+
+// NewPersistenceServiceWithDataFile stores the data in dataFile, or in the
+// XDG data directory when dataFile is empty.
+func NewPersistenceServiceWithDataFile(appName, dataFile string) (*PersistenceService, error) {
+	var err error
+	if dataFile == "" {
+		dataFile, err = xdg.DataFile(fmt.Sprintf("%s/data.json", appName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine data file path: %w", err)
+		}
+	} else if err := os.MkdirAll(filepath.Dir(dataFile), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
 	err = os.MkdirAll(xdg.ConfigHome+"/"+appName, os.ModePerm)
@@ -40,6 +54,49 @@ func (p *PersistenceService) GetDataFilePath() string {
 	return p.dataFilePath
 }
 
+// SetBackupRetention keeps the last retention versions of the data file each
+// time it is saved. Zero disables backups.
+func (p *PersistenceService) SetBackupRetention(retention int) {
+	p.backupRetention = retention
+}
+
+func (p *PersistenceService) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", p.dataFilePath, n)
+}
+
+// rotateBackups copies the data file to data.json.1, shifting older backups
+// up to data.json.N, where N is the backup retention. Nothing rotates when
+// next, the data about to be saved, is what the file already holds, so that
+// every backup is a different version.
+func (p *PersistenceService) rotateBackups(next []byte) error {
+	if p.backupRetention <= 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.dataFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read data file for backup: %w", err)
+	}
+	if len(data) == 0 || bytes.Equal(data, next) {
+		return nil
+	}
+
+	for n := p.backupRetention - 1; n >= 1; n-- {
+		if err := os.Rename(p.backupPath(n), p.backupPath(n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate backup: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(p.backupPath(1), data, 0o644); err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+
+	return nil
+}
+
 func (p *PersistenceService) detectDatabaseVersion(data []byte) (string, error) {
 	if len(data) == 0 {
 		return "", nil // Empty file, no version
@@ -152,6 +209,10 @@ func (p *PersistenceService) SaveDataV2(data models.DatabaseV2) error {
 		return fmt.Errorf("failed to marshal v2 JSON: %w", err)
 	}
 
+	if err := p.rotateBackups(jsonData); err != nil {
+		return err
+	}
+
 	if err := os.WriteFile(p.dataFilePath, jsonData, 0o644); err != nil {
 		return fmt.Errorf("failed to save v2 file: %w", err)
 	}
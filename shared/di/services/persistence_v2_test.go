@@ -3,6 +3,7 @@ package services
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -448,3 +449,37 @@ func TestPersistenceService_LoadDataV2_NonexistentFile(t *testing.T) {
 		t.Error("Expected file to be created")
 	}
 }
+
+func TestPersistenceService_BackupRetention(t *testing.T) {
+	tempDir := t.TempDir()
+	testDataFile := filepath.Join(tempDir, "data.json")
+
+	service := &PersistenceService{
+		dataFilePath: testDataFile,
+		appName:      "test-app",
+	}
+	service.SetBackupRetention(2)
+
+	for _, title := range []string{"first", "second", "third", "fourth"} {
+		db := models.NewDatabaseV2()
+		if err := db.AddItem(models.ItemV2{ID: title, Title: title, Command: "echo", FolderPath: "/"}); err != nil {
+			t.Fatalf("Failed to add item: %v", err)
+		}
+		if err := service.SaveDataV2(db); err != nil {
+			t.Fatalf("Failed to save data: %v", err)
+		}
+	}
+
+	for n, expected := range map[int]string{1: "third", 2: "second"} {
+		data, err := os.ReadFile(service.backupPath(n))
+		if err != nil {
+			t.Fatalf("Expected backup %d to exist: %v", n, err)
+		}
+		if !strings.Contains(string(data), `"title": "`+expected+`"`) {
+			t.Errorf("Expected backup %d to hold %q, got %s", n, expected, data)
+		}
+	}
+	if _, err := os.Stat(service.backupPath(3)); !os.IsNotExist(err) {
+		t.Error("Expected no more backups than the retention")
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+type compiledRiskRule struct {
+	rule    models.RiskRule
+	pattern *regexp.Regexp
+}
+
+// RiskService classifies how dangerous commands are, using the built-in rules
+// together with the rules of the config file.
+type RiskService struct {
+	rules []compiledRiskRule
+}
+
+func NewRiskService(settings models.RiskSettings) (*RiskService, error) {
+	var rules []models.RiskRule
+	for _, rule := range models.DefaultRiskRules {
+		if slices.Contains(settings.Disabled, rule.ID) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	for _, custom := range settings.Rules {
+		index := slices.IndexFunc(rules, func(rule models.RiskRule) bool { return rule.ID == custom.ID })
+		if index >= 0 {
+			rules[index] = custom
+		} else {
+			rules = append(rules, custom)
+		}
+	}
+
+	service := &RiskService{}
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for risk rule %s: %w", rule.ID, err)
+		}
+		service.rules = append(service.rules, compiledRiskRule{rule: rule, pattern: pattern})
+	}
+
+	return service, nil
+}
+
+// Analyze returns the rules command matches and the highest of their levels.
+func (r *RiskService) Analyze(command string) models.Risk {
+	var risk models.Risk
+	for _, compiled := range r.rules {
+		if !compiled.pattern.MatchString(command) {
+			continue
+		}
+		risk.Rules = append(risk.Rules, compiled.rule)
+		if models.RiskRank(compiled.rule.Level) > models.RiskRank(risk.Level) {
+			risk.Level = compiled.rule.Level
+		}
+	}
+	return risk
+}
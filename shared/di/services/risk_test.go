@@ -0,0 +1,150 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+func matchesRule(risk models.Risk, id string) bool {
+	for _, rule := range risk.Rules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRiskService_DefaultRules(t *testing.T) {
+	tests := map[string]struct {
+		matches []string
+		ignores []string
+	}{
+		"rm_recursive": {
+			matches: []string{"rm -rf /tmp/build", "rm -fr dist", "sudo rm -Rf /var/cache", "rm -r -f node_modules", "rm --recursive --force out"},
+			ignores: []string{"rm file.txt", "rm -r empty_dir", "rm -f lock", "git rm -r --cached ."},
+		},
+		"kubectl_delete": {
+			matches: []string{"kubectl delete pod web-0", "kubectl -n prod delete deploy/api", "kubectl --context staging delete ns demo"},
+			ignores: []string{"kubectl get pods", "kubectl describe pod delete-me"},
+		},
+		"terraform_destroy": {
+			matches: []string{"terraform destroy", "terraform -chdir=infra destroy -auto-approve", "terraform apply -destroy", "tofu destroy"},
+			ignores: []string{"terraform plan", "terraform apply -auto-approve"},
+		},
+		"git_force_push": {
+			matches: []string{"git push --force", "git push -f origin main", "git push --force-with-lease", "git push origin +main"},
+			ignores: []string{"git push origin main", "git push --follow-tags", "git push -u origin feature && git status -f"},
+		},
+		"pipe_to_shell": {
+			matches: []string{"curl -fsSL https://get.example.com | sh", "wget -qO- https://x.sh | bash", "curl https://install.sh | sudo bash"},
+			ignores: []string{"curl -s https://api.example.com | jq .", "curl -o install.sh https://get.example.com"},
+		},
+		"disk_overwrite": {
+			matches: []string{"dd if=image.iso of=/dev/sdb bs=4M", "mkfs.ext4 /dev/sdb1", "sudo wipefs -a /dev/sdc"},
+			ignores: []string{"dd if=/dev/zero of=test.img bs=1M count=10", "echo mkfs"},
+		},
+		"sql_drop": {
+			matches: []string{"DROP TABLE users;", "psql -c 'drop database staging'", "TRUNCATE orders", "truncate table logs"},
+			ignores: []string{"SELECT * FROM dropped_items", "ALTER TABLE users DROP COLUMN age"},
+		},
+		"git_discard": {
+			matches: []string{"git reset --hard HEAD~1", "git reset origin/main --hard", "git clean -fd", "git checkout -- ."},
+			ignores: []string{"git reset HEAD~1", "git clean -n", "git checkout main"},
+		},
+		"docker_prune": {
+			matches: []string{"docker system prune -af", "docker volume prune"},
+			ignores: []string{"docker ps -a", "docker image ls"},
+		},
+		"sudo": {
+			matches: []string{"sudo apt update", "make && sudo make install"},
+			ignores: []string{"echo pseudo code", "visudo"},
+		},
+	}
+
+	service, err := NewRiskService(models.RiskSettings{})
+	if err != nil {
+		t.Fatalf("Failed to create risk service: %v", err)
+	}
+
+	for _, rule := range models.DefaultRiskRules {
+		t.Run(rule.ID, func(t *testing.T) {
+			cases, ok := tests[rule.ID]
+			if !ok {
+				t.Fatalf("Expected test cases for rule %s", rule.ID)
+			}
+			for _, command := range cases.matches {
+				if !matchesRule(service.Analyze(command), rule.ID) {
+					t.Errorf("Expected %q to match", command)
+				}
+			}
+			for _, command := range cases.ignores {
+				if matchesRule(service.Analyze(command), rule.ID) {
+					t.Errorf("Expected %q not to match", command)
+				}
+			}
+		})
+	}
+}
+
+func TestRiskService_Analyze(t *testing.T) {
+	service, err := NewRiskService(models.RiskSettings{})
+	if err != nil {
+		t.Fatalf("Failed to create risk service: %v", err)
+	}
+
+	tests := []struct {
+		command string
+		level   string
+		rules   int
+	}{
+		{"ls -la", models.RiskNone, 0},
+		{"sudo apt upgrade", models.RiskMedium, 1},
+		{"sudo rm -rf /opt/app", models.RiskHigh, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			risk := service.Analyze(tt.command)
+			if risk.Level != tt.level {
+				t.Errorf("Expected level %q, got %q", tt.level, risk.Level)
+			}
+			if len(risk.Rules) != tt.rules {
+				t.Errorf("Expected %d matching rules, got %d", tt.rules, len(risk.Rules))
+			}
+			if risk.IsHigh() != (tt.level == models.RiskHigh) {
+				t.Errorf("Expected IsHigh to be %v", tt.level == models.RiskHigh)
+			}
+		})
+	}
+}
+
+func TestRiskService_ConfiguredRules(t *testing.T) {
+	service, err := NewRiskService(models.RiskSettings{
+		Rules: []models.RiskRule{
+			{ID: "helm_uninstall", Pattern: `\bhelm\s+uninstall\b`, Level: models.RiskHigh},
+			{ID: "sudo", Pattern: `\bsudo\s`, Level: models.RiskHigh},
+		},
+		Disabled: []string{"docker_prune"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create risk service: %v", err)
+	}
+
+	if !service.Analyze("helm uninstall web").IsHigh() {
+		t.Error("Expected configured rule to be added")
+	}
+	if !service.Analyze("sudo ls").IsHigh() {
+		t.Error("Expected configured rule to replace the built-in rule with the same ID")
+	}
+	if risk := service.Analyze("docker system prune"); risk.Level != models.RiskNone {
+		t.Errorf("Expected disabled rule to be skipped, got level %q", risk.Level)
+	}
+
+	_, err = NewRiskService(models.RiskSettings{
+		Rules: []models.RiskRule{{ID: "broken", Pattern: `(`, Level: models.RiskHigh}},
+	})
+	if err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// UsageService tracks per-workflow usage and favorites in a state file next
+// to, but separate from, the synced data file.
+type UsageService struct {
+	filePath string
+	data     models.UsageData
+	now      func() time.Time
+}
+
+func NewUsageService(appName string) (*UsageService, error) {
+	filePath, err := xdg.StateFile(fmt.Sprintf("%s/usage.json", appName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine usage file path: %w", err)
+	}
+
+	service := &UsageService{
+		filePath: filePath,
+		data:     models.NewUsageData(),
+		now:      time.Now,
+	}
+	if err := service.Load(); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (u *UsageService) GetFilePath() string {
+	return u.filePath
+}
+
+func (u *UsageService) Load() error {
+	data, err := os.ReadFile(u.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			u.data = models.NewUsageData()
+			return nil
+		}
+		return fmt.Errorf("failed to read usage file: %w", err)
+	}
+
+	usage := models.NewUsageData()
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &usage); err != nil {
+			return fmt.Errorf("failed to unmarshal usage data: %w", err)
+		}
+	}
+	if usage.Items == nil {
+		usage.Items = map[string]models.ItemUsage{}
+	}
+
+	u.data = usage
+	return nil
+}
+
+func (u *UsageService) Save() error {
+	jsonData, err := json.MarshalIndent(u.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage data: %w", err)
+	}
+
+	if err := os.WriteFile(u.filePath, jsonData, 0o644); err != nil {
+		return fmt.Errorf("failed to save usage file: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UsageService) Get(id string) models.ItemUsage {
+	return u.data.Items[id]
+}
+
+func (u *UsageService) IsFavorite(id string) bool {
+	return u.data.Items[id].Favorite
+}
+
+func (u *UsageService) Frecency(id string) float64 {
+	return u.data.Items[id].Frecency(u.now())
+}
+
+// RecordUse bumps the use count and last-used time of every given item and
+// saves once.
+func (u *UsageService) RecordUse(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	now := u.now()
+	for _, id := range ids {
+		usage := u.data.Items[id]
+		usage.Count++
+		usage.LastUsed = now
+		u.data.Items[id] = usage
+	}
+
+	return u.Save()
+}
+
+// ToggleFavorite flips the favorite flag of an item and returns the new value.
+func (u *UsageService) ToggleFavorite(id string) (bool, error) {
+	usage := u.data.Items[id]
+	usage.Favorite = !usage.Favorite
+	if usage == (models.ItemUsage{}) {
+		delete(u.data.Items, id)
+	} else {
+		u.data.Items[id] = usage
+	}
+
+	if err := u.Save(); err != nil {
+		return !usage.Favorite, err
+	}
+	return usage.Favorite, nil
+}
+
+// Favorites returns the IDs of the favorite items, most frecent first.
+func (u *UsageService) Favorites() []string {
+	now := u.now()
+	var ids []string
+	for id, usage := range u.data.Items {
+		if usage.Favorite {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		left, right := u.data.Items[ids[i]].Frecency(now), u.data.Items[ids[j]].Frecency(now)
+		if left != right {
+			return left > right
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// Recent returns up to limit IDs of used items, most recently used first.
+func (u *UsageService) Recent(limit int) []string {
+	var ids []string
+	for id, usage := range u.data.Items {
+		if usage.Count > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		left, right := u.data.Items[ids[i]].LastUsed, u.data.Items[ids[j]].LastUsed
+		if !left.Equal(right) {
+			return left.After(right)
+		}
+		return ids[i] < ids[j]
+	})
+
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
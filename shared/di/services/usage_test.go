@@ -0,0 +1,146 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+func newTestUsageService(t *testing.T) (*UsageService, *time.Time) {
+	t.Helper()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := &UsageService{
+		filePath: filepath.Join(t.TempDir(), "usage.json"),
+		data:     models.NewUsageData(),
+		now:      func() time.Time { return now },
+	}
+	return service, &now
+}
+
+func TestUsageService_RecordUse(t *testing.T) {
+	service, now := newTestUsageService(t)
+
+	if err := service.RecordUse("item_1", "item_2"); err != nil {
+		t.Fatalf("Failed to record use: %v", err)
+	}
+	*now = now.Add(time.Minute)
+	if err := service.RecordUse("item_1"); err != nil {
+		t.Fatalf("Failed to record use: %v", err)
+	}
+
+	usage := service.Get("item_1")
+	if usage.Count != 2 {
+		t.Errorf("Expected count 2, got %d", usage.Count)
+	}
+	if !usage.LastUsed.Equal(*now) {
+		t.Errorf("Expected last used %v, got %v", *now, usage.LastUsed)
+	}
+	if service.Get("item_2").Count != 1 {
+		t.Errorf("Expected count 1, got %d", service.Get("item_2").Count)
+	}
+	if service.Get("unknown").Count != 0 {
+		t.Error("Expected unknown item to have no usage")
+	}
+}
+
+func TestUsageService_ToggleFavorite(t *testing.T) {
+	service, _ := newTestUsageService(t)
+
+	favorite, err := service.ToggleFavorite("item_1")
+	if err != nil {
+		t.Fatalf("Failed to toggle favorite: %v", err)
+	}
+	if !favorite || !service.IsFavorite("item_1") {
+		t.Error("Expected item to be a favorite")
+	}
+
+	favorite, err = service.ToggleFavorite("item_1")
+	if err != nil {
+		t.Fatalf("Failed to toggle favorite: %v", err)
+	}
+	if favorite || service.IsFavorite("item_1") {
+		t.Error("Expected item not to be a favorite")
+	}
+	if _, exists := service.data.Items["item_1"]; exists {
+		t.Error("Expected empty usage record to be dropped")
+	}
+}
+
+func TestUsageService_FavoritesAndRecent(t *testing.T) {
+	service, now := newTestUsageService(t)
+
+	for _, id := range []string{"item_a", "item_b", "item_c"} {
+		if _, err := service.ToggleFavorite(id); err != nil {
+			t.Fatalf("Failed to toggle favorite: %v", err)
+		}
+	}
+
+	*now = now.Add(-30 * 24 * time.Hour)
+	if err := service.RecordUse("item_c", "item_c", "item_c"); err != nil {
+		t.Fatalf("Failed to record use: %v", err)
+	}
+	*now = now.Add(30 * 24 * time.Hour)
+	if err := service.RecordUse("item_b", "item_b"); err != nil {
+		t.Fatalf("Failed to record use: %v", err)
+	}
+	*now = now.Add(time.Minute)
+	if err := service.RecordUse("item_d"); err != nil {
+		t.Fatalf("Failed to record use: %v", err)
+	}
+
+	expectedFavorites := []string{"item_b", "item_c", "item_a"}
+	if favorites := service.Favorites(); !reflect.DeepEqual(favorites, expectedFavorites) {
+		t.Errorf("Expected favorites %v, got %v", expectedFavorites, favorites)
+	}
+
+	expectedRecent := []string{"item_d", "item_b"}
+	if recent := service.Recent(2); !reflect.DeepEqual(recent, expectedRecent) {
+		t.Errorf("Expected recent %v, got %v", expectedRecent, recent)
+	}
+	if recent := service.Recent(0); len(recent) != 3 {
+		t.Errorf("Expected 3 recent items without a limit, got %d", len(recent))
+	}
+}
+
+func TestUsageService_SaveAndLoad(t *testing.T) {
+	service, _ := newTestUsageService(t)
+
+	if err := service.RecordUse("item_1"); err != nil {
+		t.Fatalf("Failed to record use: %v", err)
+	}
+	if _, err := service.ToggleFavorite("item_1"); err != nil {
+		t.Fatalf("Failed to toggle favorite: %v", err)
+	}
+
+	loaded := &UsageService{filePath: service.filePath, now: service.now}
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Failed to load usage: %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded.Get("item_1"), service.Get("item_1")) {
+		t.Errorf("Expected %+v, got %+v", service.Get("item_1"), loaded.Get("item_1"))
+	}
+}
+
+func TestUsageService_Load_MissingAndCorruptFile(t *testing.T) {
+	service, _ := newTestUsageService(t)
+
+	if err := service.Load(); err != nil {
+		t.Fatalf("Expected missing file to load as empty, got %v", err)
+	}
+	if len(service.data.Items) != 0 {
+		t.Errorf("Expected no usage, got %d records", len(service.data.Items))
+	}
+
+	if err := os.WriteFile(service.filePath, []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := service.Load(); err == nil {
+		t.Error("Expected error for corrupt usage file")
+	}
+}
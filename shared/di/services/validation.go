@@ -2,12 +2,24 @@ package services
 
 import (
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/evertonstz/go-workflows/models"
 )
 
+// variableTags validate the values of variables by type.
+var variableTags = map[string]string{
+	models.VariableInt:  "integer",
+	models.VariableBool: "boolean",
+	models.VariablePath: "excludesall=\x00\n",
+}
+
 type ValidationService struct {
 	validator *validator.Validate
 }
@@ -23,9 +35,37 @@ func NewValidationService() *ValidationService {
 		panic(fmt.Sprintf("failed to register 'alphanum_space_dash_underscore' validation: %v", err))
 	}
 
-	return &ValidationService{
+	if err := v.RegisterValidation("terminal_color", validateTerminalColor); err != nil {
+		panic(fmt.Sprintf("failed to register 'terminal_color' validation: %v", err))
+	}
+
+	if err := v.RegisterValidation("regexp", validateRegexp); err != nil {
+		panic(fmt.Sprintf("failed to register 'regexp' validation: %v", err))
+	}
+
+	if err := v.RegisterValidation("variable_name", validateVariableName); err != nil {
+		panic(fmt.Sprintf("failed to register 'variable_name' validation: %v", err))
+	}
+
+	if err := v.RegisterValidation("integer", validateInteger); err != nil {
+		panic(fmt.Sprintf("failed to register 'integer' validation: %v", err))
+	}
+
+	if err := v.RegisterValidation("working_dir", validateWorkingDir); err != nil {
+		panic(fmt.Sprintf("failed to register 'working_dir' validation: %v", err))
+	}
+
+	if err := v.RegisterValidation("env_name", validateEnvName); err != nil {
+		panic(fmt.Sprintf("failed to register 'env_name' validation: %v", err))
+	}
+
+	service := &ValidationService{
 		validator: v,
 	}
+	v.RegisterStructValidation(service.validateVariableDefault, models.Variable{})
+	v.RegisterStructValidation(validateInterpreterLanguage, models.ItemV2{})
+
+	return service
 }
 
 func (vs *ValidationService) Validate(s interface{}) error {
@@ -36,6 +76,50 @@ func (vs *ValidationService) ValidateVar(field interface{}, tag string) error {
 	return vs.validator.Var(field, tag)
 }
 
+// ValidateVariable checks a value given to variable against its type.
+func (vs *ValidationService) ValidateVariable(variable models.Variable, value string) error {
+	if value == "" {
+		if variable.Required {
+			return fmt.Errorf("%s is required", variable.Name)
+		}
+		return nil
+	}
+
+	kind := variable.Kind()
+	if kind == models.VariableEnum {
+		if !slices.Contains(variable.Choices, value) {
+			return fmt.Errorf("%s must be one of %s", variable.Name, strings.Join(variable.Choices, ", "))
+		}
+		return nil
+	}
+
+	tag, ok := variableTags[kind]
+	if !ok {
+		return nil
+	}
+	if err := vs.validator.Var(value, tag); err != nil {
+		switch kind {
+		case models.VariableInt:
+			return fmt.Errorf("%s must be a whole number", variable.Name)
+		case models.VariableBool:
+			return fmt.Errorf("%s must be true or false", variable.Name)
+		default:
+			return fmt.Errorf("%s must be a valid path", variable.Name)
+		}
+	}
+	return nil
+}
+
+// validateVariableDefault checks that the default of a variable is a valid
+// value for it.
+func (vs *ValidationService) validateVariableDefault(sl validator.StructLevel) {
+	variable := sl.Current().Interface().(models.Variable)
+	variable.Required = false
+	if err := vs.ValidateVariable(variable, variable.Default); err != nil {
+		sl.ReportError(variable.Default, "Default", "Default", "variable_default", variable.Kind())
+	}
+}
+
 func (vs *ValidationService) GetValidationErrors(err error) []string {
 	var errors []string
 
@@ -58,6 +142,8 @@ func (vs *ValidationService) formatValidationError(err validator.FieldError) str
 	switch tag {
 	case "required":
 		return fmt.Sprintf("%s is required", field)
+	case "required_without":
+		return fmt.Sprintf("%s is required when %s is empty", field, param)
 	case "min":
 		if err.Kind().String() == "string" {
 			return fmt.Sprintf("%s must be at least %s characters long", field, param)
@@ -68,12 +154,34 @@ func (vs *ValidationService) formatValidationError(err validator.FieldError) str
 			return fmt.Sprintf("%s must be at most %s characters long", field, param)
 		}
 		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", field, strings.Join(strings.Fields(param), ", "))
 	case "eq":
 		return fmt.Sprintf("%s must be equal to %s", field, param)
 	case "folder_path":
 		return fmt.Sprintf("%s must be a valid folder path (e.g., '/', '/folder', '/folder/subfolder')", field)
 	case "alphanum_space_dash_underscore":
 		return fmt.Sprintf("%s can only contain letters, numbers, spaces, hyphens, and underscores", field)
+	case "terminal_color":
+		return fmt.Sprintf("%s must be an ANSI color number from 0 to 255 or a hex color (e.g., '205', '#ff5f87')", field)
+	case "regexp":
+		return fmt.Sprintf("%s must be a valid regular expression", field)
+	case "required_if":
+		return fmt.Sprintf("%s is required when %s", field, strings.Replace(param, " ", " is ", 1))
+	case "excluded_with":
+		return fmt.Sprintf("%s is not allowed when %s is set", field, param)
+	case "excluded_if":
+		return fmt.Sprintf("%s is not allowed when %s", field, strings.Replace(param, " ", " is ", 1))
+	case "variable_name":
+		return fmt.Sprintf("%s must start with a letter or underscore and contain only letters, numbers and underscores", field)
+	case "variable_default":
+		return fmt.Sprintf("%s must be a valid %s value", field, param)
+	case "working_dir":
+		return fmt.Sprintf("%s must be an absolute path, or start with ~ or a variable (e.g., '/srv/app', '~/src', '$HOME/src', '{{project}}')", field)
+	case "env_name":
+		return fmt.Sprintf("%s must start with a letter or underscore and contain only letters, numbers and underscores", field)
+	case "interpreter_language":
+		return fmt.Sprintf("%s cannot run %s snippets", field, param)
 	default:
 		return fmt.Sprintf("%s failed validation for tag '%s'", field, tag)
 	}
@@ -120,6 +228,57 @@ func validateTagCharacters(fl validator.FieldLevel) bool {
 	return isValidTag(tag)
 }
 
+func validateTerminalColor(fl validator.FieldLevel) bool {
+	color := fl.Field().String()
+	if matched, _ := regexp.MatchString(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`, color); matched {
+		return true
+	}
+	number, err := strconv.Atoi(color)
+	return err == nil && number >= 0 && number <= 255 && strconv.Itoa(number) == color
+}
+
+func validateRegexp(fl validator.FieldLevel) bool {
+	_, err := regexp.Compile(fl.Field().String())
+	return err == nil
+}
+
+func validateVariableName(fl validator.FieldLevel) bool {
+	matched, _ := regexp.MatchString(`^[A-Za-z_][A-Za-z0-9_]*$`, fl.Field().String())
+	return matched
+}
+
+// validateWorkingDir accepts the directories that don't depend on where the
+// application was started.
+func validateWorkingDir(fl validator.FieldLevel) bool {
+	dir := fl.Field().String()
+	if strings.ContainsAny(dir, "\x00\n") {
+		return false
+	}
+	return filepath.IsAbs(dir) || strings.HasPrefix(dir, "~") || strings.HasPrefix(dir, "$") || strings.HasPrefix(dir, "{{")
+}
+
+func validateEnvName(fl validator.FieldLevel) bool {
+	matched, _ := regexp.MatchString(`^[A-Za-z_][A-Za-z0-9_]*$`, fl.Field().String())
+	return matched
+}
+
+// validateInterpreterLanguage checks that the interpreter of an item runs
+// the language it was given.
+func validateInterpreterLanguage(sl validator.StructLevel) {
+	item := sl.Current().Interface().(models.ItemV2)
+	if item.Language == "" || item.Interpreter == "" {
+		return
+	}
+	if models.InterpreterLanguage(item.Interpreter) != item.Language {
+		sl.ReportError(item.Interpreter, "Interpreter", "Interpreter", "interpreter_language", item.Language)
+	}
+}
+
+func validateInteger(fl validator.FieldLevel) bool {
+	_, err := strconv.Atoi(fl.Field().String())
+	return err == nil
+}
+
 func isValidPathSegment(segment string) bool {
 	matched, _ := regexp.MatchString(`^[a-zA-Z0-9\-_\s\.]+$`, segment)
 	return matched
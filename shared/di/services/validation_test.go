@@ -89,6 +89,43 @@ func TestValidationService_ValidateItem(t *testing.T) {
 			expectError:   true,
 			errorContains: "Title must be at most 255 characters long",
 		},
+		{
+			name: "multi-step item without command",
+			item: models.ItemV2{
+				ID:          "test-id",
+				Title:       "Deploy",
+				Steps:       []models.Step{{Title: "Build", Command: "make build"}, {Title: "Ship", Command: "make ship"}},
+				DateAdded:   time.Now(),
+				DateUpdated: time.Now(),
+				FolderPath:  "/",
+			},
+			expectError: false,
+		},
+		{
+			name: "neither command nor steps",
+			item: models.ItemV2{
+				ID:          "test-id",
+				Title:       "Empty",
+				DateAdded:   time.Now(),
+				DateUpdated: time.Now(),
+				FolderPath:  "/",
+			},
+			expectError:   true,
+			errorContains: "Command is required when Steps is empty",
+		},
+		{
+			name: "step without command",
+			item: models.ItemV2{
+				ID:          "test-id",
+				Title:       "Deploy",
+				Steps:       []models.Step{{Title: "Build"}},
+				DateAdded:   time.Now(),
+				DateUpdated: time.Now(),
+				FolderPath:  "/",
+			},
+			expectError:   true,
+			errorContains: "Command is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -234,3 +271,252 @@ func TestValidationService_ValidateFolderPath(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationService_ValidateTerminalColor(t *testing.T) {
+	validationService := NewValidationService()
+
+	tests := []struct {
+		color       string
+		expectValid bool
+	}{
+		{"0", true},
+		{"205", true},
+		{"255", true},
+		{"#fff", true},
+		{"#FF5F87", true},
+		{"256", false},
+		{"-1", false},
+		{"007", false},
+		{"#ff5f8", false},
+		{"pink", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			err := validationService.ValidateVar(tt.color, "terminal_color")
+
+			if tt.expectValid && err != nil {
+				t.Errorf("Expected color '%s' to be valid but got error: %v", tt.color, err)
+			}
+
+			if !tt.expectValid && err == nil {
+				t.Errorf("Expected color '%s' to be invalid but it was accepted", tt.color)
+			}
+		})
+	}
+}
+
+func TestValidationService_ValidateRegexp(t *testing.T) {
+	validationService := NewValidationService()
+
+	tests := []struct {
+		pattern     string
+		expectValid bool
+	}{
+		{`\bhelm\s+uninstall\b`, true},
+		{`^rm -rf`, true},
+		{`(`, false},
+		{`[a-`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			err := validationService.ValidateVar(tt.pattern, "regexp")
+
+			if tt.expectValid && err != nil {
+				t.Errorf("Expected pattern '%s' to be valid but got error: %v", tt.pattern, err)
+			}
+
+			if !tt.expectValid && err == nil {
+				t.Errorf("Expected pattern '%s' to be invalid but it was accepted", tt.pattern)
+			}
+		})
+	}
+}
+
+func TestValidationService_ValidateVariable(t *testing.T) {
+	service := NewValidationService()
+
+	tests := []struct {
+		name          string
+		variable      models.Variable
+		value         string
+		errorContains string
+	}{
+		{"optional string left empty", models.Variable{Name: "message"}, "", ""},
+		{"required string left empty", models.Variable{Name: "message", Required: true}, "", "message is required"},
+		{"int", models.Variable{Name: "replicas", Type: models.VariableInt}, "-3", ""},
+		{"invalid int", models.Variable{Name: "replicas", Type: models.VariableInt}, "3.5", "replicas must be a whole number"},
+		{"bool", models.Variable{Name: "dry_run", Type: models.VariableBool}, "false", ""},
+		{"invalid bool", models.Variable{Name: "dry_run", Type: models.VariableBool}, "maybe", "dry_run must be true or false"},
+		{"enum", models.Variable{Name: "env", Type: models.VariableEnum, Choices: []string{"dev", "prod"}}, "prod", ""},
+		{"invalid enum", models.Variable{Name: "env", Type: models.VariableEnum, Choices: []string{"dev", "prod"}}, "qa", "env must be one of dev, prod"},
+		{"path", models.Variable{Name: "values", Type: models.VariablePath}, "~/charts/values.yaml", ""},
+		{"invalid path", models.Variable{Name: "values", Type: models.VariablePath}, "a\nb", "values must be a valid path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateVariable(tt.variable, tt.value)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestValidationService_ValidateItemVariables(t *testing.T) {
+	service := NewValidationService()
+
+	item := models.ItemV2{
+		ID:          "test-id",
+		Title:       "Scale",
+		Command:     "kubectl scale deploy/{{name}} --replicas={{replicas}}",
+		DateAdded:   time.Now(),
+		DateUpdated: time.Now(),
+		FolderPath:  "/",
+		Variables: []models.Variable{
+			{Name: "name", Required: true},
+			{Name: "replicas", Type: models.VariableInt, Default: "2"},
+		},
+	}
+	if err := service.Validate(item); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		variable      models.Variable
+		errorContains string
+	}{
+		{"invalid name", models.Variable{Name: "2fast"}, "Name must start with a letter or underscore"},
+		{"unknown type", models.Variable{Name: "size", Type: "float"}, "Type must be one of"},
+		{"enum without choices", models.Variable{Name: "env", Type: models.VariableEnum}, "Choices is required when Type is enum"},
+		{"invalid default", models.Variable{Name: "replicas", Type: models.VariableInt, Default: "two"}, "Default must be a valid int value"},
+		{"suggestions for choices", models.Variable{Name: "dry_run", Type: models.VariableBool, Suggest: "echo true"}, "Suggest is not allowed when Type is bool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := item.Clone()
+			invalid.Variables = []models.Variable{tt.variable}
+			err := service.Validate(invalid)
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if message := strings.Join(service.GetValidationErrors(err), "; "); !strings.Contains(message, tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorContains, message)
+			}
+		})
+	}
+}
+
+func TestValidationService_ValidateRunSettings(t *testing.T) {
+	service := NewValidationService()
+
+	item := models.ItemV2{
+		ID:          "test-id",
+		Title:       "Deploy",
+		Command:     "make deploy",
+		DateAdded:   time.Now(),
+		DateUpdated: time.Now(),
+		FolderPath:  "/",
+		RunSettings: models.RunSettings{
+			WorkingDir:  "~/src/{{project}}",
+			Env:         map[string]string{"STAGE": "prod", "_DEBUG": "1"},
+			Interpreter: models.InterpreterBash,
+		},
+	}
+	if err := service.Validate(item); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(item *models.ItemV2)
+		errorContains string
+	}{
+		{"relative directory", func(item *models.ItemV2) { item.WorkingDir = "src/app" }, "WorkingDir must be an absolute path"},
+		{"invalid environment variable", func(item *models.ItemV2) { item.Env = map[string]string{"MY-VAR": "1"} }, "must start with a letter or underscore"},
+		{"unknown interpreter", func(item *models.ItemV2) { item.Interpreter = "ruby" }, "Interpreter must be one of bash, zsh, sh, fish, python"},
+		{"interpreter of another language", func(item *models.ItemV2) { item.Language = models.LanguagePython }, "Interpreter cannot run python snippets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := item.Clone()
+			tt.modify(&invalid)
+			err := service.Validate(invalid)
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if message := strings.Join(service.GetValidationErrors(err), "; "); !strings.Contains(message, tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorContains, message)
+			}
+		})
+	}
+
+	folder := models.FolderV2{
+		ID:          "folder-id",
+		Name:        "Work",
+		Path:        "/Work",
+		DateAdded:   time.Now(),
+		DateUpdated: time.Now(),
+		RunSettings: models.RunSettings{WorkingDir: "relative"},
+	}
+	if err := service.Validate(folder); err == nil {
+		t.Error("Expected the run settings of folders to be validated")
+	}
+}
+
+func TestValidationService_ValidateVariants(t *testing.T) {
+	service := NewValidationService()
+
+	item := models.ItemV2{
+		ID:          "test-id",
+		Title:       "Open report",
+		Command:     "xdg-open report.html",
+		DateAdded:   time.Now(),
+		DateUpdated: time.Now(),
+		FolderPath:  "/",
+		Variants: []models.Variant{
+			{OS: "darwin", Command: "open report.html"},
+			{Shell: models.InterpreterFish, Command: "xdg-open report.html; and echo opened"},
+		},
+	}
+	if err := service.Validate(item); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(item *models.ItemV2)
+		errorContains string
+	}{
+		{"unknown system", func(item *models.ItemV2) { item.Variants[0].OS = "macos" }, "OS must be one of"},
+		{"unknown shell", func(item *models.ItemV2) { item.Variants[1].Shell = "python" }, "Shell must be one of bash, zsh, sh, fish"},
+		{"applies everywhere", func(item *models.ItemV2) { item.Variants[0].OS = "" }, "OS is required when Shell is empty"},
+		{"without command", func(item *models.ItemV2) { item.Variants[0].Command = "" }, "Command is required"},
+		{"with steps", func(item *models.ItemV2) { item.Steps = []models.Step{{Title: "Open", Command: "open"}} }, "Variants is not allowed when Steps is set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := item.Clone()
+			tt.modify(&invalid)
+			err := service.Validate(invalid)
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if message := strings.Join(service.GetValidationErrors(err), "; "); !strings.Contains(message, tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorContains, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/adrg/xdg"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// VariableValuesService remembers the last value given to each variable of a
+// workflow, so that it can be offered the next time.
+type VariableValuesService struct {
+	filePath string
+	data     models.VariableValuesData
+}
+
+func NewVariableValuesService(appName string) (*VariableValuesService, error) {
+	filePath, err := xdg.StateFile(fmt.Sprintf("%s/variables.json", appName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine variables file path: %w", err)
+	}
+
+	service := &VariableValuesService{
+		filePath: filePath,
+		data:     models.NewVariableValuesData(),
+	}
+	if err := service.Load(); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (v *VariableValuesService) Load() error {
+	data, err := os.ReadFile(v.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			v.data = models.NewVariableValuesData()
+			return nil
+		}
+		return fmt.Errorf("failed to read variables file: %w", err)
+	}
+
+	values := models.NewVariableValuesData()
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("failed to unmarshal variables data: %w", err)
+		}
+	}
+	if values.Items == nil {
+		values.Items = map[string]map[string]string{}
+	}
+
+	v.data = values
+	return nil
+}
+
+func (v *VariableValuesService) Save() error {
+	jsonData, err := json.MarshalIndent(v.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal variables data: %w", err)
+	}
+
+	// Values may be secrets, so only the user may read them.
+	if err := os.WriteFile(v.filePath, jsonData, 0o600); err != nil {
+		return fmt.Errorf("failed to save variables file: %w", err)
+	}
+
+	return nil
+}
+
+// Initial returns the value to offer for every variable of item: the last
+// one given, or its default.
+func (v *VariableValuesService) Initial(item models.ItemV2) map[string]string {
+	last := v.data.Items[item.ID]
+	values := map[string]string{}
+	for _, variable := range item.AllVariables() {
+		if value, ok := last[variable.Name]; ok {
+			values[variable.Name] = value
+		} else {
+			values[variable.Name] = variable.Default
+		}
+	}
+	return values
+}
+
+// Remember saves the values given to the variables of the item with the
+// given ID.
+func (v *VariableValuesService) Remember(id string, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	remembered := v.data.Items[id]
+	if remembered == nil {
+		remembered = map[string]string{}
+	}
+	maps.Copy(remembered, values)
+	v.data.Items[id] = remembered
+
+	return v.Save()
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+func TestVariableValuesService_RememberAndInitial(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "variables.json")
+	service := &VariableValuesService{filePath: filePath, data: models.NewVariableValuesData()}
+
+	item := models.ItemV2{
+		ID:      "item_1",
+		Command: "kubectl -n {{namespace}} scale deploy/api --replicas={{replicas}}",
+		Variables: []models.Variable{
+			{Name: "replicas", Type: models.VariableInt, Default: "2"},
+		},
+	}
+
+	expected := map[string]string{"replicas": "2", "namespace": ""}
+	if initial := service.Initial(item); !reflect.DeepEqual(initial, expected) {
+		t.Errorf("Expected defaults %v, got %v", expected, initial)
+	}
+
+	if err := service.Remember(item.ID, map[string]string{"namespace": "prod"}); err != nil {
+		t.Fatalf("Failed to remember values: %v", err)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("Failed to stat the variables file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Expected a variables file only the user can read, got %v", perm)
+	}
+
+	reloaded := &VariableValuesService{filePath: filePath}
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Failed to reload values: %v", err)
+	}
+	expected = map[string]string{"replicas": "2", "namespace": "prod"}
+	if initial := reloaded.Initial(item); !reflect.DeepEqual(initial, expected) {
+		t.Errorf("Expected remembered values %v, got %v", expected, initial)
+	}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/adrg/xdg"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// ViewStateService remembers how each folder is listed, keyed by folder path.
+// Like usage, it lives in the state directory so it never touches the data
+// file.
+type ViewStateService struct {
+	filePath string
+	views    map[string]models.FolderView
+}
+
+func NewViewStateService(appName string) (*ViewStateService, error) {
+	filePath, err := xdg.StateFile(fmt.Sprintf("%s/views.json", appName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine view state file path: %w", err)
+	}
+
+	service := &ViewStateService{
+		filePath: filePath,
+		views:    map[string]models.FolderView{},
+	}
+	if err := service.Load(); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (v *ViewStateService) Load() error {
+	data, err := os.ReadFile(v.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			v.views = map[string]models.FolderView{}
+			return nil
+		}
+		return fmt.Errorf("failed to read view state file: %w", err)
+	}
+
+	views := map[string]models.FolderView{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &views); err != nil {
+			return fmt.Errorf("failed to unmarshal view state: %w", err)
+		}
+	}
+
+	v.views = views
+	return nil
+}
+
+func (v *ViewStateService) Save() error {
+	jsonData, err := json.MarshalIndent(v.views, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal view state: %w", err)
+	}
+
+	if err := os.WriteFile(v.filePath, jsonData, 0o644); err != nil {
+		return fmt.Errorf("failed to save view state file: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the view of path, or the default view when none was stored or
+// the stored sort mode is unknown.
+func (v *ViewStateService) Get(path string) models.FolderView {
+	view, found := v.views[path]
+	if !found || !view.SortMode.IsValid() {
+		return models.DefaultFolderView()
+	}
+	return view
+}
+
+func (v *ViewStateService) Set(path string, view models.FolderView) error {
+	if view == models.DefaultFolderView() {
+		delete(v.views, path)
+	} else {
+		v.views[path] = view
+	}
+	return v.Save()
+}
+
+// Rename moves the stored views of a folder and its descendants to their new
+// paths after the folder was renamed or moved.
+func (v *ViewStateService) Rename(oldPath, newPath string) error {
+	renamed := map[string]string{}
+	for path := range v.views {
+		if newViewPath := rebasePath(path, oldPath, newPath); newViewPath != path {
+			renamed[path] = newViewPath
+		}
+	}
+
+	if len(renamed) == 0 {
+		return nil
+	}
+
+	for path, newViewPath := range renamed {
+		v.views[newViewPath] = v.views[path]
+		delete(v.views, path)
+	}
+	return v.Save()
+}
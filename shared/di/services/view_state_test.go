@@ -0,0 +1,92 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+func newTestViewStateService(t *testing.T) *ViewStateService {
+	t.Helper()
+
+	return &ViewStateService{
+		filePath: filepath.Join(t.TempDir(), "views.json"),
+		views:    map[string]models.FolderView{},
+	}
+}
+
+func TestViewStateService_GetAndSet(t *testing.T) {
+	service := newTestViewStateService(t)
+
+	if view := service.Get("/docs"); view != models.DefaultFolderView() {
+		t.Errorf("Expected default view, got %+v", view)
+	}
+
+	view := models.FolderView{SortMode: models.SortByName, FoldersFirst: false}
+	if err := service.Set("/docs", view); err != nil {
+		t.Fatalf("Failed to set view: %v", err)
+	}
+	if got := service.Get("/docs"); got != view {
+		t.Errorf("Expected %+v, got %+v", view, got)
+	}
+	if got := service.Get("/"); got != models.DefaultFolderView() {
+		t.Errorf("Expected other folders to keep the default view, got %+v", got)
+	}
+
+	if err := service.Set("/docs", models.DefaultFolderView()); err != nil {
+		t.Fatalf("Failed to reset view: %v", err)
+	}
+	if _, exists := service.views["/docs"]; exists {
+		t.Error("Expected default view not to be stored")
+	}
+
+	service.views["/bad"] = models.FolderView{SortMode: "bogus"}
+	if got := service.Get("/bad"); got != models.DefaultFolderView() {
+		t.Errorf("Expected unknown sort mode to fall back to default, got %+v", got)
+	}
+}
+
+func TestViewStateService_SaveAndLoad(t *testing.T) {
+	service := newTestViewStateService(t)
+
+	view := models.FolderView{SortMode: models.SortByUsage, FoldersFirst: true}
+	if err := service.Set("/ops", view); err != nil {
+		t.Fatalf("Failed to set view: %v", err)
+	}
+
+	loaded := &ViewStateService{filePath: service.filePath}
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Failed to load views: %v", err)
+	}
+	if got := loaded.Get("/ops"); got != view {
+		t.Errorf("Expected %+v, got %+v", view, got)
+	}
+}
+
+func TestViewStateService_Rename(t *testing.T) {
+	service := newTestViewStateService(t)
+
+	byName := models.FolderView{SortMode: models.SortByName, FoldersFirst: true}
+	byUsage := models.FolderView{SortMode: models.SortByUsage, FoldersFirst: true}
+	service.views["/infra"] = byName
+	service.views["/infra/aws"] = byUsage
+	service.views["/infrastructure"] = byUsage
+
+	if err := service.Rename("/infra", "/platform/infra"); err != nil {
+		t.Fatalf("Failed to rename: %v", err)
+	}
+
+	if got := service.Get("/platform/infra"); got != byName {
+		t.Errorf("Expected renamed folder view %+v, got %+v", byName, got)
+	}
+	if got := service.Get("/platform/infra/aws"); got != byUsage {
+		t.Errorf("Expected descendant view %+v, got %+v", byUsage, got)
+	}
+	if _, exists := service.views["/infra"]; exists {
+		t.Error("Expected old path to be removed")
+	}
+	if got := service.Get("/infrastructure"); got != byUsage {
+		t.Error("Expected sibling with a shared prefix to be untouched")
+	}
+}
@@ -1,10 +1,25 @@
 package shared
 
-import "github.com/evertonstz/go-workflows/models"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/evertonstz/go-workflows/models"
+)
 
 type (
+	// DidSetCurrentItemMsg selects a workflow. Its RunSettings win over the
+	// ones it inherits from its folders.
 	DidSetCurrentItemMsg struct {
-		Item models.Item
+		Item                 models.Item
+		ItemID               string
+		Language             string
+		Steps                []models.Step
+		Variants             []models.Variant
+		ActiveVariant        int
+		IncludeError         error
+		Risk                 models.Risk
+		RunSettings          models.RunSettings
+		InheritedRunSettings models.RunSettings
 	}
 
 	DidSetCurrentFolderMsg struct {
@@ -23,17 +38,165 @@ type (
 		Title       string
 		Description string
 		CommandText string
+		Language    string
+		Steps       []models.Step
+		Variables   []models.Variable
+		Variants    []models.Variant
+		RunSettings models.RunSettings
 	}
 
 	DidDeleteItemMsg struct {
 		Index int
 	}
 
+	DidAddNewFolderMsg struct {
+		Name        string
+		Description string
+		RunSettings models.RunSettings
+	}
+
+	DidUpdateFolderMsg struct {
+		Path        string
+		Name        string
+		Description string
+		RunSettings models.RunSettings
+	}
+
+	DidDeleteFolderMsg struct {
+		Path string
+	}
+
+	DidCloseFolderFormScreenMsg struct{}
+
+	DidPickFolderMsg struct {
+		Path          string
+		NewFolderName string
+	}
+
+	DidCloseFolderPickerMsg struct{}
+
+	DidDuplicateMsg struct {
+		ItemID     string
+		FolderPath string
+	}
+
+	DidPasteMsg struct {
+		ItemIDs     []string
+		FolderPaths []string
+		Cut         bool
+		Destination string
+	}
+
+	DidDeleteEntriesMsg struct {
+		ItemIDs     []string
+		FolderPaths []string
+	}
+
+	DidMoveEntriesMsg struct {
+		ItemIDs       []string
+		FolderPaths   []string
+		Destination   string
+		NewFolderName string
+	}
+
+	DidSubmitTagFormMsg struct {
+		Tag    string
+		Remove bool
+	}
+
+	DidCloseTagFormMsg struct{}
+
+	// DidRequestVariablesMsg asks for the values of the variables of Item
+	// before Then copies or runs it. Values, when set, are offered instead of
+	// the ones given last.
+	DidRequestVariablesMsg struct {
+		Item   models.ItemV2
+		Values map[string]string
+		Then   func(values map[string]string) tea.Cmd
+	}
+
+	DidSubmitVariableFormMsg struct {
+		Values map[string]string
+	}
+
+	DidCloseVariableFormMsg struct{}
+
+	// DidLoadSuggestionsMsg holds the values suggested by Command, or the
+	// error it failed with.
+	DidLoadSuggestionsMsg struct {
+		Command     string
+		Suggestions []string
+		Err         error
+	}
+
+	DidUpdateTagsMsg struct {
+		ItemIDs    []string
+		AddTags    []string
+		RemoveTags []string
+	}
+
+	DidToggleFavoriteMsg struct {
+		ItemID string
+	}
+
+	DidRequestRunWorkflowMsg struct {
+		ItemID string
+		Resume bool
+		Values map[string]string
+	}
+
+	DidRequestRiskConfirmationMsg struct {
+		Risk    models.Risk
+		Confirm tea.Cmd
+	}
+
+	DidSetFolderViewMsg struct {
+		Path string
+		View models.FolderView
+	}
+
+	DidReorderMsg struct {
+		Path string
+		IDs  []string
+	}
+
+	DidOpenFolderMsg struct {
+		Path string
+	}
+
+	DidCloseFolderTreeMsg struct{}
+
+	DidCloseGoToPromptMsg struct{}
+
+	DidCloseCommandPaletteMsg struct{}
+
+	DidRunActionMsg struct {
+		Key tea.KeyMsg
+	}
+
 	DidCloseConfirmationModalMsg struct{}
 
 	DidCloseAddNewScreenMsg struct{}
 
-	CopiedToClipboardMsg struct{}
+	// CopiedToClipboardMsg reports the provider that copied the text, and
+	// the path of the file it went to when it was the file fallback.
+	CopiedToClipboardMsg struct {
+		ItemIDs  []string
+		Provider string
+		Path     string
+	}
+
+	// RanWorkflowMsg reports that a workflow finished running, with the
+	// error it failed with, if any, the outcome of its steps and the record
+	// of the run for the history.
+	RanWorkflowMsg struct {
+		ItemID string
+		Err    error
+		Steps  []models.StepStatus
+		Record models.HistoryRecord
+	}
+
+	DidCloseHistoryScreenMsg struct{}
 
 	ErrorMsg struct {
 		Err error
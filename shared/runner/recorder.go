@@ -0,0 +1,124 @@
+package runner
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// Command is a command run in the terminal while the program is suspended.
+// It matches tea.ExecCommand.
+type Command interface {
+	Run() error
+	SetStdin(io.Reader)
+	SetStdout(io.Writer)
+	SetStderr(io.Writer)
+}
+
+// Process runs a program in the terminal. The streams it already has are
+// kept, like those of tea.ExecProcess.
+type Process struct {
+	*exec.Cmd
+}
+
+func (p Process) SetStdin(r io.Reader) {
+	if p.Stdin == nil {
+		p.Stdin = r
+	}
+}
+
+func (p Process) SetStdout(w io.Writer) {
+	if p.Stdout == nil {
+		p.Stdout = w
+	}
+}
+
+func (p Process) SetStderr(w io.Writer) {
+	if p.Stderr == nil {
+		p.Stderr = w
+	}
+}
+
+// Recorder runs a command, keeping when it started, how long it ran and,
+// when it keeps any lines, the tail of its output.
+type Recorder struct {
+	command Command
+	lines   int
+	now     func() time.Time
+
+	StartedAt time.Time
+	Duration  time.Duration
+
+	mu     sync.Mutex
+	output []byte
+}
+
+// NewRecorder returns a recorder of command keeping the last lines of its
+// output, none when lines is 0.
+func NewRecorder(command Command, lines int) *Recorder {
+	return &Recorder{command: command, lines: lines, now: time.Now}
+}
+
+func (r *Recorder) SetStdin(reader io.Reader) {
+	r.command.SetStdin(reader)
+}
+
+func (r *Recorder) SetStdout(w io.Writer) {
+	r.command.SetStdout(r.tee(w))
+}
+
+func (r *Recorder) SetStderr(w io.Writer) {
+	r.command.SetStderr(r.tee(w))
+}
+
+func (r *Recorder) tee(w io.Writer) io.Writer {
+	if r.lines == 0 {
+		return w
+	}
+	return io.MultiWriter(w, (*tailWriter)(r))
+}
+
+func (r *Recorder) Run() error {
+	r.StartedAt = r.now()
+	err := r.command.Run()
+	r.Duration = r.now().Sub(r.StartedAt)
+	return err
+}
+
+// Output returns the last lines of the output of the command.
+func (r *Recorder) Output() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return models.TailLines(string(r.output), r.lines, models.HistoryOutputLimit)
+}
+
+// tailWriter keeps the end of the output of a Recorder, the standard output
+// and error interleaved as they are written.
+type tailWriter Recorder
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.output = append(t.output, p...)
+	if len(t.output) > 2*models.HistoryOutputLimit {
+		t.output = append([]byte(nil), t.output[len(t.output)-models.HistoryOutputLimit:]...)
+	}
+	return len(p), nil
+}
+
+// ExitCode returns the exit code of a command that failed with err: 0 when
+// it succeeded and -1 when it did not start or was killed by a signal.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestRecorder_Run(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+
+	process := Process{exec.Command("sh", "-c", "for i in 1 2 3 4; do echo line $i; done; exit 3")}
+	recorder := NewRecorder(process, 2)
+	recorder.now = func() time.Time {
+		current := now
+		now = now.Add(1500 * time.Millisecond)
+		return current
+	}
+
+	var stdout, stderr bytes.Buffer
+	recorder.SetStdout(&stdout)
+	recorder.SetStderr(&stderr)
+	err := recorder.Run()
+
+	if code := ExitCode(err); code != 3 {
+		t.Errorf("Expected exit code 3, got %d (%v)", code, err)
+	}
+	if !recorder.StartedAt.Equal(start) || recorder.Duration != 1500*time.Millisecond {
+		t.Errorf("Expected the run to be timed, got %v for %v", recorder.StartedAt, recorder.Duration)
+	}
+	if stdout.String() != "line 1\nline 2\nline 3\nline 4\n" {
+		t.Errorf("Expected the output to reach the terminal, got %q", stdout.String())
+	}
+	if output := recorder.Output(); output != "line 3\nline 4" {
+		t.Errorf("Expected the last lines of output, got %q", output)
+	}
+}
+
+// The standard output and error go through separate pipes, so the order in
+// which they interleave is not tested.
+func TestRecorder_KeepsErrors(t *testing.T) {
+	recorder := NewRecorder(Process{exec.Command("sh", "-c", "echo oops >&2")}, 2)
+
+	var stdout, stderr bytes.Buffer
+	recorder.SetStdout(&stdout)
+	recorder.SetStderr(&stderr)
+	if err := recorder.Run(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stderr.String() != "oops\n" || recorder.Output() != "oops" {
+		t.Errorf("Expected the error output to be kept, got %q and %q", stderr.String(), recorder.Output())
+	}
+}
+
+func TestRecorder_WithoutOutput(t *testing.T) {
+	var stdout bytes.Buffer
+	recorder := NewRecorder(Process{exec.Command("sh", "-c", "echo hello")}, 0)
+	recorder.SetStdout(&stdout)
+
+	if err := recorder.Run(); ExitCode(err) != 0 {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stdout.String() != "hello\n" || recorder.Output() != "" {
+		t.Errorf("Expected the output not to be kept, got %q", recorder.Output())
+	}
+	if code := ExitCode(exec.Command("/nonexistent/program").Run()); code != -1 {
+		t.Errorf("Expected -1 for a program that does not start, got %d", code)
+	}
+}
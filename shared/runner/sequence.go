@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+// Step is a step of a Sequence, with the program and arguments that run its
+// command. Dir and Env are the working directory and environment of the
+// program, those of the application when empty.
+type Step struct {
+	Title           string
+	Args            []string
+	Dir             string
+	Env             []string
+	ContinueOnError bool
+}
+
+// StepError reports the step that stopped a Sequence.
+type StepError struct {
+	Index int
+	Title string
+	Err   error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %d (%s) failed: %v", e.Index+1, e.Title, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Sequence runs steps one after another in the terminal, stopping at the
+// first step that fails unless it continues on error. It implements
+// tea.ExecCommand, so that the program is suspended while it runs.
+type Sequence struct {
+	Steps []Step
+	// Statuses holds the outcome of every step once the sequence ran.
+	Statuses []models.StepStatus
+
+	start  int
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// NewSequence returns a sequence running steps. When previous holds the
+// statuses of a run that stopped at a failed step, the sequence resumes from
+// that step and keeps the statuses of the steps before it.
+func NewSequence(steps []Step, previous []models.StepStatus) *Sequence {
+	sequence := &Sequence{
+		Steps:    steps,
+		Statuses: make([]models.StepStatus, len(steps)),
+	}
+	if start, ok := models.ResumeStep(previous); ok && len(previous) == len(steps) {
+		sequence.start = start
+		copy(sequence.Statuses[:start], previous[:start])
+	}
+	return sequence
+}
+
+// Start returns the index of the first step the sequence runs.
+func (s *Sequence) Start() int {
+	return s.start
+}
+
+func (s *Sequence) SetStdin(r io.Reader) {
+	s.stdin = r
+}
+
+func (s *Sequence) SetStdout(w io.Writer) {
+	s.stdout = w
+}
+
+func (s *Sequence) SetStderr(w io.Writer) {
+	s.stderr = w
+}
+
+// Run runs the steps, printing the title of each one before it starts and
+// its outcome after it finishes. The error is a *StepError when a step
+// stopped the run.
+func (s *Sequence) Run() error {
+	out := s.stdout
+	if out == nil {
+		out = io.Discard
+	}
+
+	for i := s.start; i < len(s.Steps); i++ {
+		step := s.Steps[i]
+		fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(s.Steps), step.Title)
+
+		command := exec.Command(step.Args[0], step.Args[1:]...)
+		command.Dir = step.Dir
+		command.Env = step.Env
+		command.Stdin = s.stdin
+		command.Stdout = s.stdout
+		command.Stderr = s.stderr
+		if err := command.Run(); err != nil {
+			fmt.Fprintf(out, "✗ %v\n", err)
+			if step.ContinueOnError {
+				s.Statuses[i] = models.StepIgnored
+				continue
+			}
+			s.Statuses[i] = models.StepFailed
+			return &StepError{Index: i, Title: step.Title, Err: err}
+		}
+		fmt.Fprintln(out, "✓")
+		s.Statuses[i] = models.StepSucceeded
+	}
+
+	return nil
+}
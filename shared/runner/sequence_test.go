@@ -0,0 +1,125 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/evertonstz/go-workflows/models"
+)
+
+func shellStep(title, command string, continueOnError bool) Step {
+	return Step{Title: title, Args: []string{"sh", "-c", command}, ContinueOnError: continueOnError}
+}
+
+func TestSequence_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		steps     []Step
+		statuses  []models.StepStatus
+		failed    int
+		output    string
+		expectErr bool
+	}{
+		{
+			name:     "all steps succeed",
+			steps:    []Step{shellStep("First", "echo one", false), shellStep("Second", "echo two", false)},
+			statuses: []models.StepStatus{models.StepSucceeded, models.StepSucceeded},
+			output:   "[1/2] First\none\n✓\n[2/2] Second\ntwo\n✓\n",
+		},
+		{
+			name:      "failing step stops the run",
+			steps:     []Step{shellStep("First", "exit 3", false), shellStep("Second", "echo two", false)},
+			statuses:  []models.StepStatus{models.StepFailed, models.StepPending},
+			failed:    0,
+			output:    "[1/2] First\n✗ exit status 3\n",
+			expectErr: true,
+		},
+		{
+			name:     "continue on error",
+			steps:    []Step{shellStep("First", "exit 1", true), shellStep("Second", "echo two", false)},
+			statuses: []models.StepStatus{models.StepIgnored, models.StepSucceeded},
+			output:   "[1/2] First\n✗ exit status 1\n[2/2] Second\ntwo\n✓\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			sequence := NewSequence(tt.steps, nil)
+			sequence.SetStdout(&out)
+			sequence.SetStderr(&out)
+
+			err := sequence.Run()
+			if tt.expectErr {
+				var stepErr *StepError
+				if !errors.As(err, &stepErr) || stepErr.Index != tt.failed {
+					t.Fatalf("Expected step %d to fail, got %v", tt.failed, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(sequence.Statuses, tt.statuses) {
+				t.Errorf("Expected statuses %v, got %v", tt.statuses, sequence.Statuses)
+			}
+			if out.String() != tt.output {
+				t.Errorf("Expected output %q, got %q", tt.output, out.String())
+			}
+		})
+	}
+}
+
+func TestSequence_Resume(t *testing.T) {
+	steps := []Step{
+		shellStep("First", "echo one", false),
+		shellStep("Second", "echo two", false),
+		shellStep("Third", "echo three", false),
+	}
+	previous := []models.StepStatus{models.StepSucceeded, models.StepFailed, models.StepPending}
+
+	var out bytes.Buffer
+	sequence := NewSequence(steps, previous)
+	sequence.SetStdout(&out)
+	if sequence.Start() != 1 {
+		t.Fatalf("Expected to resume from step 1, got %d", sequence.Start())
+	}
+	if err := sequence.Run(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Contains(out.String(), "First") {
+		t.Errorf("Expected the steps before the failed one to be skipped, got %q", out.String())
+	}
+	expected := []models.StepStatus{models.StepSucceeded, models.StepSucceeded, models.StepSucceeded}
+	if !reflect.DeepEqual(sequence.Statuses, expected) {
+		t.Errorf("Expected statuses %v, got %v", expected, sequence.Statuses)
+	}
+
+	if NewSequence(steps, []models.StepStatus{models.StepFailed}).Start() != 0 {
+		t.Error("Expected statuses of other steps to start from the beginning")
+	}
+}
+
+func TestSequence_DirAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	step := shellStep("Where", `echo "$(pwd) $STAGE"`, false)
+	step.Dir = dir
+	step.Env = []string{"PATH=" + os.Getenv("PATH"), "STAGE=prod"}
+
+	var out bytes.Buffer
+	sequence := NewSequence([]Step{step}, nil)
+	sequence.SetStdout(&out)
+	if err := sequence.Run(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resolved, _ := filepath.EvalSymlinks(dir)
+	if !strings.Contains(out.String(), resolved+" prod\n") && !strings.Contains(out.String(), dir+" prod\n") {
+		t.Errorf("Expected the step to run in %s with its environment, got %q", dir, out.String())
+	}
+}
@@ -0,0 +1,126 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SuggestionTimeout is how long a suggestion command may run.
+	SuggestionTimeout = 5 * time.Second
+	// SuggestionCacheTTL is how long the output of a suggestion command is
+	// reused before the command runs again.
+	SuggestionCacheTTL = time.Minute
+)
+
+type cachedSuggestions struct {
+	values  []string
+	expires time.Time
+}
+
+// Suggestions runs the commands that suggest values for variables, one value
+// per line of output. Successful outputs are cached, so that asking for the
+// same values again doesn't wait for the command. It is safe for concurrent
+// use.
+type Suggestions struct {
+	shell   string
+	timeout time.Duration
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedSuggestions
+}
+
+// NewSuggestions returns a runner of suggestion commands in shell, or in
+// /bin/sh when it is empty.
+func NewSuggestions(shell string, timeout, ttl time.Duration) *Suggestions {
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	return &Suggestions{
+		shell:   shell,
+		timeout: timeout,
+		ttl:     ttl,
+		now:     time.Now,
+		cache:   map[string]cachedSuggestions{},
+	}
+}
+
+// Cached returns the output of command when it ran recently.
+func (s *Suggestions) Cached(command string) ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.cache[command]
+	if !ok || s.now().After(cached.expires) {
+		return nil, false
+	}
+	return cached.values, true
+}
+
+// Forget drops the cached output of command, so that it runs again.
+func (s *Suggestions) Forget(command string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cache, command)
+}
+
+// Load returns the cached output of command, running it when there is none.
+// The command is killed when it runs longer than the timeout.
+func (s *Suggestions) Load(command string) ([]string, error) {
+	if values, ok := s.Cached(command); ok {
+		return values, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.shell, "-c", command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Processes started by the command may keep its output open after it
+	// is killed.
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", s.timeout)
+		}
+		if message := firstLine(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+
+	values := parseSuggestions(stdout.String())
+	s.mu.Lock()
+	s.cache[command] = cachedSuggestions{values: values, expires: s.now().Add(s.ttl)}
+	s.mu.Unlock()
+	return values, nil
+}
+
+// parseSuggestions returns the non-empty lines of output, each once.
+func parseSuggestions(output string) []string {
+	var values []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !slices.Contains(values, line) {
+			values = append(values, line)
+		}
+	}
+	return values
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(line)
+}
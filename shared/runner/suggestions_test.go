@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuggestions_Load(t *testing.T) {
+	suggestions := NewSuggestions("sh", time.Second, time.Minute)
+
+	values, err := suggestions.Load(`printf 'dev\n  staging \n\ndev\nprod\n'`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"dev", "staging", "prod"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestSuggestions_LoadErrors(t *testing.T) {
+	suggestions := NewSuggestions("sh", 200*time.Millisecond, time.Minute)
+
+	_, err := suggestions.Load("echo 'connection refused' >&2; exit 1")
+	if err == nil || !strings.Contains(err.Error(), "exit status 1: connection refused") {
+		t.Errorf("Expected the error output of the command, got %v", err)
+	}
+
+	start := time.Now()
+	_, err = suggestions.Load("sleep 5")
+	if err == nil || !strings.Contains(err.Error(), "timed out after 200ms") {
+		t.Errorf("Expected a timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the command to be killed, it ran for %s", elapsed)
+	}
+
+	if _, ok := suggestions.Cached("sleep 5"); ok {
+		t.Error("Expected failed commands not to be cached")
+	}
+}
+
+func TestSuggestions_Cache(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "runs")
+	command := "echo run >> " + counter + "; echo value"
+
+	now := time.Now()
+	suggestions := NewSuggestions("sh", time.Second, time.Minute)
+	suggestions.now = func() time.Time { return now }
+
+	runs := func() int {
+		data, _ := os.ReadFile(counter)
+		return strings.Count(string(data), "run")
+	}
+
+	for range 2 {
+		if _, err := suggestions.Load(command); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if runs() != 1 {
+		t.Errorf("Expected the output to be cached, the command ran %d times", runs())
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := suggestions.Cached(command); ok {
+		t.Error("Expected the cached output to expire")
+	}
+	suggestions.Load(command)
+	if runs() != 2 {
+		t.Errorf("Expected the command to run again once expired, it ran %d times", runs())
+	}
+
+	suggestions.Forget(command)
+	suggestions.Load(command)
+	if runs() != 3 {
+		t.Errorf("Expected the command to run again once forgotten, it ran %d times", runs())
+	}
+}
@@ -5,9 +5,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	helpkeys "github.com/evertonstz/go-workflows/components/keys"
+	"github.com/evertonstz/go-workflows/components/list"
 	"github.com/evertonstz/go-workflows/components/notification"
 	commandlist "github.com/evertonstz/go-workflows/screens/command_list"
 	"github.com/evertonstz/go-workflows/shared"
+	"github.com/evertonstz/go-workflows/shared/di"
+	"github.com/evertonstz/go-workflows/shared/di/services"
 	"github.com/evertonstz/go-workflows/shared/messages"
 )
 
@@ -21,13 +24,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.screenState = newList
 	case shared.DidAddNewItemMsg:
 		m.screenState = newList
-		updatedListModel, _ := m.listScreen.Update(msg)
+		updatedListModel, cmd := m.listScreen.Update(msg)
+		m.listScreen = updatedListModel.(commandlist.Model)
+		return m, tea.Batch(cmd, m.persistedItemsV2())
+	case shared.DidCloseFolderFormScreenMsg:
+		m.screenState = newList
+	case shared.DidCloseHistoryScreenMsg:
+		m.screenState = newList
+	case shared.DidRequestRunWorkflowMsg:
+		m.screenState = newList
+		updatedListModel, cmd := m.listScreen.Update(msg)
 		m.listScreen = updatedListModel.(commandlist.Model)
-		return m, m.persistItemsV2()
+		return m, cmd
+	case shared.DidAddNewFolderMsg, shared.DidUpdateFolderMsg:
+		m.screenState = newList
+		updatedListModel, cmd := m.listScreen.Update(msg)
+		m.listScreen = updatedListModel.(commandlist.Model)
+		return m, tea.Batch(cmd, m.persistedItemsV2())
+	case shared.DidDeleteFolderMsg, shared.DidDuplicateMsg, shared.DidPasteMsg,
+		shared.DidMoveEntriesMsg, shared.DidDeleteEntriesMsg, shared.DidUpdateTagsMsg, shared.DidReorderMsg:
+		updatedListModel, cmd := m.listScreen.Update(msg)
+		m.listScreen = updatedListModel.(commandlist.Model)
+		return m, tea.Batch(cmd, m.persistedItemsV2())
 	case shared.DidDeleteItemMsg:
-		updatedListModel, _ := m.listScreen.Update(msg)
+		updatedListModel, cmd := m.listScreen.Update(msg)
 		m.listScreen = updatedListModel.(commandlist.Model)
-		return m, m.persistItemsV2()
+		return m, tea.Batch(cmd, m.persistedItemsV2())
 	case shared.DidNavigateToFolderMsg:
 		m.currentPath = msg.Path
 		m.notification.SetDefaultText(m.notificationTitle())
@@ -35,7 +57,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.listScreen = updatedListModel.(commandlist.Model)
 		return m, nil
 	case shared.CopiedToClipboardMsg:
-		return m, notification.ShowNotificationCmd("Copied to clipboard!")
+		updatedListModel, cmd := m.listScreen.Update(msg)
+		m.listScreen = updatedListModel.(commandlist.Model)
+		i18n := di.GetService[*services.I18nService](di.I18nServiceKey)
+		message := i18n.TranslateWithData("notification_copied_to_clipboard", map[string]interface{}{"Provider": msg.Provider})
+		if msg.Path != "" {
+			message = i18n.TranslateWithData("notification_copied_to_file", map[string]interface{}{"Path": msg.Path})
+		}
+		return m, tea.Batch(cmd, notification.ShowNotificationCmd(message))
 	case messages.PersistedFileV2Msg:
 		return m, notification.ShowNotificationCmd("Saved!")
 	case messages.PersistedFileMsg:
@@ -54,7 +83,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case shared.DidUpdateItemMsg:
 		updatedListModel, _ := m.listScreen.Update(msg)
 		m.listScreen = updatedListModel.(commandlist.Model)
-		return m, m.persistItemsV2()
+		return m, m.persistedItemsV2()
 	case shared.DidSetCurrentItemMsg:
 		updatedListModel, _ := m.listScreen.Update(msg)
 		m.listScreen = updatedListModel.(commandlist.Model)
@@ -79,16 +108,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Let the add new screen handle its own keys
 				// The screen update will be called later in the method
 			}
+		case folderForm:
+			if key.Matches(msg, m.folderFormScreen.Keys.Help) {
+				m.toggleHelpShowAll()
+				return m, nil
+			}
+		case runHistory:
+			if key.Matches(msg, m.historyScreen.Keys.Help) {
+				m.toggleHelpShowAll()
+				return m, nil
+			}
 		case newList:
+			if m.listScreen.IsCapturingInput() {
+				break
+			}
+
 			switch {
 			case key.Matches(msg, helpkeys.LisKeys.AddNewWorkflow):
 				m.screenState = addNew
 				return m, nil
+			case key.Matches(msg, helpkeys.LisKeys.History):
+				m.screenState = runHistory
+				return m, m.historyScreen.Open()
+			case key.Matches(msg, helpkeys.LisKeys.NewFolder):
+				m.folderFormScreen.ResetForm()
+				m.screenState = folderForm
+				return m, nil
+			case key.Matches(msg, helpkeys.LisKeys.EditFolder):
+				currentItem := m.listScreen.CurrentItem()
+				if folderItem, ok := currentItem.(list.FolderItem); ok && !folderItem.IsVirtual() {
+					m.folderFormScreen.SetFolder(folderItem.GetFolder())
+					m.screenState = folderForm
+				}
+				return m, nil
 			case key.Matches(msg, m.listScreen.Keys.Help):
 				m.toggleHelpShowAll()
 				return m, nil
 			case key.Matches(msg, m.listScreen.Keys.Esc):
-				if m.listScreen.IsAtRoot() {
+				if m.listScreen.IsAtRoot() && !m.listScreen.HasSelection() {
 					return m, tea.Quit
 				}
 			// TODO add edition function
@@ -115,6 +172,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		addNewScreenModel, addNewScreenCmd := m.addNewScreen.Update(msg)
 		cmds = append(cmds, addNewScreenCmd)
 		m.addNewScreen = addNewScreenModel
+	case folderForm:
+		folderFormScreenModel, folderFormScreenCmd := m.folderFormScreen.Update(msg)
+		cmds = append(cmds, folderFormScreenCmd)
+		m.folderFormScreen = folderFormScreenModel
+	case runHistory:
+		historyScreenModel, historyScreenCmd := m.historyScreen.Update(msg)
+		cmds = append(cmds, historyScreenCmd)
+		m.historyScreen = historyScreenModel
 	case newList:
 		var cmd tea.Cmd
 		updatedListScreenModel, cmd := m.listScreen.Update(msg)
@@ -18,6 +18,24 @@ func (m model) View() string {
 				lipgloss.Center,
 				m.addNewScreen.View()),
 			helpView)
+	case folderForm:
+		return lipgloss.JoinVertical(lipgloss.Left,
+			notificationView,
+			lipgloss.Place(m.termDimensions.width,
+				m.termDimensions.height-(m.panelsStyle.notificationPanelStyle.GetHeight()+m.currentHelpHeight),
+				lipgloss.Center,
+				lipgloss.Center,
+				m.folderFormScreen.View()),
+			helpView)
+	case runHistory:
+		return lipgloss.JoinVertical(lipgloss.Left,
+			notificationView,
+			lipgloss.Place(m.termDimensions.width,
+				m.termDimensions.height-(m.panelsStyle.notificationPanelStyle.GetHeight()+m.currentHelpHeight),
+				lipgloss.Center,
+				lipgloss.Center,
+				m.historyScreen.View()),
+			helpView)
 	case newList:
 		return lipgloss.JoinVertical(lipgloss.Left,
 			notificationView,